@@ -9,10 +9,18 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/embedding"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/handler"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/llm"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/metadata"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/scheduler"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/transcoder"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/urlsign"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
@@ -69,14 +77,123 @@ func main() {
 	// 3. Repositories
 	userRepo := repository.NewUserRepository(db)
 	movieRepo := repository.NewMovieRepository(db)
+	activityRepo := repository.NewActivityRepository(db)
+	similarityRepo := repository.NewSimilarityRepository(db)
+	eventRepo := repository.NewEventRepository(db)
+	embeddingRepo := repository.NewEmbeddingRepository(db)
+	personRepo := repository.NewPersonRepository(db)
+	seriesRepo := repository.NewSeriesRepository(db)
+	collectionRepo := repository.NewCollectionRepository(db)
+	genreRepo := repository.NewGenreRepository(db)
+	uploadRepo := repository.NewUploadRepository(db)
+	transcodeRepo := repository.NewTranscodeRepository(db)
+	searchIndex, err := search.New(cfg.SearchBackend, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	vectorIndex := search.NewVectorIndex()
+	embedder, err := embedding.New(cfg)
+	if err != nil {
+		log.Printf("semantic search disabled: %v", err)
+	}
+	chatModel, err := llm.New(cfg.OpenAPIKey, cfg.AssistantModel)
+	if err != nil {
+		// The rest of the API works without it, so the assistant is only switched off.
+		log.Printf("assistant disabled: %v", err)
+	}
+	draftModel, err := llm.New(cfg.OpenAPIKey, cfg.DraftModel)
+	if err != nil {
+		log.Printf("AI drafts disabled: %v", err)
+	}
+	metadataProvider, err := metadata.New(cfg)
+	if err != nil {
+		log.Printf("metadata lookups disabled: %v", err)
+	}
+	blobStore, err := storage.New(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	streamSigner, err := urlsign.New(cfg.StreamSigningKeys)
+	if err != nil {
+		log.Printf("signed stream URLs disabled: %v", err)
+	}
+	mediaTranscoder, err := transcoder.New(cfg)
+	if err != nil {
+		// Uploads then play as they are.
+		log.Printf("transcoding disabled: %v", err)
+	}
 
 	// 4. Services
-	userService := service.NewUserService(userRepo, cfg)
-	movieService := service.NewMovieService(movieRepo, userRepo, cfg)
+	userService := service.NewUserService(userRepo, genreRepo, cfg)
+	recommender, err := service.NewRecommender(movieRepo, userRepo, activityRepo, similarityRepo, vectorIndex, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	searchService := service.NewSearchService(searchIndex, movieRepo)
+	semanticService := service.NewSemanticService(embedder, embeddingRepo, movieRepo, vectorIndex)
+	movieService := service.NewMovieService(movieRepo, genreRepo, userRepo, activityRepo, eventRepo, recommender, []service.MovieIndexer{searchService, semanticService}, cfg)
+	homeService, err := service.NewHomeService(movieRepo, userRepo, activityRepo, eventRepo, collectionRepo, recommender, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	trendingService := service.NewTrendingService(eventRepo, movieRepo, cfg)
+	// Without embeddings the assistant picks candidates from the user's favourites instead.
+	var assistantSemantic service.SemanticService
+	if embedder != nil {
+		assistantSemantic = semanticService
+	}
+	assistantService := service.NewAssistantService(movieRepo, userRepo, assistantSemantic, chatModel, cfg)
+	// Services that change movies list the movie service as an indexer, which drops the
+	// movie's cached similar titles.
+	personService := service.NewPersonService(personRepo, movieRepo, []service.MovieIndexer{searchService, semanticService, movieService})
+	streamService := service.NewStreamService(movieRepo, seriesRepo, blobStore, streamSigner, cfg)
+	seriesService := service.NewSeriesService(seriesRepo, movieRepo, activityRepo, eventRepo, blobStore, streamService, []service.MovieIndexer{searchService, semanticService, movieService})
+	collectionService := service.NewCollectionService(collectionRepo, movieRepo)
+	// Embeddings of imported movies are left to the periodic sync rather than one API call per row.
+	importService := service.NewImportService(movieRepo, genreRepo, []service.MovieIndexer{searchService, movieService})
+	genreService := service.NewGenreService(genreRepo, movieRepo, userRepo, []service.MovieIndexer{searchService, semanticService, movieService})
+	draftService := service.NewDraftService(movieRepo, draftModel, cfg.DraftModel, []service.MovieIndexer{searchService, semanticService, movieService})
+	metadataService := service.NewMetadataService(metadataProvider, movieRepo, genreRepo, []service.MovieIndexer{searchService, semanticService, movieService})
+	imageService := service.NewImageService(movieRepo, blobStore, cfg)
+	transcodeService := service.NewTranscodeService(transcodeRepo, movieRepo, blobStore, mediaTranscoder, streamService, cfg)
+	uploadService := service.NewUploadService(uploadRepo, movieRepo, blobStore, transcodeService, streamService, cfg)
+	subtitleService := service.NewSubtitleService(movieRepo, blobStore, cfg)
+
+	// Background jobs
+	jobs := scheduler.New()
+	similarityJob := service.NewSimilarityJob(activityRepo, similarityRepo, cfg.SimilarityNeighbors)
+	jobs.Every("movie-similarities", cfg.SimilarityJobInterval, similarityJob.Run)
+	trendingJob := service.NewTrendingJob(eventRepo, movieRepo)
+	jobs.Every("trending-scores", cfg.TrendingJobInterval, trendingJob.Run)
+	jobs.Every("search-index", cfg.SearchReindexInterval, searchService.Reindex)
+	jobs.Every("movie-embeddings", cfg.EmbeddingJobInterval, semanticService.Sync)
+	if err := transcodeService.RequeueInterrupted(context.Background()); err != nil {
+		log.Printf("failed to requeue transcode jobs: %v", err)
+	}
+	jobs.Every("transcode-jobs", cfg.TranscodeJobInterval, transcodeService.RunQueue)
+	jobs.Start(context.Background())
+	defer jobs.Stop()
 
 	// 5. Handlers
 	userHandler := handler.NewUserHandler(userService)
-	movieHandler := handler.NewMovieHandler(movieService)
+	movieHandler := handler.NewMovieHandler(movieService, draftService, metadataService)
+	homeHandler := handler.NewHomeHandler(homeService)
+	trendingHandler := handler.NewTrendingHandler(trendingService)
+	searchHandler := handler.NewSearchHandler(searchService, semanticService)
+	assistantHandler := handler.NewAssistantHandler(assistantService)
+	draftHandler := handler.NewDraftHandler(draftService)
+	personHandler := handler.NewPersonHandler(personService)
+	seriesHandler := handler.NewSeriesHandler(seriesService)
+	collectionHandler := handler.NewCollectionHandler(collectionService)
+	genreHandler := handler.NewGenreHandler(genreService)
+	importHandler := handler.NewImportHandler(importService)
+	exportHandler := handler.NewExportHandler(movieService)
+	metadataHandler := handler.NewMetadataHandler(metadataService)
+	imageHandler := handler.NewImageHandler(imageService)
+	streamHandler := handler.NewStreamHandler(streamService)
+	uploadHandler := handler.NewUploadHandler(uploadService)
+	transcodeHandler := handler.NewTranscodeHandler(transcodeService)
+	subtitleHandler := handler.NewSubtitleHandler(subtitleService)
 
 	// 6. Router
 	router := gin.Default()
@@ -84,9 +201,9 @@ func main() {
 	// CORS
 	corsConfig := cors.Config{
 		AllowOrigins:     cfg.AllowedOrigins,
-		AllowMethods:     []string{"GET", "POST", "PATCH", "DELETE", "PUT", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "HEAD", "POST", "PATCH", "DELETE", "PUT", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Range", "Tus-Resumable", "Upload-Length", "Upload-Metadata", "Upload-Offset"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Disposition", "Content-Range", "Accept-Ranges", "Location", "Tus-Resumable", "Tus-Version", "Tus-Extension", "Tus-Max-Size", "Upload-Offset", "Upload-Length", "Upload-Metadata"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
@@ -112,19 +229,101 @@ func main() {
 	router.POST("/register", userHandler.RegisterUser)
 	router.POST("/login", userHandler.LoginUser)
 	router.POST("/user/logout", userHandler.LogoutHandler)
-	router.GET("/genres", movieHandler.GetAllGenres)
+	router.GET("/genres", genreHandler.GetGenres)
+	router.GET("/genres/:id", genreHandler.GetGenre)
 	router.GET("/movies", movieHandler.GetMovies)
+	router.GET("/movies/trending", trendingHandler.GetTrending)
+	router.GET("/movies/search", searchHandler.Search)
+	router.GET("/movies/suggest", searchHandler.Suggest)
+	router.GET("/movies/semantic-search", searchHandler.SemanticSearch)
+	router.GET("/movies/facets", movieHandler.GetFacets)
+	router.GET("/collections", collectionHandler.GetCollections)
+	router.GET("/collections/:id", collectionHandler.GetCollection)
+	router.GET("/images/*path", imageHandler.GetImage)
+	router.OPTIONS("/uploads", uploadHandler.UploadOptions)
+
+	// Optionally authenticated: personalized when a token is sent
+	optional := router.Group("/")
+	optional.Use(middleware.NewOptionalAuthMiddleware(cfg))
+	{
+		optional.GET("/home", homeHandler.GetHome)
+		optional.GET("/home/rails/:type", homeHandler.GetRail)
+	}
+
+	// Streams: a token or a signed URL from POST /movie/:imdb_id/stream-url
+	streams := router.Group("/stream")
+	streams.Use(middleware.NewStreamAuthMiddleware(cfg, streamSigner))
+	{
+		streams.GET("/:imdb_id", streamHandler.Stream)
+		streams.GET("/:imdb_id/*path", streamHandler.StreamFile)
+	}
+	// Players fetch subtitle tracks like streams, so the same signed URLs work for them.
+	router.GET("/movie/:imdb_id/subtitles/:lang", middleware.NewStreamAuthMiddleware(cfg, streamSigner), subtitleHandler.GetSubtitles)
 
 	// Protected
 	protected := router.Group("/")
 	protected.Use(middleware.NewAuthMiddleware(cfg))
 	{
 		protected.GET("/movie/:imdb_id", movieHandler.GetMovie)
+		protected.GET("/movie/:imdb_id/similar", movieHandler.GetSimilarMovies)
 		protected.POST("/movie", movieHandler.AddMovie)
 		protected.GET("/recommendedMovies", movieHandler.GetRecommendedMovies)
+		protected.GET("/recommendedMovies/:imdb_id/explain", movieHandler.ExplainRecommendation)
+		protected.POST("/movie/:imdb_id/watch", movieHandler.RecordWatch)
+		protected.POST("/events", trendingHandler.RecordEvent)
+		protected.POST("/movie/:imdb_id/reviews", movieHandler.AddReview)
+		protected.POST("/assistant/chat", assistantHandler.Chat)
+		protected.DELETE("/assistant/chat", assistantHandler.ResetChat)
 
 		// Additional routes that existed in controllers but weren't wired
 		protected.PATCH("/movie/:imdb_id/review", movieHandler.UpdateAdminReview)
+		protected.POST("/movie/:imdb_id/enrich", metadataHandler.EnrichMovie)
+		protected.POST("/movie/:imdb_id/poster", imageHandler.UploadPoster)
+		protected.POST("/movie/:imdb_id/backdrops", imageHandler.UploadBackdrop)
+		protected.PUT("/movie/:imdb_id/stream", streamHandler.SetStreamSource)
+		protected.POST("/movie/:imdb_id/stream-url", streamHandler.SignPlaybackURL)
+		protected.POST("/movie/:imdb_id/transcode", transcodeHandler.TranscodeMovie)
+		protected.GET("/movie/:imdb_id/transcode-jobs", transcodeHandler.GetMovieTranscodeJobs)
+		protected.PUT("/movie/:imdb_id/subtitles/:lang", subtitleHandler.UploadSubtitles)
+		protected.PATCH("/movie/:imdb_id/subtitles/:lang", subtitleHandler.UpdateSubtitles)
+		protected.DELETE("/movie/:imdb_id/subtitles/:lang", subtitleHandler.DeleteSubtitles)
+		protected.GET("/movie/:imdb_id/ai-draft", draftHandler.GetDraft)
+		protected.POST("/movie/:imdb_id/ai-draft", draftHandler.GenerateDraft)
+		protected.DELETE("/movie/:imdb_id/ai-draft", draftHandler.DiscardDraft)
+		protected.POST("/movie/:imdb_id/ai-draft/approve", draftHandler.ApproveDraft)
+		protected.GET("/movie/:imdb_id/credits", personHandler.GetMovieCredits)
+		protected.POST("/movie/:imdb_id/credits", personHandler.AddCredit)
+		protected.DELETE("/movie/:imdb_id/credits/:credit_id", personHandler.DeleteCredit)
+		protected.GET("/people", personHandler.GetPeople)
+		protected.GET("/people/:id", personHandler.GetPerson)
+		protected.POST("/people", personHandler.CreatePerson)
+		protected.PUT("/people/:id", personHandler.UpdatePerson)
+		protected.DELETE("/people/:id", personHandler.DeletePerson)
+		protected.POST("/collections", collectionHandler.CreateCollection)
+		protected.PUT("/collections/:id", collectionHandler.UpdateCollection)
+		protected.DELETE("/collections/:id", collectionHandler.DeleteCollection)
+		protected.POST("/genres", genreHandler.CreateGenre)
+		protected.PUT("/genres/:id", genreHandler.RenameGenre)
+		protected.DELETE("/genres/:id", genreHandler.DeleteGenre)
+		protected.POST("/genres/:id/merge", genreHandler.MergeGenre)
+		protected.GET("/series/:imdb_id", seriesHandler.GetSeries)
+		protected.GET("/series/:imdb_id/next-episode", seriesHandler.GetNextEpisode)
+		protected.GET("/series/:imdb_id/seasons/:season", seriesHandler.GetSeason)
+		protected.PUT("/series/:imdb_id/seasons/:season", seriesHandler.SaveSeason)
+		protected.DELETE("/series/:imdb_id/seasons/:season", seriesHandler.DeleteSeason)
+		protected.GET("/series/:imdb_id/seasons/:season/episodes/:episode", seriesHandler.GetEpisode)
+		protected.PUT("/series/:imdb_id/seasons/:season/episodes/:episode", seriesHandler.SaveEpisode)
+		protected.DELETE("/series/:imdb_id/seasons/:season/episodes/:episode", seriesHandler.DeleteEpisode)
+		protected.POST("/series/:imdb_id/seasons/:season/episodes/:episode/watch", seriesHandler.RecordEpisodeWatch)
+		protected.POST("/admin/import/movies", importHandler.ImportMovies)
+		protected.POST("/uploads", uploadHandler.CreateUpload)
+		protected.HEAD("/uploads/:id", uploadHandler.GetUploadOffset)
+		protected.PATCH("/uploads/:id", uploadHandler.WriteUploadChunk)
+		protected.DELETE("/uploads/:id", uploadHandler.DeleteUpload)
+		protected.GET("/transcode-jobs/:id", transcodeHandler.GetTranscodeJob)
+		protected.POST("/transcode-jobs/:id/cancel", transcodeHandler.CancelTranscodeJob)
+		protected.POST("/transcode-jobs/:id/retry", transcodeHandler.RetryTranscodeJob)
+		protected.GET("/admin/export/movies", exportHandler.ExportMovies)
 		protected.POST("/user/refresh-token", userHandler.RefreshTokenHandler)
 	}
 
@@ -0,0 +1,62 @@
+// Command enrich generates AI drafts (synopsis, keywords and mood tags) for the catalog in bulk.
+// Drafts still have to be approved by an admin before they are published.
+//
+//	go run ./cmd/enrich            # movies without a synopsis or a pending draft
+//	go run ./cmd/enrich -overwrite # every movie
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/llm"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func main() {
+	overwrite := flag.Bool("overwrite", false, "regenerate drafts for movies that already have a synopsis or a draft")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	model, err := llm.New(cfg.OpenAPIKey, cfg.DraftModel)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	client, err := mongo.Connect(options.Client().ApplyURI(cfg.MongoURI))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		if err := client.Disconnect(context.Background()); err != nil {
+			log.Println(err)
+		}
+	}()
+	pingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := client.Ping(pingCtx, nil); err != nil {
+		log.Fatal(err)
+	}
+
+	movieRepo := repository.NewMovieRepository(client.Database(cfg.DatabaseName))
+	// Drafts are not indexed until they are approved, so no indexers are needed here.
+	drafts := service.NewDraftService(movieRepo, model, cfg.DraftModel, nil)
+
+	generated, err := drafts.GenerateMissingDrafts(ctx, *overwrite)
+	fmt.Printf("generated %d drafts\n", generated)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,95 @@
+// Command import upserts movies from a CSV, JSON Lines or IMDb title.basics.tsv file, optionally
+// gzipped. Failed rows are listed on stderr and make the command exit with status 1.
+//
+//	go run ./cmd/import -file movies.csv
+//	go run ./cmd/import -file title.basics.tsv.gz -dry-run
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/importer"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func main() {
+	path := flag.String("file", "", "file to import")
+	format := flag.String("format", "", "csv, jsonl or imdb; inferred from the file name when empty")
+	dryRun := flag.Bool("dry-run", false, "validate the rows without writing them")
+	flag.Parse()
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	reader, err := importer.Open(file, *path, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg := config.LoadConfig()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	client, err := mongo.Connect(options.Client().ApplyURI(cfg.MongoURI))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		if err := client.Disconnect(context.Background()); err != nil {
+			log.Println(err)
+		}
+	}()
+	pingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := client.Ping(pingCtx, nil); err != nil {
+		log.Fatal(err)
+	}
+
+	db := client.Database(cfg.DatabaseName)
+	// The API's search index and embeddings pick imported movies up on their next scheduled run.
+	imports := service.NewImportService(repository.NewMovieRepository(db), repository.NewGenreRepository(db), nil)
+
+	report, err := imports.ImportMovies(ctx, reader, *dryRun)
+	if report != nil {
+		printReport(report)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func printReport(report *models.ImportReport) {
+	for _, rowErr := range report.Errors {
+		fmt.Fprintf(os.Stderr, "line %d %s: %s\n", rowErr.Line, rowErr.ImdbID, rowErr.Error)
+	}
+	if report.ErrorsTruncated {
+		fmt.Fprintf(os.Stderr, "... only the first %d errors are listed\n", len(report.Errors))
+	}
+	mode := ""
+	if report.DryRun {
+		mode = " (dry run, nothing written)"
+	}
+	fmt.Printf("%d rows: %d created, %d updated, %d skipped, %d failed%s\n",
+		report.Rows, report.Created, report.Updated, report.Skipped, report.Failed, mode)
+}
@@ -0,0 +1,61 @@
+// Command migrate applies pending data migrations to the configured database.
+//
+//	go run ./cmd/migrate        # apply pending migrations
+//	go run ./cmd/migrate -list  # only list them
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/migrations"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func main() {
+	list := flag.Bool("list", false, "list pending migrations without applying them")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	client, err := mongo.Connect(options.Client().ApplyURI(cfg.MongoURI))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		if err := client.Disconnect(context.Background()); err != nil {
+			log.Println(err)
+		}
+	}()
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatal(err)
+	}
+	db := client.Database(cfg.DatabaseName)
+
+	if *list {
+		pending, err := migrations.Pending(ctx, db, migrations.All)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range pending {
+			fmt.Printf("%s\t%s\n", m.ID, m.Description)
+		}
+		return
+	}
+
+	applied, err := migrations.Run(ctx, db, migrations.All)
+	for _, id := range applied {
+		fmt.Println("applied", id)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%d migrations applied\n", len(applied))
+}
@@ -24,65 +24,72 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/genres": {
+        "/admin/export/movies": {
             "get": {
-                "description": "Get a list of all unique genres",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "movies"
-                ],
-                "summary": "Get all genres",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Login user and return tokens",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Download the movies matching the filters, ordered by title, as CSV, JSON Lines or an Excel workbook. The file is streamed as it is read from the database. Columns: imdb_id, content_type, title, genre, ranking_value, ranking_name, admin_review, average_rating, review_count, release_date, runtime, maturity_rating, original_language, country, synopsis, keywords, mood_tags, poster_path, youtube_id, backdrops, created_at, updated_at. List values are separated by \"|\" in CSV and Excel, and an edited CSV export can be imported again.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "users"
+                    "admin"
                 ],
-                "summary": "Login user",
+                "summary": "Export the catalog (Admin only)",
                 "parameters": [
                     {
-                        "description": "User Login Credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.UserLogin"
-                        }
+                        "type": "string",
+                        "description": "csv (default), jsonl or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns to include, in order (default all)",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Genre names",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ranking names",
+                        "name": "ranking",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Release decades, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Average user rating buckets, 1 to 5",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content types: movie or series",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.UserResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -92,8 +99,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -109,89 +116,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/movie": {
+        "/admin/import/movies": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new movie to the database",
+                "description": "Upsert movies by imdb_id from a CSV, JSON Lines or IMDb title.basics.tsv file, optionally gzipped. The file is streamed, so it can be of any size. Each row is applied over the stored movie and validated like a movie added through POST /movie; rows that fail are listed in the report and the others are imported. CSV files start with a header of movie JSON field names, with list values (genre, keywords, backdrops) separated by \"|\" and genres given by ID or name.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movies"
+                    "admin"
                 ],
-                "summary": "Add a new movie",
+                "summary": "Bulk import movies (Admin only)",
                 "parameters": [
                     {
-                        "description": "Movie Data",
-                        "name": "movie",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "type": "file",
+                        "description": "Import file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/movie/{imdb_id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get details of a specific movie",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "movies"
-                ],
-                "summary": "Get a movie by ID",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "IMDB ID",
-                        "name": "imdb_id",
-                        "in": "path",
-                        "required": true
+                        "description": "csv, jsonl or imdb; inferred from the file name when omitted",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the rows without writing them",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImportReport"
                         }
                     },
                     "400": {
@@ -201,8 +169,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -218,51 +186,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/movie/{imdb_id}/review": {
-            "patch": {
+        "/assistant/chat": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the admin review for a movie (requires ADMIN role)",
+                "description": "Describe a mood or a request and get picks from the catalog, shaped by your favourite genres and the conversation so far. Send Accept: text/event-stream or stream=true to receive the answer as server-sent events: \"delta\" events carry text as it is generated and a final \"done\" event carries the full reply.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "movies"
+                    "assistant"
                 ],
-                "summary": "Update admin review (Admin only)",
+                "summary": "Chat with the movie assistant",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "IMDB ID",
-                        "name": "imdb_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Admin Review JSON {\\",
-                        "name": "review",
+                        "description": "Message",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ChatRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Stream the answer as server-sent events",
+                        "name": "stream",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.AssistantReply"
                         }
                     },
                     "400": {
@@ -272,8 +236,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -285,37 +249,33 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            }
-        },
-        "/movies": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all movies",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Forget the current conversation with the movie assistant",
                 "tags": [
-                    "movies"
+                    "assistant"
                 ],
-                "summary": "Get all movies",
+                "summary": "Start a new assistant conversation",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -324,38 +284,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/recommendedMovies": {
+        "/collections": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get recommended movies based on user's favorite genres",
+                "description": "List collections such as franchises, ordered by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "movies"
+                    "collections"
+                ],
+                "summary": "List collections",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get recommended movies",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Collection"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -364,11 +326,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/register": {
+            },
             "post": {
-                "description": "Register a new user with the provided details",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Group catalog titles in viewing order. Every imdb_id must be in the catalog.",
                 "consumes": [
                     "application/json"
                 ],
@@ -376,17 +341,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "collections"
                 ],
-                "summary": "Register a new user",
+                "summary": "Add a collection (Admin only)",
                 "parameters": [
                     {
-                        "description": "User Registration Data",
-                        "name": "user",
+                        "description": "Collection",
+                        "name": "collection",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.User"
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Collection"
                         }
                     }
                 ],
@@ -394,8 +359,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Collection"
                         }
                     },
                     "400": {
@@ -405,8 +369,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -422,39 +386,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/logout": {
-            "post": {
-                "description": "Logout user and clear tokens",
-                "consumes": [
-                    "application/json"
-                ],
+        "/collections/{id}": {
+            "get": {
+                "description": "Get a collection with its titles in viewing order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "collections"
                 ],
-                "summary": "Logout user",
+                "summary": "Get a collection",
                 "parameters": [
                     {
-                        "description": "User ID JSON {\\",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionDetail"
                         }
                     },
                     "400": {
@@ -464,76 +419,6421 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a collection's details and titles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Update a collection (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Collection",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Collection"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a collection; its titles stay in the catalog",
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Delete a collection (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a view or watchlist event for popularity analytics. Play and review events are recorded by the server. Repeats of the same event within the dedup window are accepted but not counted again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Record a movie event",
+                "parameters": [
+                    {
+                        "description": "Event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Event"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/genres": {
+            "get": {
+                "description": "Get the list of genres, ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Get all genres",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a genre under the next free genre ID. Names are unique, ignoring case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Add a genre (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Genre",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{id}": {
+            "get": {
+                "description": "Get a genre by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Get a genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a genre. The new name is applied to every movie in the genre and to users' favourite genres.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Rename a genre (Admin only)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Genre",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a genre that no movie is in; it is also removed from users' favourite genres. Merge genres that still have movies instead.",
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Delete a genre (Admin only)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Consolidate a duplicate genre: its movies and users' favourites move to the target genre, then the genre is deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Merge a genre into another (Admin only)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the genre to merge away",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target genre",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.GenreMerge"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/home": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the ordered home page rails (Continue Watching, Recommended For You, Top Ranked, featured collections, New Arrivals, favourite genres, Trending). Anonymous users get a non-personalized version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "home"
+                ],
+                "summary": "Get home page rails",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.HomePage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/home/rails/{type}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get further pages of a single home page rail",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "home"
+                ],
+                "summary": "Get a page of a home rail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rail type (continue_watching, recommended, top_ranked, new_arrivals, genre, trending, collection)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Genre name, required for genre rails",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID, required for collection rails",
+                        "name": "collection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default HOME_RAIL_SIZE)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Rail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/images/{path}": {
+            "get": {
+                "description": "Serve an uploaded image or one of its resized copies, as linked from a movie's images. Images never change, so they are cached for a year.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Get an image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Image path",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Login user and return tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "User Login Credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.UserLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new movie to the database. Genres are referenced by genre_id and must exist; their names are taken from the genre list. With autofill=true the title, poster, genres, runtime, synopsis and release date left out of the body are looked up by imdb_id in the metadata provider first. With enrich=true a synopsis, keywords and mood tags are generated as a draft for an admin to approve.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Add a new movie (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Movie Data",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Fill missing details from the metadata provider",
+                        "name": "autofill",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Generate an AI draft for the movie",
+                        "name": "enrich",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific movie",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Get a movie by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/ai-draft": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the generated synopsis, keywords and mood tags awaiting approval",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drafts"
+                ],
+                "summary": "Get a movie's AI draft (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ask the configured LLM for a synopsis, content keywords and mood tags and store them as a draft, replacing any earlier draft",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drafts"
+                ],
+                "summary": "Generate a movie's AI draft (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Drop the draft without publishing it",
+                "tags": [
+                    "drafts"
+                ],
+                "summary": "Discard a movie's AI draft (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/ai-draft/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publish the draft to the movie's synopsis, keywords and mood tags. A body may correct any of the draft fields before they are published.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drafts"
+                ],
+                "summary": "Approve a movie's AI draft (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Corrections to the draft",
+                        "name": "edits",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/backdrops": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a JPEG, PNG or WebP backdrop. Thumbnail, medium and large copies are made as JPEG and WebP, and the large JPEG is added to the movie's backdrops.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Upload a movie backdrop (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Backdrop image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImageSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/credits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the cast and crew of a movie in billing order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Get a movie's credits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieCredit"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an actor (with character name), director or writer credit to a movie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Credit a person on a movie (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credit",
+                        "name": "credit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieCredit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/credits/{credit_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a cast or crew credit from a movie",
+                "tags": [
+                    "people"
+                ],
+                "summary": "Remove a credit (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Credit ID",
+                        "name": "credit_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/enrich": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Look the movie up by IMDb ID in the configured metadata provider and fill in its empty title, poster, genres, runtime, synopsis and release date. With overwrite=true every field the provider knows is replaced. Genres missing from the genre list are left out. Nothing is saved when the result would not be a valid movie.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Fill in a movie from the metadata provider (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Replace fields that are already set",
+                        "name": "overwrite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/poster": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a JPEG, PNG or WebP poster. Thumbnail, medium and large copies are made as JPEG and WebP, and the large JPEG becomes the movie's poster_path. The previously uploaded poster is removed.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Upload a movie poster (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Poster image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImageSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/review": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the admin review for a movie (requires ADMIN role)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Update admin review (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Admin Review JSON {\\",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/reviews": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rate a movie from 1 to 5 with an optional comment; reviewing again replaces the previous review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Review a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review JSON {\\",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/similar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get movies similar in content to the given movie (\"More like this\"), by genre overlap, ranking proximity and text similarity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Get similar movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of movies (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/stream": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Play the title from a media file or HLS master playlist already in the blob store, or from YouTube again with type youtube. Everything next to an HLS master playlist is served with it, so the playlist must sit in a directory, not at the root of the store.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "streaming"
+                ],
+                "summary": "Set a title's stream source (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stream source",
+                        "name": "source",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/stream-url": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a short-lived URL for /stream/{imdb_id} that is bound to the current user and works without an Authorization header, for players that cannot send one. HLS playlists served through it carry the signature on to their segments. The URL expires after STREAM_URL_TTL, 15 minutes by default, so players request a new one before expires_at.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "streaming"
+                ],
+                "summary": "Get a signed playback URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.PlaybackURL"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/subtitles/{lang}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an SRT or WebVTT file as the title's track in a language, replacing any earlier track in that language. SRT is converted to WebVTT, and every cue's timing is checked. A new track is labelled with its language unless a label is given. Making a track the default clears the flag on the others.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subtitles"
+                ],
+                "summary": "Upload a subtitle track (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 language tag, e.g. en or pt-BR",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "SRT or WebVTT file, in UTF-8",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name shown in the player's captions menu",
+                        "name": "label",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether players show the track by default",
+                        "name": "default",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subtitles"
+                ],
+                "summary": "Delete a subtitle track (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 language tag",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change a track's label or default flag. Making a track the default clears the flag on the others.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subtitles"
+                ],
+                "summary": "Update a subtitle track (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 language tag",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/subtitles/{lang}.vtt": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Serve a track as WebVTT, as linked from the subtitles of a movie. Authenticate with a token, or without one through a signed URL from POST /movie/{imdb_id}/stream-url, whose query works here as well.",
+                "produces": [
+                    "text/vtt"
+                ],
+                "tags": [
+                    "subtitles"
+                ],
+                "summary": "Get a subtitle track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BCP 47 language tag",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signed URL: user ID",
+                        "name": "uid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Signed URL: expiry, Unix seconds",
+                        "name": "exp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signed URL: signing key ID",
+                        "name": "kid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signed URL: signature",
+                        "name": "sig",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/transcode": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a job that transcodes media in the blob store into an adaptive HLS ladder with thumbnails. The source defaults to the title's media file, or else the source of its last transcode. The title plays from the ladder once the job is ready. Completed uploads are queued without this call.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcoding"
+                ],
+                "summary": "Transcode a title to HLS (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Media to transcode",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/transcode-jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcoding"
+                ],
+                "summary": "List a title's transcode jobs (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeJob"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/{imdb_id}/watch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that the current user watched a movie, with their latest progress. Series are watched per episode through /series/{imdb_id}/seasons/{season}/episodes/{episode}/watch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Record watch progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Progress JSON {\\",
+                        "name": "progress",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all movies, optionally narrowed by facet filters. Filters take several values, repeated or comma separated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Get all movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Genre names",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ranking names",
+                        "name": "ranking",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release decades, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Average user rating buckets (1-5)",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content types: movie or series",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/facets": {
+            "get": {
+                "description": "Get the number of movies per genre, ranking name, release decade, average rating bucket and content type for the given facet filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Get movie facets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Genre names",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ranking names",
+                        "name": "ranking",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release decades, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Average user rating buckets (1-5)",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content types: movie or series",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Facets"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/search": {
+            "get": {
+                "description": "Full-text search over titles, genres, review text and the episode titles of series, ranked by relevance and tolerant of small typos. Facet filters take several values, repeated or comma separated; the response carries facet counts for the query.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Search movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Genre names",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ranking names",
+                        "name": "ranking",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release decades, e.g. 1990",
+                        "name": "decade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Average user rating buckets (1-5)",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content types: movie or series",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/semantic-search": {
+            "get": {
+                "description": "Find movies by meaning rather than wording, e.g. \"movies about time travel with a twist\", by comparing embeddings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Semantic movie search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Natural language query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/suggest": {
+            "get": {
+                "description": "Suggest movie titles starting with, or containing a word starting with, the prefix",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Autocomplete movie titles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Title prefix",
+                        "name": "prefix",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of suggestions (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Suggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/trending": {
+            "get": {
+                "description": "Get the movies with the highest time-decayed popularity over a window",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Get trending movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trending window (24h, 7d or 30d; default 7d)",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return movies in this genre",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of movies to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TrendingMovie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/people": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List cast and crew ordered by name, optionally filtered by a name fragment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "List people",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name contains",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Person"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a cast or crew member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Add a person (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Person",
+                        "name": "person",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Person"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/people/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a person with a page of their filmography, newest releases first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Get a person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.PersonPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a person's details. Renames are carried over to the search index.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people"
+                ],
+                "summary": "Update a person (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Person",
+                        "name": "person",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Person"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a person and all of their credits",
+                "tags": [
+                    "people"
+                ],
+                "summary": "Delete a person (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/recommendedMovies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get recommended movies based on user's favorite genres, excluding movies already watched or reviewed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Get recommended movies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default RECOMMENDED_MOVIE_LIMIT)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RecommendedMovie"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/recommendedMovies/{imdb_id}/explain": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the full score breakdown and reasons for recommending a movie to the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movies"
+                ],
+                "summary": "Explain a recommendation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RecommendationExplanation"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Register a new user with the provided details. Favourite genres are referenced by genre_id and must exist; their names are taken from the genre list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User Registration Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{imdb_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a series title with its seasons in order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Get a series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SeriesDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{imdb_id}/next-episode": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the episode the current user should play next: the first episode for a new viewer, the unfinished episode to resume, or the one after the last finished episode. Specials (season 0) come after the regular seasons. Responds with no content once every episode was watched.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Get the next episode to watch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.NextEpisode"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{imdb_id}/seasons/{season}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a season of a series with its episodes in order. Season 0 holds specials.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Get a season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season number",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SeasonDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a season of a series, or replace its details when it already exists",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Create or replace a season (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season number",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Season",
+                        "name": "details",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Season"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Season"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a season of a series together with its episodes",
+                "tags": [
+                    "series"
+                ],
+                "summary": "Delete a season (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season number",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{imdb_id}/seasons/{season}/episodes/{episode}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an episode of a series, with its stream and runtime",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Get an episode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season number",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Episode number",
+                        "name": "episode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Episode"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an episode in an existing season, or replace it when it already exists. An episode plays from YouTube, or from a media file or HLS master playlist already in the blob store given as its stream; a self-hosted episode needs its own imdb_id, under which it plays from /stream/{imdb_id} and signs URLs through POST /movie/{imdb_id}/stream-url.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Create or replace an episode (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season number",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Episode number",
+                        "name": "episode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Episode",
+                        "name": "details",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Episode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Episode"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an episode of a series",
+                "tags": [
+                    "series"
+                ],
+                "summary": "Delete an episode (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season number",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Episode number",
+                        "name": "episode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{imdb_id}/seasons/{season}/episodes/{episode}/watch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that the current user watched an episode, with their latest progress. The series then resumes from this episode, or from the next one once it is completed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Record episode watch progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID of the series",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season number",
+                        "name": "season",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Episode number",
+                        "name": "episode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Progress JSON {\\",
+                        "name": "progress",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/{imdb_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Play a self-hosted title. Media files are served with byte range support. HLS titles redirect to their master playlist, whose variant playlists and segments are served below the same path. Titles that play from YouTube answer 409 with their youtube_id. Authenticate with a token, or without one through a signed URL from POST /movie/{imdb_id}/stream-url.",
+                "produces": [
+                    "video/mp4",
+                    "application/vnd.apple.mpegurl"
+                ],
+                "tags": [
+                    "streaming"
+                ],
+                "summary": "Stream a title",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Byte range, e.g. bytes=0-1048575",
+                        "name": "Range",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signed URL: user ID",
+                        "name": "uid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Signed URL: expiry, Unix seconds",
+                        "name": "exp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signed URL: signing key ID",
+                        "name": "kid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signed URL: signature",
+                        "name": "sig",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "206": {
+                        "description": "Partial Content"
+                    },
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "416": {
+                        "description": "Requested Range Not Satisfiable"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/{imdb_id}/{path}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Serve a playlist or segment of a self-hosted HLS title, relative to its master playlist. Playlists fetched through a signed URL have the signature added to the URLs they list.",
+                "produces": [
+                    "application/vnd.apple.mpegurl",
+                    "video/mp2t"
+                ],
+                "tags": [
+                    "streaming"
+                ],
+                "summary": "Stream an HLS file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IMDB ID",
+                        "name": "imdb_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Playlist or segment path",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "206": {
+                        "description": "Partial Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transcode-jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Status and progress, from 0 to 1, of a transcode job, with its renditions and thumbnails once it is ready.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcoding"
+                ],
+                "summary": "Get a transcode job (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeJob"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transcode-jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop a queued or running job. A running job stops within a few seconds and what it wrote is removed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcoding"
+                ],
+                "summary": "Cancel a transcode job (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeJob"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transcode-jobs/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a failed or canceled job again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transcoding"
+                ],
+                "summary": "Retry a transcode job (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeJob"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "tus creation. Upload-Metadata must name the title with imdb_id and may give filename and filetype. Send the data with PATCH to the URL in the Location header; once all of it has arrived the file is queued to be transcoded to HLS, or becomes the title's stream source when transcoding is off.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Start a resumable media upload (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of the file in bytes",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated key and base64 value pairs, e.g. imdb_id dHQyNTQzMTY0,filename bW92aWUubXA0",
+                        "name": "Upload-Metadata",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "options": {
+                "description": "tus discovery: the protocol version, extensions and largest upload accepted.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Describe the upload server",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/uploads/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "tus termination: stop an unfinished upload and remove the data received.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Cancel a resumable upload (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "tus HEAD: how many bytes have arrived, so an interrupted upload can resume from there.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Get the offset of a resumable upload (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "tus PATCH: append the body at Upload-Offset, which must be the upload's current offset. Once the last chunk is in, the file is stored in the background and queued for transcoding, or made the title's stream source when transcoding is off; the upload shows completed_at when that is done. If storing the file fails, an empty PATCH at the final offset retries it.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Send data of a resumable upload (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset the body starts at",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user/logout": {
+            "post": {
+                "description": "Logout user and clear tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Logout user",
+                "parameters": [
+                    {
+                        "description": "User ID JSON {\\",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/user/refresh-token": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refresh access and refresh tokens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Refresh user tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.AssistantReply": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "picks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                    }
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ChatRequest": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Collection": {
+            "type": "object",
+            "required": [
+                "imdb_ids",
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "featured": {
+                    "description": "Featured collections get a rail on the home page.",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imdb_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 2
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionDetail": {
+            "type": "object",
+            "required": [
+                "imdb_ids",
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "featured": {
+                    "description": "Featured collections get a rail on the home page.",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imdb_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "movies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 2
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionMembership": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CreditRequest": {
+            "type": "object",
+            "required": [
+                "person_id",
+                "role"
+            ],
+            "properties": {
+                "character": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "person_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "actor",
+                        "director",
+                        "writer"
+                    ]
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Episode": {
+            "type": "object",
+            "required": [
+                "runtime",
+                "title"
+            ],
+            "properties": {
+                "air_date": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "episode_number": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "description": "minutes",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "season_number": {
+                    "type": "integer"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "stream": {
+                    "description": "Stream points the episode at media in the blob store, like the stream source of a movie;\nepisodes without one play from YouTube. A self-hosted episode plays from /stream under its\nown imdb_id, so it needs one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource"
+                        }
+                    ]
+                },
+                "synopsis": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "youtube_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Event": {
+            "type": "object",
+            "required": [
+                "imdb_id",
+                "type"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "view",
+                        "play",
+                        "watchlist",
+                        "review"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Facets": {
+            "type": "object",
+            "properties": {
+                "decades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.FacetCount"
+                    }
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.FacetCount"
+                    }
+                },
+                "rankings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.FacetCount"
+                    }
+                },
+                "ratings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.FacetCount"
+                    }
+                },
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.FacetCount"
+                    }
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.FilmographyEntry": {
+            "type": "object",
+            "properties": {
+                "character": {
+                    "type": "string"
+                },
+                "movie": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre": {
+            "type": "object",
+            "required": [
+                "genre_id",
+                "genre_name"
+            ],
+            "properties": {
+                "genre_id": {
+                    "type": "integer"
+                },
+                "genre_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.GenreMerge": {
+            "type": "object",
+            "required": [
+                "target_id"
+            ],
+            "properties": {
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.GenreRequest": {
+            "type": "object",
+            "required": [
+                "genre_name"
+            ],
+            "properties": {
+                "genre_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.HomePage": {
+            "type": "object",
+            "properties": {
+                "personalized": {
+                    "type": "boolean"
+                },
+                "rails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Rail"
+                    }
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImageSet": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "variants": {
+                    "description": "Variants maps copy names to URLs: original, thumb, medium and large, the last three\nalso as WebP under thumb_webp, medium_webp and large_webp.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "description": "Errors lists the failed rows, up to a limit; Failed has the full count.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImportRowError"
+                    }
+                },
+                "errors_truncated": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "Skipped rows are of a kind the format does not import, such as TV episodes.",
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie": {
+            "type": "object",
+            "required": [
+                "genre",
+                "imdb_id",
+                "poster_path",
+                "ranking",
+                "title",
+                "youtube_id"
+            ],
+            "properties": {
+                "admin_review": {
+                    "type": "string"
+                },
+                "ai_draft": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                },
+                "backdrops": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "collections": {
+                    "description": "Collections are maintained from the collections that list the movie; values sent by\nclients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionMembership"
+                    }
+                },
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "movie",
+                        "series"
+                    ]
+                },
+                "country": {
+                    "description": "e.g. \"US\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt and UpdatedAt are maintained by the repository; values sent by clients are ignored.",
+                    "type": "string"
+                },
+                "genre": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "description": "Images are maintained by the image uploads, which also point PosterPath and Backdrops at\nthe large copies; values sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieImages"
+                        }
+                    ]
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "maturity_rating": {
+                    "type": "string",
+                    "enum": [
+                        "G",
+                        "PG",
+                        "PG-13",
+                        "R",
+                        "NC-17",
+                        "NR"
+                    ]
+                },
+                "mood_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "original_language": {
+                    "description": "e.g. \"en\"",
+                    "type": "string"
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "ranking": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Ranking"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "description": "minutes",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "stream": {
+                    "description": "Stream is set through the stream source endpoint; titles without one play from YouTube.\nValues sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource"
+                        }
+                    ]
+                },
+                "subtitles": {
+                    "description": "Subtitles are maintained by the subtitle endpoints; values sent by clients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack"
+                    }
+                },
+                "synopsis": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 2
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "youtube_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieCredit": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "character": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "person": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Person"
+                },
+                "person_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "actor",
+                        "director",
+                        "writer"
+                    ]
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft": {
+            "type": "object",
+            "properties": {
+                "generated_at": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "model": {
+                    "type": "string"
+                },
+                "mood_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "synopsis": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieImages": {
+            "type": "object",
+            "properties": {
+                "backdrops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImageSet"
+                    }
+                },
+                "poster": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ImageSet"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.NextEpisode": {
+            "type": "object",
+            "properties": {
+                "episode": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Episode"
+                },
+                "progress_seconds": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Person": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "bio": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "birth_date": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 1
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.PersonPage": {
+            "type": "object",
+            "properties": {
+                "filmography": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.FilmographyEntry"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "person": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Person"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.PlaybackURL": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Rail": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "description": "Collection is the ID of the collection shown by a collection rail.",
+                    "type": "string"
+                },
+                "genre": {
+                    "type": "string"
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RailItem"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RailItem": {
+            "type": "object",
+            "required": [
+                "genre",
+                "imdb_id",
+                "poster_path",
+                "ranking",
+                "title",
+                "youtube_id"
+            ],
+            "properties": {
+                "admin_review": {
+                    "type": "string"
+                },
+                "ai_draft": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                },
+                "backdrops": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "collections": {
+                    "description": "Collections are maintained from the collections that list the movie; values sent by\nclients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionMembership"
+                    }
+                },
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "movie",
+                        "series"
+                    ]
+                },
+                "country": {
+                    "description": "e.g. \"US\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt and UpdatedAt are maintained by the repository; values sent by clients are ignored.",
+                    "type": "string"
+                },
+                "episode_number": {
+                    "type": "integer"
+                },
+                "genre": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "description": "Images are maintained by the image uploads, which also point PosterPath and Backdrops at\nthe large copies; values sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieImages"
+                        }
+                    ]
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "maturity_rating": {
+                    "type": "string",
+                    "enum": [
+                        "G",
+                        "PG",
+                        "PG-13",
+                        "R",
+                        "NC-17",
+                        "NR"
+                    ]
+                },
+                "mood_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "original_language": {
+                    "description": "e.g. \"en\"",
+                    "type": "string"
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "progress_seconds": {
+                    "type": "integer"
+                },
+                "ranking": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Ranking"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RecommendationReason"
+                    }
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "description": "minutes",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "season_number": {
+                    "description": "SeasonNumber and EpisodeNumber name the episode to resume when the item is a series.",
+                    "type": "integer"
+                },
+                "stream": {
+                    "description": "Stream is set through the stream source endpoint; titles without one play from YouTube.\nValues sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource"
+                        }
+                    ]
+                },
+                "subtitles": {
+                    "description": "Subtitles are maintained by the subtitle endpoints; values sent by clients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack"
+                    }
+                },
+                "synopsis": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 2
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "youtube_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Ranking": {
+            "type": "object",
+            "required": [
+                "ranking_name",
+                "ranking_value"
+            ],
+            "properties": {
+                "ranking_name": {
+                    "type": "string"
+                },
+                "ranking_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RecommendationExplanation": {
+            "type": "object",
+            "properties": {
+                "already_seen": {
+                    "type": "boolean"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ScoreComponent"
+                    }
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RecommendationReason"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RecommendationReason": {
+            "type": "object",
+            "properties": {
+                "genre": {
+                    "type": "string"
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "ranking_name": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RecommendedMovie": {
+            "type": "object",
+            "required": [
+                "genre",
+                "imdb_id",
+                "poster_path",
+                "ranking",
+                "title",
+                "youtube_id"
+            ],
+            "properties": {
+                "admin_review": {
+                    "type": "string"
+                },
+                "ai_draft": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                },
+                "backdrops": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "collections": {
+                    "description": "Collections are maintained from the collections that list the movie; values sent by\nclients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionMembership"
+                    }
+                },
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "movie",
+                        "series"
+                    ]
+                },
+                "country": {
+                    "description": "e.g. \"US\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt and UpdatedAt are maintained by the repository; values sent by clients are ignored.",
+                    "type": "string"
+                },
+                "genre": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "description": "Images are maintained by the image uploads, which also point PosterPath and Backdrops at\nthe large copies; values sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieImages"
+                        }
+                    ]
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "maturity_rating": {
+                    "type": "string",
+                    "enum": [
+                        "G",
+                        "PG",
+                        "PG-13",
+                        "R",
+                        "NC-17",
+                        "NR"
+                    ]
+                },
+                "mood_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "original_language": {
+                    "description": "e.g. \"en\"",
+                    "type": "string"
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "ranking": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Ranking"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.RecommendationReason"
+                    }
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "description": "minutes",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "score": {
+                    "type": "number"
+                },
+                "stream": {
+                    "description": "Stream is set through the stream source endpoint; titles without one play from YouTube.\nValues sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource"
+                        }
+                    ]
+                },
+                "subtitles": {
+                    "description": "Subtitles are maintained by the subtitle endpoints; values sent by clients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack"
+                    }
+                },
+                "synopsis": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 2
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "youtube_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.ScoreComponent": {
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "type": "number"
+                },
+                "signal": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Facets"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SearchResult"
+                    }
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SearchResult": {
+            "type": "object",
+            "required": [
+                "genre",
+                "imdb_id",
+                "poster_path",
+                "ranking",
+                "title",
+                "youtube_id"
+            ],
+            "properties": {
+                "admin_review": {
+                    "type": "string"
+                },
+                "ai_draft": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                },
+                "backdrops": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "collections": {
+                    "description": "Collections are maintained from the collections that list the movie; values sent by\nclients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionMembership"
+                    }
+                },
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "movie",
+                        "series"
+                    ]
+                },
+                "country": {
+                    "description": "e.g. \"US\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt and UpdatedAt are maintained by the repository; values sent by clients are ignored.",
+                    "type": "string"
+                },
+                "genre": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "description": "Images are maintained by the image uploads, which also point PosterPath and Backdrops at\nthe large copies; values sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieImages"
+                        }
+                    ]
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "maturity_rating": {
+                    "type": "string",
+                    "enum": [
+                        "G",
+                        "PG",
+                        "PG-13",
+                        "R",
+                        "NC-17",
+                        "NR"
+                    ]
+                },
+                "mood_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "original_language": {
+                    "description": "e.g. \"en\"",
+                    "type": "string"
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "ranking": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Ranking"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "description": "minutes",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "score": {
+                    "type": "number"
+                },
+                "stream": {
+                    "description": "Stream is set through the stream source endpoint; titles without one play from YouTube.\nValues sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource"
+                        }
+                    ]
+                },
+                "subtitles": {
+                    "description": "Subtitles are maintained by the subtitle endpoints; values sent by clients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack"
+                    }
+                },
+                "synopsis": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 2
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "youtube_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Season": {
+            "type": "object",
+            "properties": {
+                "air_date": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "overview": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "season_number": {
+                    "type": "integer"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SeasonDetail": {
+            "type": "object",
+            "properties": {
+                "air_date": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Episode"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "overview": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "season_number": {
+                    "type": "integer"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SeriesDetail": {
+            "type": "object",
+            "required": [
+                "genre",
+                "imdb_id",
+                "poster_path",
+                "ranking",
+                "title",
+                "youtube_id"
+            ],
+            "properties": {
+                "admin_review": {
+                    "type": "string"
+                },
+                "ai_draft": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                },
+                "backdrops": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "collections": {
+                    "description": "Collections are maintained from the collections that list the movie; values sent by\nclients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionMembership"
+                    }
+                },
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "movie",
+                        "series"
+                    ]
+                },
+                "country": {
+                    "description": "e.g. \"US\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt and UpdatedAt are maintained by the repository; values sent by clients are ignored.",
+                    "type": "string"
+                },
+                "genre": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "description": "Images are maintained by the image uploads, which also point PosterPath and Backdrops at\nthe large copies; values sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieImages"
+                        }
+                    ]
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "items": {
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "/user/refresh-token": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
+                },
+                "maturity_rating": {
+                    "type": "string",
+                    "enum": [
+                        "G",
+                        "PG",
+                        "PG-13",
+                        "R",
+                        "NC-17",
+                        "NR"
+                    ]
+                },
+                "mood_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
-                ],
-                "description": "Refresh access and refresh tokens",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Refresh user tokens",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                },
+                "original_language": {
+                    "description": "e.g. \"en\"",
+                    "type": "string"
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "ranking": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Ranking"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "description": "minutes",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Season"
+                    }
+                },
+                "stream": {
+                    "description": "Stream is set through the stream source endpoint; titles without one play from YouTube.\nValues sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource"
                         }
+                    ]
+                },
+                "subtitles": {
+                    "description": "Subtitles are maintained by the subtitle endpoints; values sent by clients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack"
                     }
+                },
+                "synopsis": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 2
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "youtube_id": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Genre": {
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource": {
             "type": "object",
             "required": [
-                "genre_id",
-                "genre_name"
+                "type"
             ],
             "properties": {
-                "genre_id": {
-                    "type": "integer"
+                "key": {
+                    "description": "Key is the blob key of the media file, or of the master playlist for HLS. Segments and\nvariant playlists are stored next to the master playlist.",
+                    "type": "string"
                 },
-                "genre_name": {
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "youtube",
+                        "file",
+                        "hls"
+                    ]
+                },
+                "youtube_id": {
+                    "description": "YouTubeID is set on the source returned for YouTube titles, from the movie's youtube_id.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "description": "Label is the name players show in their captions menu.",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "Language is a BCP 47 tag in canonical form, e.g. \"en\" or \"pt-BR\".",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL serves the track at /movie/{imdb_id}/subtitles/{language}.vtt.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrackRequest": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "type": "boolean"
+                },
+                "label": {
                     "type": "string",
                     "maxLength": 100,
-                    "minLength": 2
+                    "minLength": 1
                 }
             }
         },
-        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Movie": {
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Suggestion": {
+            "type": "object",
+            "properties": {
+                "imdb_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imdb_id": {
+                    "type": "string"
+                },
+                "playlist_key": {
+                    "description": "PlaylistKey is the blob key of the master playlist, set once the job is ready.",
+                    "type": "string"
+                },
+                "progress": {
+                    "description": "Progress is the share of the work done, from 0 to 1.",
+                    "type": "number"
+                },
+                "renditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeRendition"
+                    }
+                },
+                "source_key": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "thumbnails": {
+                    "description": "Thumbnails are blob keys of stills spread over the video, in order.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeRendition": {
+            "type": "object",
+            "properties": {
+                "bandwidth": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "playlist_key": {
+                    "description": "PlaylistKey is the blob key of the rendition's variant playlist.",
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TranscodeRequest": {
+            "type": "object",
+            "properties": {
+                "source_key": {
+                    "description": "SourceKey is the blob key of the media, such as the key of a completed upload. It\ndefaults to the title's media file, or else the source of its last transcode.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.TrendingMovie": {
             "type": "object",
             "required": [
                 "genre",
@@ -547,6 +6847,38 @@ const docTemplate = `{
                 "admin_review": {
                     "type": "string"
                 },
+                "ai_draft": {
+                    "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieDraft"
+                },
+                "backdrops": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "collections": {
+                    "description": "Collections are maintained from the collections that list the movie; values sent by\nclients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.CollectionMembership"
+                    }
+                },
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "movie",
+                        "series"
+                    ]
+                },
+                "country": {
+                    "description": "e.g. \"US\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt and UpdatedAt are maintained by the repository; values sent by clients are ignored.",
+                    "type": "string"
+                },
                 "genre": {
                     "type": "array",
                     "items": {
@@ -556,37 +6888,92 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "images": {
+                    "description": "Images are maintained by the image uploads, which also point PosterPath and Backdrops at\nthe large copies; values sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.MovieImages"
+                        }
+                    ]
+                },
                 "imdb_id": {
                     "type": "string"
                 },
+                "keywords": {
+                    "type": "array",
+                    "maxItems": 30,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "maturity_rating": {
+                    "type": "string",
+                    "enum": [
+                        "G",
+                        "PG",
+                        "PG-13",
+                        "R",
+                        "NC-17",
+                        "NR"
+                    ]
+                },
+                "mood_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "original_language": {
+                    "description": "e.g. \"en\"",
+                    "type": "string"
+                },
                 "poster_path": {
                     "type": "string"
                 },
                 "ranking": {
                     "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Ranking"
                 },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "description": "minutes",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "stream": {
+                    "description": "Stream is set through the stream source endpoint; titles without one play from YouTube.\nValues sent by clients are ignored.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.StreamSource"
+                        }
+                    ]
+                },
+                "subtitles": {
+                    "description": "Subtitles are maintained by the subtitle endpoints; values sent by clients are ignored.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.SubtitleTrack"
+                    }
+                },
+                "synopsis": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
                 "title": {
                     "type": "string",
                     "maxLength": 500,
                     "minLength": 2
                 },
-                "youtube_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_sirolad_MagicStreamMovies_Server_MagicStreamMovies_Server_internal_models.Ranking": {
-            "type": "object",
-            "required": [
-                "ranking_name",
-                "ranking_value"
-            ],
-            "properties": {
-                "ranking_name": {
+                "trending_score": {
+                    "type": "number"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "ranking_value": {
-                    "type": "integer"
+                "youtube_id": {
+                    "type": "string"
                 }
             }
         },
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Now()
+	c := NewTTLCache[string, int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok, "entry should expire after the TTL")
+
+	c.Set("b", 2)
+	c.Delete("b")
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	c.Set("c", 3)
+	c.Clear()
+	_, ok = c.Get("c")
+	assert.False(t, ok)
+}
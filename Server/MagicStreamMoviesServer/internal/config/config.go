@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -18,6 +19,83 @@ type Config struct {
 	BasePromptTemplate    string
 	RecommendedMovieLimit int64
 	AllowedOrigins        []string
+
+	// Recommendation engine
+	RecommendationStrategy string
+	RecommendationWeights  RecommendationWeights
+	SimilarityNeighbors    int
+	SimilarityJobInterval  time.Duration
+	SimilarMoviesCacheTTL  time.Duration
+
+	// Home page
+	HomeRails         []string
+	HomeRailSize      int64
+	HomeMaxGenreRails int
+
+	// Analytics
+	TrendingJobInterval time.Duration
+	EventDedupWindow    time.Duration
+
+	// Search
+	SearchBackend         string
+	SearchReindexInterval time.Duration
+
+	// Embeddings
+	EmbeddingProvider    string
+	EmbeddingModel       string
+	EmbeddingBaseURL     string
+	EmbeddingDimensions  int
+	EmbeddingJobInterval time.Duration
+
+	// Assistant
+	AssistantModel           string
+	AssistantConversationTTL time.Duration
+	AssistantMaxTurns        int
+	AssistantCandidates      int
+
+	// Drafts
+	DraftModel string
+
+	// Metadata lookups
+	MetadataProvider    string
+	MetadataAPIKey      string
+	MetadataBaseURL     string
+	MetadataFixturesDir string
+
+	// Uploads
+	PublicURL     string
+	BlobStore     string
+	BlobDir       string
+	S3Endpoint    string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3Bucket      string
+	S3Region      string
+	S3UseSSL      bool
+	ImageMaxBytes int64
+
+	// Streaming
+	StreamSigningKeys []string
+	StreamURLTTL      time.Duration
+	UploadMaxBytes    int64
+
+	// Transcoding
+	FFmpegPath           string
+	FFprobePath          string
+	TranscodeRenditions  []string
+	TranscodeWorkDir     string
+	TranscodeJobInterval time.Duration
+
+	// Subtitles
+	SubtitleMaxBytes int64
+}
+
+// RecommendationWeights blends the signals used by the collaborative recommender.
+type RecommendationWeights struct {
+	CF       float64
+	Genre    float64
+	Ranking  float64
+	Semantic float64
 }
 
 func LoadConfig() *Config {
@@ -55,5 +133,111 @@ func LoadConfig() *Config {
 		BasePromptTemplate:    os.Getenv("BASE_PROMPT_TEMPLATE"),
 		RecommendedMovieLimit: limit,
 		AllowedOrigins:        origins,
+
+		RecommendationStrategy: getEnv("RECOMMENDATION_STRATEGY", "collaborative"),
+		RecommendationWeights: RecommendationWeights{
+			CF:       getEnvFloat("RECOMMENDATION_CF_WEIGHT", 0.6),
+			Genre:    getEnvFloat("RECOMMENDATION_GENRE_WEIGHT", 0.3),
+			Ranking:  getEnvFloat("RECOMMENDATION_RANKING_WEIGHT", 0.1),
+			Semantic: getEnvFloat("RECOMMENDATION_SEMANTIC_WEIGHT", 0.2),
+		},
+		SimilarityNeighbors:   getEnvInt("SIMILARITY_NEIGHBORS", 20),
+		SimilarityJobInterval: getEnvDuration("SIMILARITY_JOB_INTERVAL", time.Hour),
+		SimilarMoviesCacheTTL: getEnvDuration("SIMILAR_MOVIES_CACHE_TTL", 15*time.Minute),
+
+		HomeRails: getEnvList("HOME_RAILS", []string{
+			"continue_watching", "recommended", "top_ranked", "featured_collections", "new_arrivals", "favourite_genres", "trending",
+		}),
+		HomeRailSize:      int64(getEnvInt("HOME_RAIL_SIZE", 10)),
+		HomeMaxGenreRails: getEnvInt("HOME_MAX_GENRE_RAILS", 3),
+
+		TrendingJobInterval: getEnvDuration("TRENDING_JOB_INTERVAL", 15*time.Minute),
+		EventDedupWindow:    getEnvDuration("EVENT_DEDUP_WINDOW", 30*time.Minute),
+
+		SearchBackend:         getEnv("SEARCH_BACKEND", "memory"),
+		SearchReindexInterval: getEnvDuration("SEARCH_REINDEX_INTERVAL", time.Hour),
+
+		EmbeddingProvider:    os.Getenv("EMBEDDING_PROVIDER"),
+		EmbeddingModel:       getEnv("EMBEDDING_MODEL", "text-embedding-3-small"),
+		EmbeddingBaseURL:     getEnv("EMBEDDING_BASE_URL", "http://localhost:11434/v1"),
+		EmbeddingDimensions:  getEnvInt("EMBEDDING_DIMENSIONS", 256),
+		EmbeddingJobInterval: getEnvDuration("EMBEDDING_JOB_INTERVAL", time.Hour),
+
+		AssistantModel:           getEnv("ASSISTANT_MODEL", "gpt-4o-mini"),
+		AssistantConversationTTL: getEnvDuration("ASSISTANT_CONVERSATION_TTL", 30*time.Minute),
+		AssistantMaxTurns:        getEnvInt("ASSISTANT_MAX_TURNS", 10),
+		AssistantCandidates:      getEnvInt("ASSISTANT_CANDIDATES", 30),
+
+		DraftModel: getEnv("DRAFT_MODEL", "gpt-4o-mini"),
+
+		MetadataProvider:    getEnv("METADATA_PROVIDER", "local"),
+		MetadataAPIKey:      os.Getenv("METADATA_API_KEY"),
+		MetadataBaseURL:     os.Getenv("METADATA_BASE_URL"),
+		MetadataFixturesDir: getEnv("METADATA_FIXTURES_DIR", "fixtures/metadata"),
+
+		PublicURL:     strings.TrimSuffix(getEnv("PUBLIC_URL", "http://localhost:8080"), "/"),
+		BlobStore:     getEnv("BLOB_STORE", "local"),
+		BlobDir:       getEnv("BLOB_DIR", "data/blobs"),
+		S3Endpoint:    os.Getenv("S3_ENDPOINT"),
+		S3AccessKey:   os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:   os.Getenv("S3_SECRET_KEY"),
+		S3Bucket:      os.Getenv("S3_BUCKET"),
+		S3Region:      os.Getenv("S3_REGION"),
+		S3UseSSL:      getEnv("S3_USE_SSL", "true") == "true",
+		ImageMaxBytes: int64(getEnvInt("IMAGE_MAX_BYTES", 20<<20)),
+
+		StreamSigningKeys: getEnvList("STREAM_SIGNING_KEYS", nil),
+		StreamURLTTL:      getEnvDuration("STREAM_URL_TTL", 15*time.Minute),
+		UploadMaxBytes:    int64(getEnvInt("UPLOAD_MAX_BYTES", 50<<30)),
+
+		FFmpegPath:           getEnv("FFMPEG_PATH", "ffmpeg"),
+		FFprobePath:          getEnv("FFPROBE_PATH", "ffprobe"),
+		TranscodeRenditions:  getEnvList("TRANSCODE_RENDITIONS", []string{"360p", "720p", "1080p"}),
+		TranscodeWorkDir:     os.Getenv("TRANSCODE_WORK_DIR"),
+		TranscodeJobInterval: getEnvDuration("TRANSCODE_JOB_INTERVAL", 10*time.Second),
+
+		SubtitleMaxBytes: int64(getEnvInt("SUBTITLE_MAX_BYTES", 2<<20)),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return fallback
+}
+
+func getEnvList(key string, fallback []string) []string {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	var items []string
+	for _, p := range strings.Split(val, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			items = append(items, p)
+		}
+	}
+	return items
+}
+
+func getEnvInt(key string, fallback int) int {
+	if val, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return val
+	}
+	return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if val, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return val
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if val, err := time.ParseDuration(os.Getenv(key)); err == nil && val > 0 {
+		return val
 	}
+	return fallback
 }
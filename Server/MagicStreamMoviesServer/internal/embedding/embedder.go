@@ -0,0 +1,39 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+)
+
+const (
+	ProviderOpenAI = "openai"
+	ProviderLocal  = "local"
+	ProviderHash   = "hash"
+)
+
+// Embedder turns texts into vectors whose cosine similarity reflects how alike the texts are.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Model identifies the embedding space. Vectors from different models are not comparable.
+	Model() string
+}
+
+// New builds the embedder selected by cfg.EmbeddingProvider. There is no default: the hash
+// embedder only matches words, so it has to be asked for.
+func New(cfg *config.Config) (Embedder, error) {
+	switch cfg.EmbeddingProvider {
+	case "":
+		return nil, errors.New("EMBEDDING_PROVIDER is not set")
+	case ProviderOpenAI:
+		return NewOpenAIEmbedder(cfg.OpenAPIKey, cfg.EmbeddingModel)
+	case ProviderLocal:
+		return NewLocalEmbedder(cfg.EmbeddingBaseURL, cfg.EmbeddingModel)
+	case ProviderHash:
+		return NewHashEmbedder(cfg.EmbeddingDimensions), nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", cfg.EmbeddingProvider)
+	}
+}
@@ -0,0 +1,60 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/pkg/utils"
+)
+
+type hashEmbedder struct {
+	dimensions int
+}
+
+// NewHashEmbedder returns a deterministic embedder that hashes every token of a text into one
+// of dimensions buckets. It needs no network and only captures word overlap, which makes it a
+// stand-in for tests and local development rather than a semantic model.
+func NewHashEmbedder(dimensions int) Embedder {
+	return &hashEmbedder{dimensions: dimensions}
+}
+
+func (e *hashEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vector := make([]float32, e.dimensions)
+		for _, token := range utils.Tokenize(text) {
+			h := fnv.New64a()
+			h.Write([]byte(token))
+			sum := h.Sum64()
+			// The top bit picks the sign so that collisions tend to cancel out.
+			sign := float32(1)
+			if sum>>63 == 1 {
+				sign = -1
+			}
+			vector[sum%uint64(e.dimensions)] += sign
+		}
+		vectors = append(vectors, normalize(vector))
+	}
+	return vectors, nil
+}
+
+func (e *hashEmbedder) Model() string {
+	return fmt.Sprintf("hash-%d", e.dimensions)
+}
+
+func normalize(vector []float32) []float32 {
+	norm := 0.0
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vector
+	}
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vector {
+		vector[i] *= scale
+	}
+	return vector
+}
@@ -0,0 +1,38 @@
+package embedding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dot(a, b []float32) float64 {
+	total := 0.0
+	for i := range a {
+		total += float64(a[i]) * float64(b[i])
+	}
+	return total
+}
+
+func TestHashEmbedder(t *testing.T) {
+	embedder := NewHashEmbedder(64)
+
+	vectors, err := embedder.Embed(context.Background(), []string{
+		"time travel paradox",
+		"a paradox of time travel",
+		"romantic comedy in paris",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, vectors, 3)
+	assert.Len(t, vectors[0], 64)
+	assert.InDelta(t, 1, dot(vectors[0], vectors[0]), 1e-6)
+	assert.InDelta(t, 1, dot(vectors[0], vectors[1]), 1e-6)
+	assert.Less(t, dot(vectors[0], vectors[2]), 0.5)
+	assert.Equal(t, "hash-64", embedder.Model())
+
+	again, err := embedder.Embed(context.Background(), []string{"time travel paradox"})
+	assert.NoError(t, err)
+	assert.Equal(t, vectors[0], again[0])
+}
@@ -0,0 +1,46 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+type openAIEmbedder struct {
+	llm   *openai.LLM
+	model string
+}
+
+// NewOpenAIEmbedder embeds texts with the OpenAI embeddings API.
+func NewOpenAIEmbedder(apiKey string, model string) (Embedder, error) {
+	if apiKey == "" {
+		return nil, errors.New("could not read OPEN_API_KEY")
+	}
+	llm, err := openai.New(openai.WithToken(apiKey), openai.WithEmbeddingModel(model))
+	if err != nil {
+		return nil, err
+	}
+	return &openAIEmbedder{llm: llm, model: model}, nil
+}
+
+// NewLocalEmbedder embeds texts with a self-hosted server speaking the OpenAI embeddings API,
+// such as Ollama or llama.cpp. Such servers do not check the API key.
+func NewLocalEmbedder(baseURL string, model string) (Embedder, error) {
+	llm, err := openai.New(openai.WithBaseURL(baseURL), openai.WithToken("local"), openai.WithEmbeddingModel(model))
+	if err != nil {
+		return nil, err
+	}
+	return &openAIEmbedder{llm: llm, model: model}, nil
+}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	return e.llm.CreateEmbedding(ctx, texts)
+}
+
+func (e *openAIEmbedder) Model() string {
+	return e.model
+}
@@ -0,0 +1,42 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+type csvWriter struct {
+	csv     *csv.Writer
+	columns []Column
+	row     []string
+}
+
+func newCSVWriter(w io.Writer, columns []Column) (*csvWriter, error) {
+	writer := &csvWriter{csv: csv.NewWriter(w), columns: columns, row: make([]string, len(columns))}
+	for i, column := range columns {
+		writer.row[i] = column.Name
+	}
+	if err := writer.csv.Write(writer.row); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) WriteRow(movie models.MovieExport) error {
+	for i, column := range w.columns {
+		switch v := column.Value(movie).(type) {
+		case int, *float64:
+			w.row[i] = cellText(v)
+		default:
+			w.row[i] = escapeFormula(cellText(v))
+		}
+	}
+	return w.csv.Write(w.row)
+}
+
+func (w *csvWriter) Close() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
@@ -0,0 +1,175 @@
+// Package exporter writes catalog exports as CSV, JSON Lines or Excel workbooks. Rows are
+// written as they arrive, so exports of any size are streamed without being held in memory.
+//
+// Column names match the CSV columns of the importer, so an exported file can be edited and
+// imported again; the read-only columns are ignored on import.
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// Supported formats.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatXLSX  = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format, expected csv, jsonl or xlsx")
+
+// Column is one exported field.
+type Column struct {
+	Name string
+	// Value returns the field as a string, number, time, string list or genre list.
+	Value func(movie models.MovieExport) any
+	// ratings marks the columns computed from user reviews.
+	ratings bool
+}
+
+// Columns lists every column in the default order.
+var Columns = []Column{
+	{Name: "imdb_id", Value: func(m models.MovieExport) any { return m.ImdbID }},
+	{Name: "content_type", Value: func(m models.MovieExport) any { return m.ContentType }},
+	{Name: "title", Value: func(m models.MovieExport) any { return m.Title }},
+	{Name: "genre", Value: func(m models.MovieExport) any { return m.Genre }},
+	{Name: "ranking_value", Value: func(m models.MovieExport) any { return m.Ranking.RankingValue }},
+	{Name: "ranking_name", Value: func(m models.MovieExport) any { return m.Ranking.RankingName }},
+	{Name: "admin_review", Value: func(m models.MovieExport) any { return m.AdminReview }},
+	{Name: "average_rating", Value: func(m models.MovieExport) any { return m.AverageRating }, ratings: true},
+	{Name: "review_count", Value: func(m models.MovieExport) any { return m.ReviewCount }, ratings: true},
+	{Name: "release_date", Value: func(m models.MovieExport) any { return m.ReleaseDate }},
+	{Name: "runtime", Value: func(m models.MovieExport) any { return m.Runtime }},
+	{Name: "maturity_rating", Value: func(m models.MovieExport) any { return m.MaturityRating }},
+	{Name: "original_language", Value: func(m models.MovieExport) any { return m.OriginalLanguage }},
+	{Name: "country", Value: func(m models.MovieExport) any { return m.Country }},
+	{Name: "synopsis", Value: func(m models.MovieExport) any { return m.Synopsis }},
+	{Name: "keywords", Value: func(m models.MovieExport) any { return m.Keywords }},
+	{Name: "mood_tags", Value: func(m models.MovieExport) any { return m.MoodTags }},
+	{Name: "poster_path", Value: func(m models.MovieExport) any { return m.PosterPath }},
+	{Name: "youtube_id", Value: func(m models.MovieExport) any { return m.YouTubeID }},
+	{Name: "backdrops", Value: func(m models.MovieExport) any { return m.Backdrops }},
+	{Name: "created_at", Value: func(m models.MovieExport) any { return m.CreatedAt }},
+	{Name: "updated_at", Value: func(m models.MovieExport) any { return m.UpdatedAt }},
+}
+
+// ParseColumns picks the named columns, in the given order, from a comma separated list. An
+// empty list selects every column.
+func ParseColumns(list string) ([]Column, error) {
+	if strings.TrimSpace(list) == "" {
+		return Columns, nil
+	}
+	byName := make(map[string]Column, len(Columns))
+	for _, column := range Columns {
+		byName[column.Name] = column
+	}
+
+	var columns []Column
+	for _, name := range strings.Split(list, ",") {
+		column, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", strings.TrimSpace(name))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// NeedsRatings reports whether any of the columns is computed from user reviews.
+func NeedsRatings(columns []Column) bool {
+	for _, column := range columns {
+		if column.ratings {
+			return true
+		}
+	}
+	return false
+}
+
+// Writer writes the rows of an export.
+type Writer interface {
+	WriteRow(movie models.MovieExport) error
+	// Close completes the file. The export is not valid until it is called.
+	Close() error
+}
+
+// NewWriter writes the header, where the format has one, and returns a writer for the rows.
+func NewWriter(w io.Writer, format string, columns []Column) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatJSONL:
+		return newJSONLWriter(w, columns), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// ContentType returns the media type of the format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// listSeparator joins list values in CSV and spreadsheet cells, as the importer expects.
+const listSeparator = "|"
+
+// formulaPrefixes are the characters that make spreadsheets read a cell as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula keeps spreadsheets from running text as a formula by putting a quote in front,
+// which they hide. The importer drops the quote again, so exports still import as they were.
+func escapeFormula(text string) string {
+	if text != "" && strings.IndexByte(formulaPrefixes, text[0]) >= 0 {
+		return "'" + text
+	}
+	return text
+}
+
+// cellText formats a value for a text cell. Genres are written by name.
+func cellText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return fmt.Sprint(v)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprintf("%.2f", *v)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.DateOnly)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, listSeparator)
+	case []models.Genre:
+		names := make([]string, 0, len(v))
+		for _, genre := range v {
+			names = append(names, genre.GenreName)
+		}
+		return strings.Join(names, listSeparator)
+	}
+	return fmt.Sprint(value)
+}
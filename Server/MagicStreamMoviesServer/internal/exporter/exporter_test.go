@@ -0,0 +1,168 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func exportMovie() models.MovieExport {
+	released := time.Date(2016, 11, 11, 0, 0, 0, 0, time.UTC)
+	rating := 4.25
+	return models.MovieExport{
+		Movie: models.Movie{
+			ImdbID:      "tt2543164",
+			Title:       "Arrival, the film",
+			Genre:       []models.Genre{{GenreID: 1, GenreName: "Drama"}, {GenreID: 2, GenreName: "Sci-Fi"}},
+			ReleaseDate: &released,
+			Runtime:     116,
+			Keywords:    []string{"aliens", "language"},
+		},
+		AverageRating: &rating,
+		ReviewCount:   8,
+	}
+}
+
+func export(t *testing.T, format string, columns []Column, movies ...models.MovieExport) string {
+	t.Helper()
+	var out bytes.Buffer
+	writer, err := NewWriter(&out, format, columns)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	for _, movie := range movies {
+		assert.NoError(t, writer.WriteRow(movie))
+	}
+	assert.NoError(t, writer.Close())
+	return out.String()
+}
+
+func TestParseColumns(t *testing.T) {
+	columns, err := ParseColumns("")
+	assert.NoError(t, err)
+	assert.Len(t, columns, len(Columns))
+	assert.True(t, NeedsRatings(columns))
+
+	columns, err = ParseColumns("title, imdb_id")
+	assert.NoError(t, err)
+	if assert.Len(t, columns, 2) {
+		assert.Equal(t, "title", columns[0].Name)
+		assert.Equal(t, "imdb_id", columns[1].Name)
+	}
+	assert.False(t, NeedsRatings(columns))
+
+	_, err = ParseColumns("title,budget")
+	assert.ErrorContains(t, err, `"budget"`)
+}
+
+func TestCSV(t *testing.T) {
+	columns, _ := ParseColumns("imdb_id,title,genre,release_date,average_rating,keywords")
+	out := export(t, FormatCSV, columns, exportMovie())
+
+	lines := strings.Split(out, "\n")
+	assert.Equal(t, "imdb_id,title,genre,release_date,average_rating,keywords", lines[0])
+	assert.Equal(t, `tt2543164,"Arrival, the film",Drama|Sci-Fi,2016-11-11,4.25,aliens|language`, lines[1])
+}
+
+func TestCSV_EscapesFormulas(t *testing.T) {
+	columns, _ := ParseColumns("title,synopsis,keywords,runtime")
+	movie := exportMovie()
+	movie.Title = "=HYPERLINK(\"http://example.com\")"
+	movie.Synopsis = "@SUM(A1)"
+	movie.Keywords = []string{"-1+1", "aliens"}
+	out := export(t, FormatCSV, columns, movie)
+
+	lines := strings.Split(out, "\n")
+	assert.Equal(t, `"'=HYPERLINK(""http://example.com"")",'@SUM(A1),'-1+1|aliens,116`, lines[1])
+}
+
+func TestCSV_HeaderOnly(t *testing.T) {
+	columns, _ := ParseColumns("imdb_id,title")
+	out := export(t, FormatCSV, columns)
+
+	assert.Equal(t, "imdb_id,title\n", out)
+}
+
+func TestJSONL(t *testing.T) {
+	columns, _ := ParseColumns("imdb_id,genre,runtime,average_rating,release_date")
+	noReviews := exportMovie()
+	noReviews.AverageRating = nil
+	out := export(t, FormatJSONL, columns, exportMovie(), noReviews)
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, `{"imdb_id":"tt2543164","genre":[{"genre_id":1,"genre_name":"Drama"},{"genre_id":2,"genre_name":"Sci-Fi"}],"runtime":116,"average_rating":4.25,"release_date":"2016-11-11T00:00:00Z"}`, lines[0])
+		assert.Contains(t, lines[1], `"average_rating":null`)
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	columns, _ := ParseColumns("title,runtime")
+	out := export(t, FormatXLSX, columns, exportMovie())
+
+	archive, err := zip.NewReader(strings.NewReader(out), int64(len(out)))
+	if !assert.NoError(t, err) {
+		return
+	}
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if !assert.NoError(t, err) {
+			return
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		parts[file.Name] = string(content)
+	}
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "xl/workbook.xml")
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">title</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">Arrival, the film</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2"><v>116</v></c>`)
+}
+
+func TestXLSX_EscapesFormulas(t *testing.T) {
+	columns, _ := ParseColumns("title")
+	movie := exportMovie()
+	movie.Title = "+cmd|' /C calc'!A0"
+	out := export(t, FormatXLSX, columns, movie)
+
+	archive, err := zip.NewReader(strings.NewReader(out), int64(len(out)))
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, file := range archive.File {
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		r, _ := file.Open()
+		content, _ := io.ReadAll(r)
+		r.Close()
+		assert.Contains(t, string(content), `<t xml:space="preserve">&#39;+cmd|&#39; /C calc&#39;!A0</t>`)
+	}
+}
+
+func TestTruncateCell(t *testing.T) {
+	short := strings.Repeat("é", maxCellLength)
+	assert.Equal(t, short, truncateCell(short))
+
+	long := strings.Repeat("é", maxCellLength+10)
+	assert.Equal(t, short, truncateCell(long))
+
+	// Characters outside the Basic Multilingual Plane take two units in Excel's count.
+	emoji := strings.Repeat("🎬", maxCellLength)
+	assert.Equal(t, strings.Repeat("🎬", maxCellLength/2), truncateCell(emoji))
+}
+
+func TestColumnRef(t *testing.T) {
+	assert.Equal(t, "A", columnRef(0))
+	assert.Equal(t, "Z", columnRef(25))
+	assert.Equal(t, "AA", columnRef(26))
+}
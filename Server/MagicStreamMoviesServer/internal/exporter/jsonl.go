@@ -0,0 +1,44 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// jsonlWriter writes one object per movie with the selected columns as keys, in column order.
+// Values keep their JSON types, so the lines can be imported again.
+type jsonlWriter struct {
+	out     *bufio.Writer
+	columns []Column
+}
+
+func newJSONLWriter(w io.Writer, columns []Column) *jsonlWriter {
+	return &jsonlWriter{out: bufio.NewWriter(w), columns: columns}
+}
+
+func (w *jsonlWriter) WriteRow(movie models.MovieExport) error {
+	w.out.WriteByte('{')
+	for i, column := range w.columns {
+		if i > 0 {
+			w.out.WriteByte(',')
+		}
+		key, _ := json.Marshal(column.Name)
+		value, err := json.Marshal(column.Value(movie))
+		if err != nil {
+			return err
+		}
+		w.out.Write(key)
+		w.out.WriteByte(':')
+		w.out.Write(value)
+	}
+	w.out.WriteByte('}')
+	_, err := w.out.WriteString("\n")
+	return err
+}
+
+func (w *jsonlWriter) Close() error {
+	return w.out.Flush()
+}
@@ -0,0 +1,170 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"unicode/utf16"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// maxCellLength is the longest text an Excel cell holds, in UTF-16 code units.
+const maxCellLength = 32767
+
+// The fixed parts of a workbook with a single sheet. Style 1 is the bold header.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Movies" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+const (
+	sheetStart = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>` +
+		`<sheetData>`
+	sheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxWriter writes an Office Open XML workbook. The fixed parts are written first and the
+// sheet is streamed into the last zip entry, with inline strings so no shared string table has
+// to be built up front.
+type xlsxWriter struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	columns []Column
+	refs    []string
+	row     int
+}
+
+func newXLSXWriter(w io.Writer, columns []Column) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		entry, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(entry, part.content); err != nil {
+			return nil, err
+		}
+	}
+	entry, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &xlsxWriter{zip: archive, sheet: bufio.NewWriter(entry), columns: columns, refs: make([]string, len(columns))}
+	for i := range columns {
+		writer.refs[i] = columnRef(i)
+	}
+	writer.sheet.WriteString(sheetStart)
+	writer.startRow()
+	for i, column := range columns {
+		writer.stringCell(i, column.Name, true)
+	}
+	writer.sheet.WriteString("</row>")
+	return writer, nil
+}
+
+func (w *xlsxWriter) WriteRow(movie models.MovieExport) error {
+	w.startRow()
+	for i, column := range w.columns {
+		switch v := column.Value(movie).(type) {
+		case int:
+			w.numberCell(i, strconv.Itoa(v))
+		case *float64:
+			if v != nil {
+				w.numberCell(i, strconv.FormatFloat(*v, 'f', 2, 64))
+			}
+		default:
+			if text := cellText(v); text != "" {
+				w.stringCell(i, escapeFormula(text), false)
+			}
+		}
+	}
+	_, err := w.sheet.WriteString("</row>")
+	return err
+}
+
+func (w *xlsxWriter) Close() error {
+	w.sheet.WriteString(sheetEnd)
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func (w *xlsxWriter) startRow() {
+	w.row++
+	w.sheet.WriteString(`<row r="` + strconv.Itoa(w.row) + `">`)
+}
+
+func (w *xlsxWriter) cellStart(i int) string {
+	return `<c r="` + w.refs[i] + strconv.Itoa(w.row) + `"`
+}
+
+func (w *xlsxWriter) numberCell(i int, value string) {
+	w.sheet.WriteString(w.cellStart(i) + `><v>` + value + `</v></c>`)
+}
+
+func (w *xlsxWriter) stringCell(i int, text string, bold bool) {
+	text = truncateCell(text)
+	style := ""
+	if bold {
+		style = ` s="1"`
+	}
+	w.sheet.WriteString(w.cellStart(i) + style + ` t="inlineStr"><is><t xml:space="preserve">`)
+	// EscapeText also replaces characters XML cannot carry.
+	xml.EscapeText(w.sheet, []byte(text))
+	w.sheet.WriteString(`</t></is></c>`)
+}
+
+// truncateCell cuts text to the length Excel allows, without splitting a character.
+func truncateCell(text string) string {
+	if len(text) <= maxCellLength {
+		return text
+	}
+	units := 0
+	for i, r := range text {
+		units += utf16.RuneLen(r)
+		if units > maxCellLength {
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// columnRef returns the spreadsheet letters of the zero based column i: A, B, ..., Z, AA, ...
+func columnRef(i int) string {
+	ref := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		ref = string(rune('A'+(i-1)%26)) + ref
+	}
+	return ref
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
+)
+
+// requireAdmin lets admins through and answers everyone else with a 403.
+func requireAdmin(c *gin.Context) bool {
+	role, err := middleware.GetRoleFromContext(c)
+	if err != nil || role != "ADMIN" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: Admin access required"})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+)
+
+type AssistantHandler struct {
+	service  service.AssistantService
+	validate *validator.Validate
+}
+
+func NewAssistantHandler(s service.AssistantService) *AssistantHandler {
+	return &AssistantHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+// Chat godoc
+// @Summary      Chat with the movie assistant
+// @Description  Describe a mood or a request and get picks from the catalog, shaped by your favourite genres and the conversation so far. Send Accept: text/event-stream or stream=true to receive the answer as server-sent events: "delta" events carry text as it is generated and a final "done" event carries the full reply.
+// @Tags         assistant
+// @Accept       json
+// @Produce      json
+// @Produce      text/event-stream
+// @Param        request  body      models.ChatRequest  true  "Message"
+// @Param        stream   query     bool                false "Stream the answer as server-sent events"
+// @Success      200      {object}  models.AssistantReply
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      401      {object}  map[string]interface{}
+// @Failure      503      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /assistant/chat [post]
+func (h *AssistantHandler) Chat(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+
+	var req models.ChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+	req.Message = strings.TrimSpace(req.Message)
+	if err := h.validate.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	// The event stream is only opened once the first text arrives, so errors that happen
+	// before that still get a regular status code.
+	var onChunk func(string) error
+	streaming := false
+	if wantsEventStream(c) {
+		onChunk = func(text string) error {
+			if !streaming {
+				streaming = true
+				c.Header("Cache-Control", "no-cache")
+				c.Header("Connection", "keep-alive")
+				c.Status(http.StatusOK)
+			}
+			c.SSEvent("delta", gin.H{"text": text})
+			c.Writer.Flush()
+			return ctx.Err()
+		}
+	}
+
+	reply, err := h.service.Chat(ctx, userId, req.Message, onChunk)
+	if err != nil {
+		if streaming {
+			c.SSEvent("error", gin.H{"error": "Error generating answer"})
+			return
+		}
+		if errors.Is(err, service.ErrAssistantUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Assistant is not available"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating answer"})
+		return
+	}
+
+	if onChunk != nil {
+		if !streaming {
+			c.Header("Cache-Control", "no-cache")
+		}
+		c.SSEvent("done", reply)
+		return
+	}
+	c.JSON(http.StatusOK, reply)
+}
+
+// ResetChat godoc
+// @Summary      Start a new assistant conversation
+// @Description  Forget the current conversation with the movie assistant
+// @Tags         assistant
+// @Success      204
+// @Failure      401  {object}  map[string]interface{}
+// @Security     BearerAuth
+// @Router       /assistant/chat [delete]
+func (h *AssistantHandler) ResetChat(c *gin.Context) {
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+
+	h.service.ResetConversation(userId)
+	c.Status(http.StatusNoContent)
+}
+
+func wantsEventStream(c *gin.Context) bool {
+	return c.Query("stream") == "true" || strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+}
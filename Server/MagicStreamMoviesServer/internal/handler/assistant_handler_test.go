@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssistantChat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("JSON", func(t *testing.T) {
+		mockService := new(mocks.MockAssistantService)
+		assistantHandler := NewAssistantHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/assistant/chat", bytes.NewBufferString(`{"message":"something funny"}`))
+		c.Set("user_id", "u1")
+
+		reply := &models.AssistantReply{Message: "Try Airplane!", Picks: []models.Movie{{ImdbID: "tt0080339"}}}
+		mockService.On("Chat", mock.Anything, "u1", "something funny", mock.Anything).Return(reply, nil)
+
+		assistantHandler.Chat(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"imdb_id":"tt0080339"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		mockService := new(mocks.MockAssistantService)
+		assistantHandler := NewAssistantHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/assistant/chat", bytes.NewBufferString(`{"message":"something funny"}`))
+		c.Request.Header.Set("Accept", "text/event-stream")
+		c.Set("user_id", "u1")
+
+		reply := &models.AssistantReply{Message: "Try Airplane!", Picks: []models.Movie{}}
+		mockService.On("Chat", mock.Anything, "u1", "something funny", mock.Anything).
+			Run(func(args mock.Arguments) {
+				onChunk := args.Get(3).(func(string) error)
+				_ = onChunk("Try ")
+				_ = onChunk("Airplane!")
+			}).
+			Return(reply, nil)
+
+		assistantHandler.Chat(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/event-stream")
+		assert.Contains(t, w.Body.String(), "event:delta\ndata:{\"text\":\"Try \"}")
+		assert.Contains(t, w.Body.String(), "event:done")
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		mockService := new(mocks.MockAssistantService)
+		assistantHandler := NewAssistantHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/assistant/chat", bytes.NewBufferString(`{"message":"hi"}`))
+		c.Set("user_id", "u1")
+
+		mockService.On("Chat", mock.Anything, "u1", "hi", mock.Anything).Return(nil, service.ErrAssistantUnavailable)
+
+		assistantHandler.Chat(c)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("EmptyMessage", func(t *testing.T) {
+		mockService := new(mocks.MockAssistantService)
+		assistantHandler := NewAssistantHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/assistant/chat", bytes.NewBufferString(`{"message":"  "}`))
+		c.Set("user_id", "u1")
+
+		assistantHandler.Chat(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Chat")
+	})
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+)
+
+type CollectionHandler struct {
+	service  service.CollectionService
+	validate *validator.Validate
+}
+
+func NewCollectionHandler(s service.CollectionService) *CollectionHandler {
+	return &CollectionHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+// GetCollections godoc
+// @Summary      List collections
+// @Description  List collections such as franchises, ordered by name
+// @Tags         collections
+// @Produce      json
+// @Param        page       query     int     false  "Page number (default 1)"
+// @Param        page_size  query     int     false  "Page size (default 20, max 100)"
+// @Success      200        {array}   models.Collection
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /collections [get]
+func (h *CollectionHandler) GetCollections(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	collections, err := h.service.GetCollections(ctx, parsePagination(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching collections"})
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// GetCollection godoc
+// @Summary      Get a collection
+// @Description  Get a collection with its titles in viewing order
+// @Tags         collections
+// @Produce      json
+// @Param        id   path      string  true  "Collection ID"
+// @Success      200  {object}  models.CollectionDetail
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /collections/{id} [get]
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	collection, err := h.service.GetCollection(ctx, c.Param("id"))
+	if err != nil {
+		collectionError(c, err, "Error fetching collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// CreateCollection godoc
+// @Summary      Add a collection (Admin only)
+// @Description  Group catalog titles in viewing order. Every imdb_id must be in the catalog.
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        collection  body      models.Collection  true  "Collection"
+// @Success      201         {object}  models.Collection
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      403         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /collections [post]
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	collection, ok := h.bindCollection(c)
+	if !ok {
+		return
+	}
+
+	created, err := h.service.CreateCollection(ctx, collection)
+	if err != nil {
+		collectionError(c, err, "Error adding collection")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateCollection godoc
+// @Summary      Update a collection (Admin only)
+// @Description  Replace a collection's details and titles
+// @Tags         collections
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      string             true  "Collection ID"
+// @Param        collection  body      models.Collection  true  "Collection"
+// @Success      200         {object}  models.Collection
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      403         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /collections/{id} [put]
+func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	collection, ok := h.bindCollection(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.UpdateCollection(ctx, c.Param("id"), collection)
+	if err != nil {
+		collectionError(c, err, "Error updating collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteCollection godoc
+// @Summary      Delete a collection (Admin only)
+// @Description  Delete a collection; its titles stay in the catalog
+// @Tags         collections
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Collection ID"
+// @Success      204
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /collections/{id} [delete]
+func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	if err := h.service.DeleteCollection(ctx, c.Param("id")); err != nil {
+		collectionError(c, err, "Error deleting collection")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CollectionHandler) bindCollection(c *gin.Context) (models.Collection, bool) {
+	var collection models.Collection
+	if err := c.ShouldBindJSON(&collection); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return collection, false
+	}
+	if err := h.validate.Struct(&collection); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return collection, false
+	}
+	return collection, true
+}
+
+func collectionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidID), errors.Is(err, service.ErrUnknownTitles):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCollectionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateCollection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		mockService := new(mocks.MockCollectionService)
+		collectionHandler := NewCollectionHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/collections", bytes.NewBufferString(`{}`))
+		c.Set("role", "USER")
+
+		collectionHandler.CreateCollection(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("DuplicateTitles", func(t *testing.T) {
+		mockService := new(mocks.MockCollectionService)
+		collectionHandler := NewCollectionHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"name":"The Matrix Collection","imdb_ids":["tt0133093","tt0133093"]}`
+		c.Request = httptest.NewRequest("POST", "/collections", bytes.NewBufferString(body))
+		c.Set("role", "ADMIN")
+
+		collectionHandler.CreateCollection(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "CreateCollection")
+	})
+
+	t.Run("UnknownTitles", func(t *testing.T) {
+		mockService := new(mocks.MockCollectionService)
+		collectionHandler := NewCollectionHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"name":"The Matrix Collection","imdb_ids":["tt0133093","tt9999999"]}`
+		c.Request = httptest.NewRequest("POST", "/collections", bytes.NewBufferString(body))
+		c.Set("role", "ADMIN")
+
+		mockService.On("CreateCollection", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: tt9999999", service.ErrUnknownTitles))
+
+		collectionHandler.CreateCollection(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "tt9999999")
+	})
+}
+
+func TestGetCollection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockCollectionService)
+	collectionHandler := NewCollectionHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/collections/abc", nil)
+	c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+	mockService.On("GetCollection", mock.Anything, "abc").Return(&models.CollectionDetail{
+		Collection: models.Collection{Name: "The Matrix Collection"},
+		Movies:     []models.Movie{{ImdbID: "tt0133093", Title: "The Matrix"}},
+	}, nil)
+
+	collectionHandler.GetCollection(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"title":"The Matrix"`)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type DraftHandler struct {
+	service service.DraftService
+}
+
+func NewDraftHandler(s service.DraftService) *DraftHandler {
+	return &DraftHandler{service: s}
+}
+
+// GetDraft godoc
+// @Summary      Get a movie's AI draft (Admin only)
+// @Description  Get the generated synopsis, keywords and mood tags awaiting approval
+// @Tags         drafts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Success      200      {object}  models.MovieDraft
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/ai-draft [get]
+func (h *DraftHandler) GetDraft(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	draft, err := h.service.GetDraft(ctx, c.Param("imdb_id"))
+	if err != nil {
+		draftError(c, err, "Error fetching draft")
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// GenerateDraft godoc
+// @Summary      Generate a movie's AI draft (Admin only)
+// @Description  Ask the configured LLM for a synopsis, content keywords and mood tags and store them as a draft, replacing any earlier draft
+// @Tags         drafts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Success      201      {object}  models.MovieDraft
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      502      {object}  map[string]interface{}
+// @Failure      503      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/ai-draft [post]
+func (h *DraftHandler) GenerateDraft(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	draft, err := h.service.GenerateDraft(ctx, c.Param("imdb_id"))
+	if err != nil {
+		draftError(c, err, "Error generating draft")
+		return
+	}
+
+	c.JSON(http.StatusCreated, draft)
+}
+
+// ApproveDraft godoc
+// @Summary      Approve a movie's AI draft (Admin only)
+// @Description  Publish the draft to the movie's synopsis, keywords and mood tags. A body may correct any of the draft fields before they are published.
+// @Tags         drafts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string             true   "IMDB ID"
+// @Param        edits    body      models.MovieDraft  false  "Corrections to the draft"
+// @Success      200      {object}  models.Movie
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/ai-draft/approve [post]
+func (h *DraftHandler) ApproveDraft(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	var edits *models.MovieDraft
+	if c.Request.ContentLength > 0 {
+		edits = &models.MovieDraft{}
+		if err := c.ShouldBindJSON(edits); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+	}
+
+	movie, err := h.service.ApproveDraft(ctx, c.Param("imdb_id"), edits)
+	if err != nil {
+		draftError(c, err, "Error approving draft")
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+// DiscardDraft godoc
+// @Summary      Discard a movie's AI draft (Admin only)
+// @Description  Drop the draft without publishing it
+// @Tags         drafts
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Success      204
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/ai-draft [delete]
+func (h *DraftHandler) DiscardDraft(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	if err := h.service.DiscardDraft(ctx, c.Param("imdb_id")); err != nil {
+		draftError(c, err, "Error discarding draft")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func draftError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case errors.Is(err, service.ErrNoDraft):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie has no draft"})
+	case errors.Is(err, service.ErrDraftsUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Draft generation is not available"})
+	case errors.Is(err, service.ErrInvalidDraft):
+		c.JSON(http.StatusBadGateway, gin.H{"error": "The model returned an unusable draft"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
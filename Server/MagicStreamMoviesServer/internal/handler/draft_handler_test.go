@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateDraft(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService := new(mocks.MockDraftService)
+		draftHandler := NewDraftHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/ai-draft", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "USER")
+
+		draftHandler.GenerateDraft(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "GenerateDraft")
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		mockService := new(mocks.MockDraftService)
+		draftHandler := NewDraftHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/ai-draft", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("GenerateDraft", mock.Anything, "tt1").Return(nil, service.ErrDraftsUnavailable)
+
+		draftHandler.GenerateDraft(c)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+func TestApproveDraft(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("WithEdits", func(t *testing.T) {
+		mockService := new(mocks.MockDraftService)
+		draftHandler := NewDraftHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/ai-draft/approve", bytes.NewBufferString(`{"synopsis":"Edited."}`))
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("ApproveDraft", mock.Anything, "tt1", &models.MovieDraft{Synopsis: "Edited."}).
+			Return(&models.Movie{ImdbID: "tt1", Synopsis: "Edited."}, nil)
+
+		draftHandler.ApproveDraft(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"synopsis":"Edited."`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("NoDraft", func(t *testing.T) {
+		mockService := new(mocks.MockDraftService)
+		draftHandler := NewDraftHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/ai-draft/approve", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("ApproveDraft", mock.Anything, "tt1", (*models.MovieDraft)(nil)).Return(nil, service.ErrNoDraft)
+
+		draftHandler.ApproveDraft(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/exporter"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+)
+
+// exportTimeout is longer than the usual request timeout, since the whole catalog may be
+// written.
+const exportTimeout = 30 * time.Minute
+
+type ExportHandler struct {
+	service service.MovieService
+}
+
+func NewExportHandler(s service.MovieService) *ExportHandler {
+	return &ExportHandler{service: s}
+}
+
+// ExportMovies godoc
+// @Summary      Export the catalog (Admin only)
+// @Description  Download the movies matching the filters, ordered by title, as CSV, JSON Lines or an Excel workbook. The file is streamed as it is read from the database. Columns: imdb_id, content_type, title, genre, ranking_value, ranking_name, admin_review, average_rating, review_count, release_date, runtime, maturity_rating, original_language, country, synopsis, keywords, mood_tags, poster_path, youtube_id, backdrops, created_at, updated_at. List values are separated by "|" in CSV and Excel, and an edited CSV export can be imported again.
+// @Tags         admin
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     BearerAuth
+// @Param        format   query     string  false  "csv (default), jsonl or xlsx"
+// @Param        columns  query     string  false  "Comma separated columns to include, in order (default all)"
+// @Param        genre    query     string  false  "Genre names"
+// @Param        ranking  query     string  false  "Ranking names"
+// @Param        decade   query     int     false  "Release decades, e.g. 1990"
+// @Param        rating   query     int     false  "Average user rating buckets, 1 to 5"
+// @Param        type     query     string  false  "Content types: movie or series"
+// @Success      200      {file}    file
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /admin/export/movies [get]
+func (h *ExportHandler) ExportMovies(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), exportTimeout)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	format := c.DefaultQuery("format", exporter.FormatCSV)
+	if format != exporter.FormatCSV && format != exporter.FormatJSONL && format != exporter.FormatXLSX {
+		c.JSON(http.StatusBadRequest, gin.H{"error": exporter.ErrUnknownFormat.Error()})
+		return
+	}
+	columns, err := exporter.ParseColumns(c.Query("columns"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filters, err := parseFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The response is only started with the first movie, so a query that fails straight away
+	// still gets a JSON error.
+	var writer exporter.Writer
+	start := func() error {
+		filename := fmt.Sprintf("movies-%s.%s", time.Now().UTC().Format("20060102"), format)
+		c.Header("Content-Type", exporter.ContentType(format))
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Status(http.StatusOK)
+		writer, err = exporter.NewWriter(c.Writer, format, columns)
+		return err
+	}
+	err = h.service.ExportMovies(ctx, filters, exporter.NeedsRatings(columns), func(movie models.MovieExport) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return writer.WriteRow(movie)
+	})
+	if err == nil && writer == nil {
+		err = start()
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil {
+		if writer == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error exporting movies"})
+			return
+		}
+		// Part of the file has already been sent, so the status can no longer change.
+		log.Printf("movie export failed after the response started: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportMovies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("CSV", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		exportHandler := NewExportHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/admin/export/movies?columns=imdb_id,title&genre=Drama", nil)
+		c.Set("role", "ADMIN")
+
+		movies := []models.MovieExport{
+			{Movie: models.Movie{ImdbID: "tt2543164", Title: "Arrival"}},
+			{Movie: models.Movie{ImdbID: "tt0816692", Title: "Interstellar"}},
+		}
+		filters := models.MovieFilters{Genres: []string{"Drama"}}
+		mockService.On("ExportMovies", mock.Anything, filters, false, mock.Anything).Return(movies, nil)
+
+		exportHandler.ExportMovies(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), `attachment; filename="movies-`)
+		assert.Equal(t, "imdb_id,title\ntt2543164,Arrival\ntt0816692,Interstellar\n", w.Body.String())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		exportHandler := NewExportHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/admin/export/movies?format=jsonl", nil)
+		c.Set("role", "ADMIN")
+
+		mockService.On("ExportMovies", mock.Anything, models.MovieFilters{}, true, mock.Anything).Return([]models.MovieExport{}, nil)
+
+		exportHandler.ExportMovies(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("QueryFails", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		exportHandler := NewExportHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/admin/export/movies", nil)
+		c.Set("role", "ADMIN")
+
+		mockService.On("ExportMovies", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]models.MovieExport{}, errors.New("db error"))
+
+		exportHandler.ExportMovies(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "Error exporting movies")
+	})
+
+	t.Run("UnknownColumn", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		exportHandler := NewExportHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/admin/export/movies?columns=title,budget", nil)
+		c.Set("role", "ADMIN")
+
+		exportHandler.ExportMovies(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ExportMovies")
+	})
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		exportHandler := NewExportHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/admin/export/movies", nil)
+		c.Set("role", "USER")
+
+		exportHandler.ExportMovies(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "ExportMovies")
+	})
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// parseFilters reads the facet filters from the genre, ranking, decade, rating and type query
+// parameters. Each accepts several values, either repeated or comma separated.
+func parseFilters(c *gin.Context) (models.MovieFilters, error) {
+	filters := models.MovieFilters{
+		Genres:   queryValues(c, "genre"),
+		Rankings: queryValues(c, "ranking"),
+	}
+
+	for _, v := range queryValues(c, "decade") {
+		decade, err := strconv.Atoi(v)
+		if err != nil || decade%10 != 0 {
+			return filters, fmt.Errorf("invalid decade %q, expected a year like 1990", v)
+		}
+		filters.Decades = append(filters.Decades, decade)
+	}
+	for _, v := range queryValues(c, "rating") {
+		rating, err := strconv.Atoi(v)
+		if err != nil || rating < 1 || rating > 5 {
+			return filters, fmt.Errorf("invalid rating %q, expected 1 to 5", v)
+		}
+		filters.Ratings = append(filters.Ratings, rating)
+	}
+	for _, v := range queryValues(c, "type") {
+		if v != models.ContentTypeMovie && v != models.ContentTypeSeries {
+			return filters, fmt.Errorf("invalid type %q, expected movie or series", v)
+		}
+		filters.Types = append(filters.Types, v)
+	}
+	return filters, nil
+}
+
+func queryValues(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+)
+
+type GenreHandler struct {
+	service  service.GenreService
+	validate *validator.Validate
+}
+
+func NewGenreHandler(s service.GenreService) *GenreHandler {
+	return &GenreHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+// GetGenres godoc
+// @Summary      Get all genres
+// @Description  Get the list of genres, ordered by name
+// @Tags         genres
+// @Produce      json
+// @Success      200  {array}   models.Genre
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /genres [get]
+func (h *GenreHandler) GetGenres(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	genres, err := h.service.GetGenres(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching genres"})
+		return
+	}
+
+	c.JSON(http.StatusOK, genres)
+}
+
+// GetGenre godoc
+// @Summary      Get a genre
+// @Description  Get a genre by its ID
+// @Tags         genres
+// @Produce      json
+// @Param        id   path      int  true  "Genre ID"
+// @Success      200  {object}  models.Genre
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /genres/{id} [get]
+func (h *GenreHandler) GetGenre(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	id, ok := genreIDParam(c)
+	if !ok {
+		return
+	}
+
+	genre, err := h.service.GetGenre(ctx, id)
+	if err != nil {
+		genreError(c, err, "Error fetching genre")
+		return
+	}
+
+	c.JSON(http.StatusOK, genre)
+}
+
+// CreateGenre godoc
+// @Summary      Add a genre (Admin only)
+// @Description  Add a genre under the next free genre ID. Names are unique, ignoring case.
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        genre  body      models.GenreRequest  true  "Genre"
+// @Success      201    {object}  models.Genre
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      403    {object}  map[string]interface{}
+// @Failure      409    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /genres [post]
+func (h *GenreHandler) CreateGenre(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	req, ok := h.bindGenre(c)
+	if !ok {
+		return
+	}
+
+	genre, err := h.service.CreateGenre(ctx, req)
+	if err != nil {
+		genreError(c, err, "Error adding genre")
+		return
+	}
+
+	c.JSON(http.StatusCreated, genre)
+}
+
+// RenameGenre godoc
+// @Summary      Rename a genre (Admin only)
+// @Description  Rename a genre. The new name is applied to every movie in the genre and to users' favourite genres.
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int                  true  "Genre ID"
+// @Param        genre  body      models.GenreRequest  true  "Genre"
+// @Success      200    {object}  models.Genre
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      403    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      409    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /genres/{id} [put]
+func (h *GenreHandler) RenameGenre(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := genreIDParam(c)
+	if !ok {
+		return
+	}
+	req, ok := h.bindGenre(c)
+	if !ok {
+		return
+	}
+
+	genre, err := h.service.RenameGenre(ctx, id, req)
+	if err != nil {
+		genreError(c, err, "Error renaming genre")
+		return
+	}
+
+	c.JSON(http.StatusOK, genre)
+}
+
+// DeleteGenre godoc
+// @Summary      Delete a genre (Admin only)
+// @Description  Delete a genre that no movie is in; it is also removed from users' favourite genres. Merge genres that still have movies instead.
+// @Tags         genres
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Genre ID"
+// @Success      204
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /genres/{id} [delete]
+func (h *GenreHandler) DeleteGenre(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := genreIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteGenre(ctx, id); err != nil {
+		genreError(c, err, "Error deleting genre")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MergeGenre godoc
+// @Summary      Merge a genre into another (Admin only)
+// @Description  Consolidate a duplicate genre: its movies and users' favourites move to the target genre, then the genre is deleted
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int                true  "ID of the genre to merge away"
+// @Param        merge  body      models.GenreMerge  true  "Target genre"
+// @Success      200    {object}  models.Genre
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      403    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /genres/{id}/merge [post]
+func (h *GenreHandler) MergeGenre(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := genreIDParam(c)
+	if !ok {
+		return
+	}
+	var merge models.GenreMerge
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if err := h.validate.Struct(&merge); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	target, err := h.service.MergeGenre(ctx, id, merge)
+	if err != nil {
+		genreError(c, err, "Error merging genre")
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+func (h *GenreHandler) bindGenre(c *gin.Context) (models.GenreRequest, bool) {
+	var req models.GenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return req, false
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return req, false
+	}
+	return req, true
+}
+
+func genreIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid genre ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func genreError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrMergeIntoSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrGenreNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrGenreExists), errors.Is(err, service.ErrGenreInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateGenre(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		mockService := new(mocks.MockGenreService)
+		genreHandler := NewGenreHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/genres", bytes.NewBufferString(`{"genre_name":"Western"}`))
+		c.Set("role", "USER")
+
+		genreHandler.CreateGenre(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		mockService := new(mocks.MockGenreService)
+		genreHandler := NewGenreHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/genres", bytes.NewBufferString(`{"genre_name":"drama"}`))
+		c.Set("role", "ADMIN")
+
+		mockService.On("CreateGenre", mock.Anything, models.GenreRequest{GenreName: "drama"}).Return(nil, service.ErrGenreExists)
+
+		genreHandler.CreateGenre(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestMergeGenre(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockGenreService)
+		genreHandler := NewGenreHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/genres/12/merge", bytes.NewBufferString(`{"target_id":3}`))
+		c.Params = gin.Params{{Key: "id", Value: "12"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("MergeGenre", mock.Anything, 12, models.GenreMerge{TargetID: 3}).Return(&models.Genre{GenreID: 3, GenreName: "Sci-Fi"}, nil)
+
+		genreHandler.MergeGenre(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"genre_name":"Sci-Fi"`)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService := new(mocks.MockGenreService)
+		genreHandler := NewGenreHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/genres/scifi/merge", bytes.NewBufferString(`{"target_id":3}`))
+		c.Params = gin.Params{{Key: "id", Value: "scifi"}}
+		c.Set("role", "ADMIN")
+
+		genreHandler.MergeGenre(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "MergeGenre")
+	})
+}
+
+func TestDeleteGenre_InUse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockGenreService)
+	genreHandler := NewGenreHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("DELETE", "/genres/3", nil)
+	c.Params = gin.Params{{Key: "id", Value: "3"}}
+	c.Set("role", "ADMIN")
+
+	mockService.On("DeleteGenre", mock.Anything, 3).Return(service.ErrGenreInUse)
+
+	genreHandler.DeleteGenre(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
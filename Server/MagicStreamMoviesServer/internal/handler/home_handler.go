@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+)
+
+type HomeHandler struct {
+	service service.HomeService
+}
+
+func NewHomeHandler(s service.HomeService) *HomeHandler {
+	return &HomeHandler{
+		service: s,
+	}
+}
+
+// GetHome godoc
+// @Summary      Get home page rails
+// @Description  Get the ordered home page rails (Continue Watching, Recommended For You, Top Ranked, featured collections, New Arrivals, favourite genres, Trending). Anonymous users get a non-personalized version.
+// @Tags         home
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.HomePage
+// @Failure      401  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /home [get]
+func (h *HomeHandler) GetHome(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	// Anonymous requests have no user in the context and get the non-personalized page.
+	userId, _ := middleware.GetUserIdFromContext(c)
+
+	home, err := h.service.GetHome(ctx, userId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error building home page"})
+		return
+	}
+
+	c.JSON(http.StatusOK, home)
+}
+
+// GetRail godoc
+// @Summary      Get a page of a home rail
+// @Description  Get further pages of a single home page rail
+// @Tags         home
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type        path      string  true   "Rail type (continue_watching, recommended, top_ranked, new_arrivals, genre, trending, collection)"
+// @Param        genre       query     string  false  "Genre name, required for genre rails"
+// @Param        collection  query     string  false  "Collection ID, required for collection rails"
+// @Param        page        query     int     false  "Page number (default 1)"
+// @Param        page_size   query     int     false  "Page size (default HOME_RAIL_SIZE)"
+// @Success      200         {object}  models.Rail
+// @Failure      400         {object}  map[string]interface{}
+// @Failure      401         {object}  map[string]interface{}
+// @Failure      404         {object}  map[string]interface{}
+// @Failure      500         {object}  map[string]interface{}
+// @Router       /home/rails/{type} [get]
+func (h *HomeHandler) GetRail(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	userId, _ := middleware.GetUserIdFromContext(c)
+
+	railType := c.Param("type")
+	var key string
+	switch railType {
+	case models.RailGenre:
+		if key = c.Query("genre"); key == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "genre is required for genre rails"})
+			return
+		}
+	case models.RailCollection:
+		if key = c.Query("collection"); key == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "collection is required for collection rails"})
+			return
+		}
+	}
+
+	rail, err := h.service.GetRail(ctx, userId, railType, key, parsePagination(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownRail), errors.Is(err, service.ErrInvalidID):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrCollectionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrRailRequiresLogin):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching rail"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, rail)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetHome(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Anonymous", func(t *testing.T) {
+		mockService := new(mocks.MockHomeService)
+		homeHandler := NewHomeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/home", nil)
+		c.Request = req
+
+		mockService.On("GetHome", mock.Anything, "").Return(&models.HomePage{Rails: []models.Rail{}}, nil)
+
+		homeHandler.GetHome(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Personalized", func(t *testing.T) {
+		mockService := new(mocks.MockHomeService)
+		homeHandler := NewHomeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user_id", "user123")
+		req := httptest.NewRequest("GET", "/home", nil)
+		c.Request = req
+
+		mockService.On("GetHome", mock.Anything, "user123").Return(&models.HomePage{Personalized: true}, nil)
+
+		homeHandler.GetHome(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestGetRail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Unknown Rail", func(t *testing.T) {
+		mockService := new(mocks.MockHomeService)
+		homeHandler := NewHomeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "type", Value: "bogus"}}
+		req := httptest.NewRequest("GET", "/home/rails/bogus", nil)
+		c.Request = req
+
+		mockService.On("GetRail", mock.Anything, "", "bogus", "", models.Pagination{}).Return(nil, service.ErrUnknownRail)
+
+		homeHandler.GetRail(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Genre Required", func(t *testing.T) {
+		mockService := new(mocks.MockHomeService)
+		homeHandler := NewHomeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "type", Value: "genre"}}
+		req := httptest.NewRequest("GET", "/home/rails/genre", nil)
+		c.Request = req
+
+		homeHandler.GetRail(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetRail")
+	})
+	t.Run("Collection", func(t *testing.T) {
+		mockService := new(mocks.MockHomeService)
+		homeHandler := NewHomeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "type", Value: "collection"}}
+		req := httptest.NewRequest("GET", "/home/rails/collection?collection=abc", nil)
+		c.Request = req
+
+		mockService.On("GetRail", mock.Anything, "", "collection", "abc", models.Pagination{}).Return(nil, service.ErrCollectionNotFound)
+
+		homeHandler.GetRail(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
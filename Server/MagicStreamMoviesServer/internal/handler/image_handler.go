@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// imageCacheControl lets clients keep images for good: every upload is stored under a path
+// of its own, so a stored image never changes.
+const imageCacheControl = "public, max-age=31536000, immutable"
+
+type ImageHandler struct {
+	service service.ImageService
+}
+
+func NewImageHandler(s service.ImageService) *ImageHandler {
+	return &ImageHandler{service: s}
+}
+
+// UploadPoster godoc
+// @Summary      Upload a movie poster (Admin only)
+// @Description  Upload a JPEG, PNG or WebP poster. Thumbnail, medium and large copies are made as JPEG and WebP, and the large JPEG becomes the movie's poster_path. The previously uploaded poster is removed.
+// @Tags         movies
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Param        file     formData  file    true  "Poster image"
+// @Success      201      {object}  models.ImageSet
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      413      {object}  map[string]interface{}
+// @Failure      415      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/poster [post]
+func (h *ImageHandler) UploadPoster(c *gin.Context) {
+	h.upload(c, h.service.UploadPoster)
+}
+
+// UploadBackdrop godoc
+// @Summary      Upload a movie backdrop (Admin only)
+// @Description  Upload a JPEG, PNG or WebP backdrop. Thumbnail, medium and large copies are made as JPEG and WebP, and the large JPEG is added to the movie's backdrops.
+// @Tags         movies
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Param        file     formData  file    true  "Backdrop image"
+// @Success      201      {object}  models.ImageSet
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      413      {object}  map[string]interface{}
+// @Failure      415      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/backdrops [post]
+func (h *ImageHandler) UploadBackdrop(c *gin.Context) {
+	h.upload(c, h.service.UploadBackdrop)
+}
+
+func (h *ImageHandler) upload(c *gin.Context, save func(context.Context, string, io.Reader) (*models.ImageSet, error)) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid multipart upload"})
+		return
+	}
+	defer file.Close()
+
+	image, err := save(ctx, c.Param("imdb_id"), file)
+	if err != nil {
+		imageError(c, err, "Error uploading image")
+		return
+	}
+
+	c.JSON(http.StatusCreated, image)
+}
+
+// GetImage godoc
+// @Summary      Get an image
+// @Description  Serve an uploaded image or one of its resized copies, as linked from a movie's images. Images never change, so they are cached for a year.
+// @Tags         images
+// @Produce      image/jpeg,image/png,image/webp
+// @Param        path  path  string  true  "Image path"
+// @Success      200
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /images/{path} [get]
+func (h *ImageHandler) GetImage(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	key := strings.TrimPrefix(c.Param("path"), "/")
+	blob, err := h.service.OpenImage(ctx, key)
+	if err != nil {
+		imageError(c, err, "Error fetching image")
+		return
+	}
+	defer func() {
+		if err := blob.Close(); err != nil {
+			log.Printf("failed to close image %s: %v", key, err)
+		}
+	}()
+
+	header := c.Writer.Header()
+	header.Set("Cache-Control", imageCacheControl)
+	header.Set("Content-Type", blob.ContentType)
+	header.Set("ETag", `"`+key+`"`)
+	header.Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, "", blob.ModTime, blob)
+}
+
+func imageError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case errors.Is(err, service.ErrImageNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTooManyBackdrops):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrImageTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUnsupportedImage):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type readSeekNopCloser struct {
+	*strings.Reader
+}
+
+func (readSeekNopCloser) Close() error { return nil }
+
+func imageUpload(t *testing.T, field string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "poster.png")
+	assert.NoError(t, err)
+	part.Write([]byte("\x89PNG"))
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest("POST", "/movie/tt2543164/poster", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadPoster(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockImageService)
+		imageHandler := NewImageHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = imageUpload(t, "file")
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}}
+		c.Set("role", "ADMIN")
+
+		poster := &models.ImageSet{Width: 400, Height: 600, Variants: map[string]string{"large": "http://localhost:8080/images/posters/tt2543164/abc/large.jpg"}}
+		mockService.On("UploadPoster", mock.Anything, "tt2543164", mock.Anything).Return(poster, nil)
+
+		imageHandler.UploadPoster(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"width":400`)
+		assert.NotContains(t, w.Body.String(), `"key"`)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService := new(mocks.MockImageService)
+		imageHandler := NewImageHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = imageUpload(t, "file")
+		c.Set("role", "USER")
+
+		imageHandler.UploadPoster(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "UploadPoster", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		mockService := new(mocks.MockImageService)
+		imageHandler := NewImageHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = imageUpload(t, "image")
+		c.Set("role", "ADMIN")
+
+		imageHandler.UploadPoster(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := map[error]int{
+			mongo.ErrNoDocuments:        http.StatusNotFound,
+			service.ErrUnsupportedImage: http.StatusUnsupportedMediaType,
+			service.ErrImageTooLarge:    http.StatusRequestEntityTooLarge,
+			service.ErrTooManyBackdrops: http.StatusConflict,
+		}
+		for err, status := range cases {
+			mockService := new(mocks.MockImageService)
+			imageHandler := NewImageHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = imageUpload(t, "file")
+			c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}}
+			c.Set("role", "ADMIN")
+
+			mockService.On("UploadBackdrop", mock.Anything, "tt2543164", mock.Anything).Return(nil, err)
+
+			imageHandler.UploadBackdrop(c)
+
+			assert.Equal(t, status, w.Code, err.Error())
+		}
+	})
+}
+
+func TestGetImage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockImageService)
+		imageHandler := NewImageHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/images/posters/tt2543164/abc/thumb.webp", nil)
+		c.Params = gin.Params{{Key: "path", Value: "/posters/tt2543164/abc/thumb.webp"}}
+
+		blob := &storage.Blob{
+			ReadSeekCloser: readSeekNopCloser{strings.NewReader("RIFF....WEBP")},
+			BlobInfo:       storage.BlobInfo{Size: 12, ContentType: "image/webp", ModTime: time.Now()},
+		}
+		mockService.On("OpenImage", mock.Anything, "posters/tt2543164/abc/thumb.webp").Return(blob, nil)
+
+		imageHandler.GetImage(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/webp", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Cache-Control"), "immutable")
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "RIFF....WEBP", w.Body.String())
+	})
+
+	t.Run("NotModified", func(t *testing.T) {
+		mockService := new(mocks.MockImageService)
+		imageHandler := NewImageHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/images/posters/tt2543164/abc/thumb.jpg", nil)
+		c.Request.Header.Set("If-None-Match", `"posters/tt2543164/abc/thumb.jpg"`)
+		c.Params = gin.Params{{Key: "path", Value: "/posters/tt2543164/abc/thumb.jpg"}}
+
+		blob := &storage.Blob{
+			ReadSeekCloser: readSeekNopCloser{strings.NewReader("jpeg")},
+			BlobInfo:       storage.BlobInfo{Size: 4, ContentType: "image/jpeg"},
+		}
+		mockService.On("OpenImage", mock.Anything, "posters/tt2543164/abc/thumb.jpg").Return(blob, nil)
+
+		imageHandler.GetImage(c)
+
+		// Nothing is written for a 304, so the status is only on gin's writer.
+		assert.Equal(t, http.StatusNotModified, c.Writer.Status())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService := new(mocks.MockImageService)
+		imageHandler := NewImageHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/images/missing.jpg", nil)
+		c.Params = gin.Params{{Key: "path", Value: "/missing.jpg"}}
+
+		mockService.On("OpenImage", mock.Anything, "missing.jpg").Return(nil, service.ErrImageNotFound)
+
+		imageHandler.GetImage(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/importer"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+)
+
+// importTimeout is longer than the usual request timeout, since a large file takes a while to
+// read and write.
+const importTimeout = 30 * time.Minute
+
+type ImportHandler struct {
+	service service.ImportService
+}
+
+func NewImportHandler(s service.ImportService) *ImportHandler {
+	return &ImportHandler{service: s}
+}
+
+// ImportMovies godoc
+// @Summary      Bulk import movies (Admin only)
+// @Description  Upsert movies by imdb_id from a CSV, JSON Lines or IMDb title.basics.tsv file, optionally gzipped. The file is streamed, so it can be of any size. Each row is applied over the stored movie and validated like a movie added through POST /movie; rows that fail are listed in the report and the others are imported. CSV files start with a header of movie JSON field names, with list values (genre, keywords, backdrops) separated by "|" and genres given by ID or name.
+// @Tags         admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file     formData  file    true   "Import file"
+// @Param        format   query     string  false  "csv, jsonl or imdb; inferred from the file name when omitted"
+// @Param        dry_run  query     bool    false  "Validate the rows without writing them"
+// @Success      200      {object}  models.ImportReport
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /admin/import/movies [post]
+func (h *ImportHandler) ImportMovies(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), importTimeout)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	// The multipart body is read part by part instead of through FormFile, which would buffer
+	// the whole upload first.
+	parts, err := c.Request.MultipartReader()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Expected a multipart upload with a file field"})
+		return
+	}
+	for {
+		part, err := parts.NextPart()
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid multipart upload"})
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		reader, err := importer.Open(part, part.FileName(), c.Query("format"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		report, err := h.service.ImportMovies(ctx, reader, c.Query("dry_run") == "true")
+		if err != nil {
+			importError(c, report, err)
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
+}
+
+// importError reports an import that stopped early, with the report of the rows read so far.
+// The batches before the failure are already saved.
+func importError(c *gin.Context, report *models.ImportReport, err error) {
+	if errors.Is(err, service.ErrImportFile) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "report": report})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Error importing movies", "report": report})
+}
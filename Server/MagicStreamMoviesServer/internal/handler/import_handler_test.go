@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func importUpload(t *testing.T, filename string, content string) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestImportMovies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("DryRun", func(t *testing.T) {
+		mockService := new(mocks.MockImportService)
+		importHandler := NewImportHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body, contentType := importUpload(t, "movies.csv", "imdb_id,title\ntt2543164,Arrival\n")
+		c.Request = httptest.NewRequest("POST", "/admin/import/movies?dry_run=true", body)
+		c.Request.Header.Set("Content-Type", contentType)
+		c.Set("role", "ADMIN")
+
+		report := &models.ImportReport{DryRun: true, Rows: 1, Created: 1, Errors: []models.ImportRowError{}}
+		mockService.On("ImportMovies", mock.Anything, mock.Anything, true).Return(report, nil)
+
+		importHandler.ImportMovies(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"created":1`)
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		mockService := new(mocks.MockImportService)
+		importHandler := NewImportHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body, contentType := importUpload(t, "movies.xml", "<movies/>")
+		c.Request = httptest.NewRequest("POST", "/admin/import/movies", body)
+		c.Request.Header.Set("Content-Type", contentType)
+		c.Set("role", "ADMIN")
+
+		importHandler.ImportMovies(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ImportMovies")
+	})
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		mockService := new(mocks.MockImportService)
+		importHandler := NewImportHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body, contentType := importUpload(t, "movies.csv", "imdb_id\n")
+		c.Request = httptest.NewRequest("POST", "/admin/import/movies", body)
+		c.Request.Header.Set("Content-Type", contentType)
+		c.Set("role", "USER")
+
+		importHandler.ImportMovies(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type MetadataHandler struct {
+	service service.MetadataService
+}
+
+func NewMetadataHandler(s service.MetadataService) *MetadataHandler {
+	return &MetadataHandler{service: s}
+}
+
+// EnrichMovie godoc
+// @Summary      Fill in a movie from the metadata provider (Admin only)
+// @Description  Look the movie up by IMDb ID in the configured metadata provider and fill in its empty title, poster, genres, runtime, synopsis and release date. With overwrite=true every field the provider knows is replaced. Genres missing from the genre list are left out. Nothing is saved when the result would not be a valid movie.
+// @Tags         movies
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id    path      string  true   "IMDB ID"
+// @Param        overwrite  query     bool    false  "Replace fields that are already set"
+// @Success      200        {object}  models.Movie
+// @Failure      403        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      422        {object}  map[string]interface{}
+// @Failure      502        {object}  map[string]interface{}
+// @Failure      503        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/enrich [post]
+func (h *MetadataHandler) EnrichMovie(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	var movie *models.Movie
+	movie, err := h.service.EnrichMovie(ctx, c.Param("imdb_id"), c.Query("overwrite") == "true")
+	if err != nil {
+		metadataError(c, err, "Error enriching movie")
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+func metadataError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case errors.Is(err, service.ErrMetadataNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrMetadataUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Metadata lookups are not available"})
+	case errors.Is(err, service.ErrInvalidMetadata):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrMetadataProvider):
+		c.JSON(http.StatusBadGateway, gin.H{"error": "The metadata provider could not be reached"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestEnrichMovie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockMetadataService)
+		metadataHandler := NewMetadataHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt2543164/enrich?overwrite=true", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("EnrichMovie", mock.Anything, "tt2543164", true).Return(&models.Movie{ImdbID: "tt2543164", Title: "Arrival"}, nil)
+
+		metadataHandler.EnrichMovie(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"title":"Arrival"`)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := map[error]int{
+			mongo.ErrNoDocuments:           http.StatusNotFound,
+			service.ErrMetadataNotFound:    http.StatusNotFound,
+			service.ErrMetadataUnavailable: http.StatusServiceUnavailable,
+			service.ErrMetadataProvider:    http.StatusBadGateway,
+		}
+		for err, status := range cases {
+			mockService := new(mocks.MockMetadataService)
+			metadataHandler := NewMetadataHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/movie/tt2543164/enrich", nil)
+			c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}}
+			c.Set("role", "ADMIN")
+
+			mockService.On("EnrichMovie", mock.Anything, "tt2543164", false).Return(nil, err)
+
+			metadataHandler.EnrichMovie(c)
+
+			assert.Equal(t, status, w.Code, err.Error())
+		}
+	})
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		mockService := new(mocks.MockMetadataService)
+		metadataHandler := NewMetadataHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt2543164/enrich", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}}
+		c.Set("role", "USER")
+
+		metadataHandler.EnrichMovie(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "EnrichMovie")
+	})
+}
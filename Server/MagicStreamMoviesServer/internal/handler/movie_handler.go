@@ -2,7 +2,9 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,30 +17,48 @@ import (
 
 type MovieHandler struct {
 	service  service.MovieService
+	drafts   service.DraftService
+	metadata service.MetadataService
 	validate *validator.Validate
 }
 
-func NewMovieHandler(s service.MovieService) *MovieHandler {
+// NewMovieHandler builds the movie handler. drafts and metadata may be nil, in which case
+// movies are never enriched or auto-filled when they are added.
+func NewMovieHandler(s service.MovieService, drafts service.DraftService, metadata service.MetadataService) *MovieHandler {
 	return &MovieHandler{
 		service:  s,
+		drafts:   drafts,
+		metadata: metadata,
 		validate: validator.New(),
 	}
 }
 
 // GetMovies godoc
 // @Summary      Get all movies
-// @Description  Get a list of all movies
+// @Description  Get a list of all movies, optionally narrowed by facet filters. Filters take several values, repeated or comma separated.
 // @Tags         movies
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.Movie
-// @Failure      500  {object}  map[string]interface{}
+// @Param        genre    query     string  false  "Genre names"
+// @Param        ranking  query     string  false  "Ranking names"
+// @Param        decade   query     string  false  "Release decades, e.g. 1990"
+// @Param        rating   query     string  false  "Average user rating buckets (1-5)"
+// @Param        type     query     string  false  "Content types: movie or series"
+// @Success      200      {array}   models.Movie
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
 // @Router       /movies [get]
 func (h *MovieHandler) GetMovies(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 	defer cancel()
 
-	movies, err := h.service.GetMovies(ctx)
+	filters, err := parseFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	movies, err := h.service.GetMovies(ctx, filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching movies"})
 		return
@@ -47,25 +67,37 @@ func (h *MovieHandler) GetMovies(c *gin.Context) {
 	c.JSON(http.StatusOK, movies)
 }
 
-// GetAllGenres godoc
-// @Summary      Get all genres
-// @Description  Get a list of all unique genres
+// GetFacets godoc
+// @Summary      Get movie facets
+// @Description  Get the number of movies per genre, ranking name, release decade, average rating bucket and content type for the given facet filters
 // @Tags         movies
 // @Produce      json
-// @Success      200  {array}   models.Genre
-// @Failure      500  {object}  map[string]interface{}
-// @Router       /genres [get]
-func (h *MovieHandler) GetAllGenres(c *gin.Context) {
+// @Param        genre    query     string  false  "Genre names"
+// @Param        ranking  query     string  false  "Ranking names"
+// @Param        decade   query     string  false  "Release decades, e.g. 1990"
+// @Param        rating   query     string  false  "Average user rating buckets (1-5)"
+// @Param        type     query     string  false  "Content types: movie or series"
+// @Success      200      {object}  models.Facets
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movies/facets [get]
+func (h *MovieHandler) GetFacets(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 	defer cancel()
 
-	genres, err := h.service.GetAllGenres(ctx)
+	filters, err := parseFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	facets, err := h.service.GetFacets(ctx, filters)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching genres"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching facets"})
 		return
 	}
 
-	c.JSON(http.StatusOK, genres)
+	c.JSON(http.StatusOK, facets)
 }
 
 // GetMovie godoc
@@ -104,39 +136,84 @@ func (h *MovieHandler) GetMovie(c *gin.Context) {
 }
 
 // AddMovie godoc
-// @Summary      Add a new movie
-// @Description  Add a new movie to the database
+// @Summary      Add a new movie (Admin only)
+// @Description  Add a new movie to the database. Genres are referenced by genre_id and must exist; their names are taken from the genre list. With autofill=true the title, poster, genres, runtime, synopsis and release date left out of the body are looked up by imdb_id in the metadata provider first. With enrich=true a synopsis, keywords and mood tags are generated as a draft for an admin to approve.
 // @Tags         movies
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        movie  body      models.Movie  true  "Movie Data"
-// @Success      201    {object}  map[string]interface{}
-// @Failure      400    {object}  map[string]interface{}
-// @Failure      500    {object}  map[string]interface{}
+// @Param        movie     body      models.Movie  true   "Movie Data"
+// @Param        autofill  query     bool          false  "Fill missing details from the metadata provider"
+// @Param        enrich    query     bool          false  "Generate an AI draft for the movie"
+// @Success      201       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Failure      403       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]interface{}
+// @Failure      502       {object}  map[string]interface{}
+// @Failure      503       {object}  map[string]interface{}
+// @Failure      500       {object}  map[string]interface{}
 // @Router       /movie [post]
 func (h *MovieHandler) AddMovie(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 	defer cancel()
 
+	// Autofill and enrich call paid external services, and the catalog is the admins' to curate.
+	if !requireAdmin(c) {
+		return
+	}
+
 	var movie models.Movie
 	if err := c.ShouldBindJSON(&movie); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
 
+	if c.Query("autofill") == "true" && movie.ImdbID != "" {
+		if h.metadata == nil {
+			metadataError(c, service.ErrMetadataUnavailable, "")
+			return
+		}
+		if err := h.metadata.FillMovie(ctx, &movie); err != nil {
+			metadataError(c, err, "Error looking up movie details")
+			return
+		}
+	}
+
 	if err := h.validate.Struct(&movie); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
 		return
 	}
 
+	// Drafts only come from the enrichment step, memberships from the collections, images
+	// from the uploads, stream sources from the stream endpoint and subtitles from the
+	// subtitle endpoints.
+	movie.AIDraft = nil
+	movie.Collections = nil
+	movie.Images = nil
+	movie.Stream = nil
+	movie.Subtitles = nil
 	err := h.service.AddMovie(ctx, movie)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error adding movie"})
+		if errors.Is(err, service.ErrUnknownGenres) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error adding movie"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Movie added successfully"})
+	response := gin.H{"message": "Movie added successfully"}
+	if c.Query("enrich") == "true" && h.drafts != nil {
+		// The movie is saved either way; a failed draft can be generated again later.
+		draft, err := h.drafts.GenerateDraft(ctx, movie.ImdbID)
+		if err != nil {
+			response["ai_draft_error"] = "Error generating draft"
+		} else {
+			response["ai_draft"] = draft
+		}
+	}
+
+	c.JSON(http.StatusCreated, response)
 }
 
 // UpdateAdminReview godoc
@@ -191,11 +268,13 @@ func (h *MovieHandler) UpdateAdminReview(c *gin.Context) {
 
 // GetRecommendedMovies godoc
 // @Summary      Get recommended movies
-// @Description  Get recommended movies based on user's favorite genres
+// @Description  Get recommended movies based on user's favorite genres, excluding movies already watched or reviewed
 // @Tags         movies
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.Movie
+// @Param        page       query     int  false  "Page number (default 1)"
+// @Param        page_size  query     int  false  "Page size (default RECOMMENDED_MOVIE_LIMIT)"
+// @Success      200  {array}   models.RecommendedMovie
 // @Failure      401  {object}  map[string]interface{}
 // @Failure      500  {object}  map[string]interface{}
 // @Router       /recommendedMovies [get]
@@ -209,7 +288,7 @@ func (h *MovieHandler) GetRecommendedMovies(c *gin.Context) {
 		return
 	}
 
-	movies, err := h.service.GetRecommendedMovies(ctx, userId)
+	movies, err := h.service.GetRecommendedMovies(ctx, userId, parsePagination(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching recommended movies"})
 		return
@@ -217,3 +296,181 @@ func (h *MovieHandler) GetRecommendedMovies(c *gin.Context) {
 
 	c.JSON(http.StatusOK, movies)
 }
+
+// ExplainRecommendation godoc
+// @Summary      Explain a recommendation
+// @Description  Get the full score breakdown and reasons for recommending a movie to the current user
+// @Tags         movies
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Success      200      {object}  models.RecommendationExplanation
+// @Failure      401      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /recommendedMovies/{imdb_id}/explain [get]
+func (h *MovieHandler) ExplainRecommendation(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+
+	explanation, err := h.service.ExplainRecommendation(ctx, userId, c.Param("imdb_id"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error explaining recommendation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, explanation)
+}
+
+// GetSimilarMovies godoc
+// @Summary      Get similar movies
+// @Description  Get movies similar in content to the given movie ("More like this"), by genre overlap, ranking proximity and text similarity
+// @Tags         movies
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true   "IMDB ID"
+// @Param        limit    query     int     false  "Maximum number of movies (default 10)"
+// @Success      200      {array}   models.Movie
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/similar [get]
+func (h *MovieHandler) GetSimilarMovies(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	limit := 10
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = v
+	}
+
+	movies, err := h.service.GetSimilarMovies(ctx, c.Param("imdb_id"), limit)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching similar movies"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, movies)
+}
+
+// RecordWatch godoc
+// @Summary      Record watch progress
+// @Description  Record that the current user watched a movie, with their latest progress. Series are watched per episode through /series/{imdb_id}/seasons/{season}/episodes/{episode}/watch.
+// @Tags         movies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id   path      string  true  "IMDB ID"
+// @Param        progress  body      map[string]interface{}  true  "Progress JSON {\"progress_seconds\": 120, \"completed\": false}"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Failure      401       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]interface{}
+// @Failure      500       {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/watch [post]
+func (h *MovieHandler) RecordWatch(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+
+	var req struct {
+		ProgressSeconds int  `json:"progress_seconds" validate:"gte=0"`
+		Completed       bool `json:"completed"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if err := h.validate.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	err = h.service.RecordWatch(ctx, models.WatchHistory{
+		UserID:          userId,
+		ImdbID:          c.Param("imdb_id"),
+		ProgressSeconds: req.ProgressSeconds,
+		Completed:       req.Completed,
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+		} else if errors.Is(err, service.ErrWatchSeriesTitle) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Series progress is recorded per episode"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error recording watch progress"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Watch progress recorded"})
+}
+
+// AddReview godoc
+// @Summary      Review a movie
+// @Description  Rate a movie from 1 to 5 with an optional comment; reviewing again replaces the previous review
+// @Tags         movies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Param        review   body      map[string]interface{}  true  "Review JSON {\"rating\": 4, \"comment\": \"Great\"}"
+// @Success      201      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      401      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/reviews [post]
+func (h *MovieHandler) AddReview(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+
+	var review models.Review
+	if err := c.ShouldBindJSON(&review); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	review.UserID = userId
+	review.ImdbID = c.Param("imdb_id")
+
+	if err := h.validate.Struct(&review); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	if err := h.service.AddReview(ctx, review); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error saving review"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Review saved successfully"})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/v2/mongo"
@@ -21,7 +22,7 @@ func TestGetMovies(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockService := new(mocks.MockMovieService)
-		movieHandler := NewMovieHandler(mockService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -33,7 +34,7 @@ func TestGetMovies(t *testing.T) {
 			{Title: "Movie 2", ImdbID: "tt2"},
 		}
 
-		mockService.On("GetMovies", mock.Anything).Return(movies, nil)
+		mockService.On("GetMovies", mock.Anything, models.MovieFilters{}).Return(movies, nil)
 
 		movieHandler.GetMovies(c)
 
@@ -43,20 +44,78 @@ func TestGetMovies(t *testing.T) {
 
 	t.Run("Error", func(t *testing.T) {
 		mockService := new(mocks.MockMovieService)
-		movieHandler := NewMovieHandler(mockService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		req := httptest.NewRequest("GET", "/movies", nil)
 		c.Request = req
 
-		mockService.On("GetMovies", mock.Anything).Return(nil, errors.New("db error"))
+		mockService.On("GetMovies", mock.Anything, models.MovieFilters{}).Return(nil, errors.New("db error"))
 
 		movieHandler.GetMovies(c)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("Filters", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/movies?genre=Drama,Comedy&genre=Action&decade=1990&rating=4&rating=5", nil)
+		c.Request = req
+
+		filters := models.MovieFilters{
+			Genres:  []string{"Drama", "Comedy", "Action"},
+			Decades: []int{1990},
+			Ratings: []int{4, 5},
+		}
+		mockService.On("GetMovies", mock.Anything, filters).Return([]models.Movie{}, nil)
+
+		movieHandler.GetMovies(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidDecade", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/movies?decade=1995", nil)
+		c.Request = req
+
+		movieHandler.GetMovies(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetFacets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockMovieService)
+	movieHandler := NewMovieHandler(mockService, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req := httptest.NewRequest("GET", "/movies/facets?ranking=Excellent", nil)
+	c.Request = req
+
+	mockService.On("GetFacets", mock.Anything, models.MovieFilters{Rankings: []string{"Excellent"}}).Return(&models.Facets{
+		Genres: []models.FacetCount{{Value: "Drama", Count: 3}},
+	}, nil)
+
+	movieHandler.GetFacets(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `{"value":"Drama","count":3}`)
+	mockService.AssertExpectations(t)
 }
 
 func TestGetMovie(t *testing.T) {
@@ -64,7 +123,7 @@ func TestGetMovie(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockService := new(mocks.MockMovieService)
-		movieHandler := NewMovieHandler(mockService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -86,7 +145,7 @@ func TestGetMovie(t *testing.T) {
 
 	t.Run("Not Found", func(t *testing.T) {
 		mockService := new(mocks.MockMovieService)
-		movieHandler := NewMovieHandler(mockService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
@@ -109,10 +168,11 @@ func TestAddMovie(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockService := new(mocks.MockMovieService)
-		movieHandler := NewMovieHandler(mockService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("role", "ADMIN")
 
 		movie := models.Movie{
 			Title:      "Test Movie",
@@ -142,12 +202,97 @@ func TestAddMovie(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("Enrich", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		mockDrafts := new(mocks.MockDraftService)
+		movieHandler := NewMovieHandler(mockService, mockDrafts, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("role", "ADMIN")
+
+		movie := models.Movie{
+			Title:      "Test Movie",
+			ImdbID:     "tt1234567",
+			PosterPath: "http://example.com/poster.jpg",
+			YouTubeID:  "dQw4w9WgXcQ",
+			Genre:      []models.Genre{{GenreID: 1, GenreName: "Action"}},
+			Ranking:    models.Ranking{RankingValue: 8, RankingName: "Good"},
+		}
+
+		mockService.On("AddMovie", mock.Anything, mock.Anything).Return(nil)
+		mockDrafts.On("GenerateDraft", mock.Anything, "tt1234567").Return(&models.MovieDraft{Synopsis: "Generated."}, nil)
+
+		jsonBytes, _ := json.Marshal(movie)
+		c.Request = httptest.NewRequest("POST", "/movie?enrich=true", bytes.NewBuffer(jsonBytes))
+
+		movieHandler.AddMovie(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"synopsis":"Generated."`)
+		mockDrafts.AssertExpectations(t)
+	})
+
+	t.Run("Autofill", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		mockMetadata := new(mocks.MockMetadataService)
+		movieHandler := NewMovieHandler(mockService, nil, mockMetadata)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("role", "ADMIN")
+
+		movie := models.Movie{
+			ImdbID:    "tt1234567",
+			YouTubeID: "dQw4w9WgXcQ",
+			Ranking:   models.Ranking{RankingValue: 8, RankingName: "Good"},
+		}
+
+		mockMetadata.On("FillMovie", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			filled := args.Get(1).(*models.Movie)
+			filled.Title = "Test Movie"
+			filled.PosterPath = "http://example.com/poster.jpg"
+			filled.Genre = []models.Genre{{GenreID: 1, GenreName: "Action"}}
+		}).Return(nil)
+		mockService.On("AddMovie", mock.Anything, mock.MatchedBy(func(m models.Movie) bool {
+			return m.Title == "Test Movie" && m.YouTubeID == "dQw4w9WgXcQ"
+		})).Return(nil)
+
+		jsonBytes, _ := json.Marshal(movie)
+		c.Request = httptest.NewRequest("POST", "/movie?autofill=true", bytes.NewBuffer(jsonBytes))
+
+		movieHandler.AddMovie(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Autofill Not Found", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		mockMetadata := new(mocks.MockMetadataService)
+		movieHandler := NewMovieHandler(mockService, nil, mockMetadata)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("role", "ADMIN")
+
+		mockMetadata.On("FillMovie", mock.Anything, mock.Anything).Return(service.ErrMetadataNotFound)
+
+		c.Request = httptest.NewRequest("POST", "/movie?autofill=true", bytes.NewBufferString(`{"imdb_id":"tt1234567"}`))
+
+		movieHandler.AddMovie(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertNotCalled(t, "AddMovie")
+	})
+
 	t.Run("Validation Error", func(t *testing.T) {
 		mockService := new(mocks.MockMovieService)
-		movieHandler := NewMovieHandler(mockService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Set("role", "ADMIN")
 
 		movie := models.Movie{
 			Title: "Incomplete Movie",
@@ -162,4 +307,151 @@ func TestAddMovie(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		mockService.AssertNotCalled(t, "AddMovie")
 	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		mockDrafts := new(mocks.MockDraftService)
+		movieHandler := NewMovieHandler(mockService, mockDrafts, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("role", "USER")
+
+		c.Request = httptest.NewRequest("POST", "/movie?enrich=true", bytes.NewBufferString(`{"imdb_id":"tt1234567"}`))
+
+		movieHandler.AddMovie(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "AddMovie")
+		mockDrafts.AssertNotCalled(t, "GenerateDraft")
+	})
+}
+
+func TestGetRecommendedMovies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Passes Pagination", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user_id", "user123")
+		req := httptest.NewRequest("GET", "/recommendedMovies?page=2&page_size=10", nil)
+		c.Request = req
+
+		mockService.On("GetRecommendedMovies", mock.Anything, "user123", models.Pagination{Page: 2, PageSize: 10}).
+			Return([]models.RecommendedMovie{{Movie: models.Movie{Title: "Movie 1", ImdbID: "tt1"}}}, nil)
+
+		movieHandler.GetRecommendedMovies(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/recommendedMovies", nil)
+		c.Request = req
+
+		movieHandler.GetRecommendedMovies(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockService.AssertNotCalled(t, "GetRecommendedMovies")
+	})
+}
+
+func TestAddReview(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user_id", "user123")
+		c.Params = []gin.Param{{Key: "imdb_id", Value: "tt123"}}
+		req := httptest.NewRequest("POST", "/movie/tt123/reviews", bytes.NewBufferString(`{"rating": 4, "comment": "Great"}`))
+		c.Request = req
+
+		mockService.On("AddReview", mock.Anything, mock.MatchedBy(func(r models.Review) bool {
+			return r.UserID == "user123" && r.ImdbID == "tt123" && r.Rating == 4
+		})).Return(nil)
+
+		movieHandler.AddReview(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Rating Out Of Range", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user_id", "user123")
+		c.Params = []gin.Param{{Key: "imdb_id", Value: "tt123"}}
+		req := httptest.NewRequest("POST", "/movie/tt123/reviews", bytes.NewBufferString(`{"rating": 9}`))
+		c.Request = req
+
+		movieHandler.AddReview(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "AddReview")
+	})
+}
+
+func TestGetSimilarMovies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{{Key: "imdb_id", Value: "tt404"}}
+		req := httptest.NewRequest("GET", "/movie/tt404/similar?limit=5", nil)
+		c.Request = req
+
+		mockService.On("GetSimilarMovies", mock.Anything, "tt404", 5).Return(nil, mongo.ErrNoDocuments)
+
+		movieHandler.GetSimilarMovies(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestExplainRecommendation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockMovieService)
+		movieHandler := NewMovieHandler(mockService, nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user_id", "user123")
+		c.Params = []gin.Param{{Key: "imdb_id", Value: "tt1"}}
+		req := httptest.NewRequest("GET", "/recommendedMovies/tt1/explain", nil)
+		c.Request = req
+
+		explanation := models.RecommendationExplanation{ImdbID: "tt1", Strategy: "collaborative", Score: 0.5}
+		mockService.On("ExplainRecommendation", mock.Anything, "user123", "tt1").Return(&explanation, nil)
+
+		movieHandler.ExplainRecommendation(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body models.RecommendationExplanation
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, explanation.Score, body.Score)
+		mockService.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// parsePagination reads the page and page_size query parameters. Missing or invalid values
+// are left as zero so the service can apply its own defaults.
+func parsePagination(c *gin.Context) models.Pagination {
+	var page models.Pagination
+	if v, err := strconv.ParseInt(c.Query("page"), 10, 64); err == nil && v > 0 {
+		page.Page = v
+	}
+	if v, err := strconv.ParseInt(c.Query("page_size"), 10, 64); err == nil && v > 0 {
+		page.PageSize = v
+	}
+	return page
+}
+
+// maxLimit caps the limit query parameter of endpoints that return a single list.
+const maxLimit = 100
+
+// parseLimit reads the limit query parameter. An out of range value gets a 400 response and
+// false is returned.
+func parseLimit(c *gin.Context, defaultLimit int) (int, bool) {
+	raw := c.Query("limit")
+	if raw == "" {
+		return defaultLimit, true
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 || limit > maxLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return 0, false
+	}
+	return limit, true
+}
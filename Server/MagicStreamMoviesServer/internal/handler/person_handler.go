@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type PersonHandler struct {
+	service  service.PersonService
+	validate *validator.Validate
+}
+
+func NewPersonHandler(s service.PersonService) *PersonHandler {
+	return &PersonHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+// GetPeople godoc
+// @Summary      List people
+// @Description  List cast and crew ordered by name, optionally filtered by a name fragment
+// @Tags         people
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q          query     string  false  "Name contains"
+// @Param        page       query     int     false  "Page number (default 1)"
+// @Param        page_size  query     int     false  "Page size (default 20, max 100)"
+// @Success      200        {array}   models.Person
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /people [get]
+func (h *PersonHandler) GetPeople(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	people, err := h.service.SearchPeople(ctx, strings.TrimSpace(c.Query("q")), parsePagination(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching people"})
+		return
+	}
+
+	c.JSON(http.StatusOK, people)
+}
+
+// GetPerson godoc
+// @Summary      Get a person
+// @Description  Get a person with a page of their filmography, newest releases first
+// @Tags         people
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true   "Person ID"
+// @Param        page       query     int     false  "Page number (default 1)"
+// @Param        page_size  query     int     false  "Page size (default 20, max 100)"
+// @Success      200        {object}  models.PersonPage
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /people/{id} [get]
+func (h *PersonHandler) GetPerson(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	person, err := h.service.GetPerson(ctx, c.Param("id"), parsePagination(c))
+	if err != nil {
+		personError(c, err, "Error fetching person")
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
+
+// CreatePerson godoc
+// @Summary      Add a person (Admin only)
+// @Description  Add a cast or crew member
+// @Tags         people
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        person  body      models.Person  true  "Person"
+// @Success      201     {object}  models.Person
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]interface{}
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /people [post]
+func (h *PersonHandler) CreatePerson(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	person, ok := h.bindPerson(c)
+	if !ok {
+		return
+	}
+
+	created, err := h.service.CreatePerson(ctx, person)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error adding person"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdatePerson godoc
+// @Summary      Update a person (Admin only)
+// @Description  Replace a person's details. Renames are carried over to the search index.
+// @Tags         people
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string         true  "Person ID"
+// @Param        person  body      models.Person  true  "Person"
+// @Success      200     {object}  models.Person
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      403     {object}  map[string]interface{}
+// @Failure      404     {object}  map[string]interface{}
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /people/{id} [put]
+func (h *PersonHandler) UpdatePerson(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	person, ok := h.bindPerson(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.UpdatePerson(ctx, c.Param("id"), person)
+	if err != nil {
+		personError(c, err, "Error updating person")
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeletePerson godoc
+// @Summary      Delete a person (Admin only)
+// @Description  Delete a person and all of their credits
+// @Tags         people
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Person ID"
+// @Success      204
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /people/{id} [delete]
+func (h *PersonHandler) DeletePerson(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	if err := h.service.DeletePerson(ctx, c.Param("id")); err != nil {
+		personError(c, err, "Error deleting person")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMovieCredits godoc
+// @Summary      Get a movie's credits
+// @Description  Get the cast and crew of a movie in billing order
+// @Tags         people
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Success      200      {array}   models.MovieCredit
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/credits [get]
+func (h *PersonHandler) GetMovieCredits(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	credits, err := h.service.GetMovieCredits(ctx, c.Param("imdb_id"))
+	if err != nil {
+		personError(c, err, "Error fetching credits")
+		return
+	}
+
+	c.JSON(http.StatusOK, credits)
+}
+
+// AddCredit godoc
+// @Summary      Credit a person on a movie (Admin only)
+// @Description  Add an actor (with character name), director or writer credit to a movie
+// @Tags         people
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string                true  "IMDB ID"
+// @Param        credit   body      models.CreditRequest  true  "Credit"
+// @Success      201      {object}  models.MovieCredit
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/credits [post]
+func (h *PersonHandler) AddCredit(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req models.CreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	credit, err := h.service.AddCredit(ctx, c.Param("imdb_id"), req)
+	if err != nil {
+		personError(c, err, "Error adding credit")
+		return
+	}
+
+	c.JSON(http.StatusCreated, credit)
+}
+
+// DeleteCredit godoc
+// @Summary      Remove a credit (Admin only)
+// @Description  Remove a cast or crew credit from a movie
+// @Tags         people
+// @Security     BearerAuth
+// @Param        imdb_id    path  string  true  "IMDB ID"
+// @Param        credit_id  path  string  true  "Credit ID"
+// @Success      204
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/credits/{credit_id} [delete]
+func (h *PersonHandler) DeleteCredit(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	if err := h.service.DeleteCredit(ctx, c.Param("imdb_id"), c.Param("credit_id")); err != nil {
+		personError(c, err, "Error removing credit")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PersonHandler) bindPerson(c *gin.Context) (models.Person, bool) {
+	var person models.Person
+	if err := c.ShouldBindJSON(&person); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return person, false
+	}
+	if err := h.validate.Struct(&person); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return person, false
+	}
+	return person, true
+}
+
+func personError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidID):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrPersonNotFound), errors.Is(err, service.ErrCreditNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, mongo.ErrNoDocuments):
+		// The service reports missing people and credits with its own errors, so only a movie
+		// lookup gets here.
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPerson(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockPersonService)
+		personHandler := NewPersonHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/people/abc?page=2", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		mockService.On("GetPerson", mock.Anything, "abc", models.Pagination{Page: 2}).
+			Return(&models.PersonPage{Person: models.Person{Name: "Rian Johnson"}, Filmography: []models.FilmographyEntry{}}, nil)
+
+		personHandler.GetPerson(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"Rian Johnson"`)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService := new(mocks.MockPersonService)
+		personHandler := NewPersonHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/people/abc", nil)
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		mockService.On("GetPerson", mock.Anything, "abc", models.Pagination{}).Return(nil, service.ErrInvalidID)
+
+		personHandler.GetPerson(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAddCredit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("CharacterOnlyForActors", func(t *testing.T) {
+		mockService := new(mocks.MockPersonService)
+		personHandler := NewPersonHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"person_id":"abc","role":"director","character":"Sara"}`
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/credits", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+
+		personHandler.AddCredit(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "AddCredit")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockPersonService)
+		personHandler := NewPersonHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"person_id":"abc","role":"actor","character":"Sara"}`
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/credits", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+
+		req := models.CreditRequest{PersonID: "abc", Role: models.CreditActor, Character: "Sara"}
+		mockService.On("AddCredit", mock.Anything, "tt1", req).
+			Return(&models.MovieCredit{Credit: models.Credit{Role: models.CreditActor, Character: "Sara"}}, nil)
+
+		personHandler.AddCredit(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
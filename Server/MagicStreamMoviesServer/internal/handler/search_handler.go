@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+)
+
+const (
+	defaultSearchLimit  = 20
+	defaultSuggestLimit = 10
+)
+
+type SearchHandler struct {
+	service  service.SearchService
+	semantic service.SemanticService
+}
+
+func NewSearchHandler(s service.SearchService, semantic service.SemanticService) *SearchHandler {
+	return &SearchHandler{
+		service:  s,
+		semantic: semantic,
+	}
+}
+
+// Search godoc
+// @Summary      Search movies
+// @Description  Full-text search over titles, genres, review text and the episode titles of series, ranked by relevance and tolerant of small typos. Facet filters take several values, repeated or comma separated; the response carries facet counts for the query.
+// @Tags         movies
+// @Produce      json
+// @Param        q        query     string  true   "Search query"
+// @Param        genre    query     string  false  "Genre names"
+// @Param        ranking  query     string  false  "Ranking names"
+// @Param        decade   query     string  false  "Release decades, e.g. 1990"
+// @Param        rating   query     string  false  "Average user rating buckets (1-5)"
+// @Param        type     query     string  false  "Content types: movie or series"
+// @Param        limit    query     int     false  "Number of results (default 20, max 100)"
+// @Success      200      {object}  models.SearchResponse
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movies/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+	filters, err := parseFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	limit, ok := parseLimit(c, defaultSearchLimit)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.Search(ctx, query, filters, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error searching movies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Suggest godoc
+// @Summary      Autocomplete movie titles
+// @Description  Suggest movie titles starting with, or containing a word starting with, the prefix
+// @Tags         movies
+// @Produce      json
+// @Param        prefix  query     string  true   "Title prefix"
+// @Param        limit   query     int     false  "Number of suggestions (default 10, max 100)"
+// @Success      200     {array}   models.Suggestion
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /movies/suggest [get]
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	prefix := strings.TrimSpace(c.Query("prefix"))
+	if prefix == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "prefix is required"})
+		return
+	}
+	limit, ok := parseLimit(c, defaultSuggestLimit)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.service.Suggest(ctx, prefix, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching suggestions"})
+		return
+	}
+	if suggestions == nil {
+		suggestions = []models.Suggestion{}
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// SemanticSearch godoc
+// @Summary      Semantic movie search
+// @Description  Find movies by meaning rather than wording, e.g. "movies about time travel with a twist", by comparing embeddings
+// @Tags         movies
+// @Produce      json
+// @Param        q      query     string  true   "Natural language query"
+// @Param        limit  query     int     false  "Number of results (default 20, max 100)"
+// @Success      200    {array}   models.SearchResult
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      503    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /movies/semantic-search [get]
+func (h *SearchHandler) SemanticSearch(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+	limit, ok := parseLimit(c, defaultSearchLimit)
+	if !ok {
+		return
+	}
+
+	results, err := h.semantic.Search(ctx, query, limit)
+	if errors.Is(err, service.ErrSemanticSearchUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error searching movies"})
+		return
+	}
+	if results == nil {
+		results = []models.SearchResult{}
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockSearchService)
+		searchHandler := NewSearchHandler(mockService, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/movies/search?q=dark+knight&ranking=Excellent", nil)
+		c.Request = req
+
+		mockService.On("Search", mock.Anything, "dark knight", models.MovieFilters{Rankings: []string{"Excellent"}}, defaultSearchLimit).
+			Return(&models.SearchResponse{
+				Results: []models.SearchResult{{Movie: models.Movie{ImdbID: "tt1"}, Score: 2.5}},
+				Facets:  models.Facets{Rankings: []models.FacetCount{{Value: "Excellent", Count: 1}}},
+			}, nil)
+
+		searchHandler.Search(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"score":2.5`)
+		assert.Contains(t, w.Body.String(), `"facets":`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("MissingQuery", func(t *testing.T) {
+		mockService := new(mocks.MockSearchService)
+		searchHandler := NewSearchHandler(mockService, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/movies/search?q=+", nil)
+		c.Request = req
+
+		searchHandler.Search(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidLimit", func(t *testing.T) {
+		mockService := new(mocks.MockSearchService)
+		searchHandler := NewSearchHandler(mockService, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/movies/search?q=dark&limit=500", nil)
+		c.Request = req
+
+		searchHandler.Search(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSuggest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockSearchService)
+	searchHandler := NewSearchHandler(mockService, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req := httptest.NewRequest("GET", "/movies/suggest?prefix=kni&limit=5", nil)
+	c.Request = req
+
+	mockService.On("Suggest", mock.Anything, "kni", 5).
+		Return([]models.Suggestion{{ImdbID: "tt2", Title: "Knight and Day"}}, nil)
+
+	searchHandler.Suggest(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Knight and Day")
+	mockService.AssertExpectations(t)
+}
+
+func TestSemanticSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSemantic := new(mocks.MockSemanticService)
+	searchHandler := NewSearchHandler(nil, mockSemantic)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req := httptest.NewRequest("GET", "/movies/semantic-search?q=time+travel+with+a+twist", nil)
+	c.Request = req
+
+	mockSemantic.On("Search", mock.Anything, "time travel with a twist", defaultSearchLimit).
+		Return([]models.SearchResult{{Movie: models.Movie{ImdbID: "tt1"}, Score: 0.8}}, nil)
+
+	searchHandler.SemanticSearch(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"imdb_id":"tt1"`)
+	mockSemantic.AssertExpectations(t)
+}
+
+func TestSemanticSearch_Unconfigured(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockSemantic := new(mocks.MockSemanticService)
+	searchHandler := NewSearchHandler(nil, mockSemantic)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/movies/semantic-search?q=heist", nil)
+
+	mockSemantic.On("Search", mock.Anything, "heist", defaultSearchLimit).Return(nil, service.ErrSemanticSearchUnavailable)
+
+	searchHandler.SemanticSearch(c)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
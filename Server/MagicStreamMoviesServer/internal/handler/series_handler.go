@@ -0,0 +1,417 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type SeriesHandler struct {
+	service  service.SeriesService
+	validate *validator.Validate
+}
+
+func NewSeriesHandler(s service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+// GetSeries godoc
+// @Summary      Get a series
+// @Description  Get a series title with its seasons in order
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID of the series"
+// @Success      200      {object}  models.SeriesDetail
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /series/{imdb_id} [get]
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	series, err := h.service.GetSeries(ctx, c.Param("imdb_id"))
+	if err != nil {
+		seriesError(c, err, "Error fetching series")
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// GetSeason godoc
+// @Summary      Get a season
+// @Description  Get a season of a series with its episodes in order. Season 0 holds specials.
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID of the series"
+// @Param        season   path      int     true  "Season number"
+// @Success      200      {object}  models.SeasonDetail
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /series/{imdb_id}/seasons/{season} [get]
+func (h *SeriesHandler) GetSeason(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	seasonNumber, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	season, err := h.service.GetSeason(ctx, c.Param("imdb_id"), seasonNumber)
+	if err != nil {
+		seriesError(c, err, "Error fetching season")
+		return
+	}
+
+	c.JSON(http.StatusOK, season)
+}
+
+// SaveSeason godoc
+// @Summary      Create or replace a season (Admin only)
+// @Description  Create a season of a series, or replace its details when it already exists
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string         true  "IMDB ID of the series"
+// @Param        season   path      int            true  "Season number"
+// @Param        details  body      models.Season  true  "Season"
+// @Success      200      {object}  models.Season
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /series/{imdb_id}/seasons/{season} [put]
+func (h *SeriesHandler) SaveSeason(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	seasonNumber, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	var season models.Season
+	if !h.bind(c, &season) {
+		return
+	}
+	season.SeriesID = c.Param("imdb_id")
+	season.SeasonNumber = seasonNumber
+
+	saved, err := h.service.SaveSeason(ctx, season)
+	if err != nil {
+		seriesError(c, err, "Error saving season")
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteSeason godoc
+// @Summary      Delete a season (Admin only)
+// @Description  Delete a season of a series together with its episodes
+// @Tags         series
+// @Security     BearerAuth
+// @Param        imdb_id  path  string  true  "IMDB ID of the series"
+// @Param        season   path  int     true  "Season number"
+// @Success      204
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /series/{imdb_id}/seasons/{season} [delete]
+func (h *SeriesHandler) DeleteSeason(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	seasonNumber, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSeason(ctx, c.Param("imdb_id"), seasonNumber); err != nil {
+		seriesError(c, err, "Error deleting season")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEpisode godoc
+// @Summary      Get an episode
+// @Description  Get an episode of a series, with its stream and runtime
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID of the series"
+// @Param        season   path      int     true  "Season number"
+// @Param        episode  path      int     true  "Episode number"
+// @Success      200      {object}  models.Episode
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /series/{imdb_id}/seasons/{season}/episodes/{episode} [get]
+func (h *SeriesHandler) GetEpisode(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	seasonNumber, episodeNumber, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	episode, err := h.service.GetEpisode(ctx, c.Param("imdb_id"), seasonNumber, episodeNumber)
+	if err != nil {
+		seriesError(c, err, "Error fetching episode")
+		return
+	}
+
+	c.JSON(http.StatusOK, episode)
+}
+
+// SaveEpisode godoc
+// @Summary      Create or replace an episode (Admin only)
+// @Description  Create an episode in an existing season, or replace it when it already exists. An episode plays from YouTube, or from a media file or HLS master playlist already in the blob store given as its stream; a self-hosted episode needs its own imdb_id, under which it plays from /stream/{imdb_id} and signs URLs through POST /movie/{imdb_id}/stream-url.
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string          true  "IMDB ID of the series"
+// @Param        season   path      int             true  "Season number"
+// @Param        episode  path      int             true  "Episode number"
+// @Param        details  body      models.Episode  true  "Episode"
+// @Success      200      {object}  models.Episode
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /series/{imdb_id}/seasons/{season}/episodes/{episode} [put]
+func (h *SeriesHandler) SaveEpisode(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	seasonNumber, episodeNumber, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	var episode models.Episode
+	if !h.bind(c, &episode) {
+		return
+	}
+	episode.SeriesID = c.Param("imdb_id")
+	episode.SeasonNumber = seasonNumber
+	episode.EpisodeNumber = episodeNumber
+
+	saved, err := h.service.SaveEpisode(ctx, episode)
+	if err != nil {
+		seriesError(c, err, "Error saving episode")
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// DeleteEpisode godoc
+// @Summary      Delete an episode (Admin only)
+// @Description  Delete an episode of a series
+// @Tags         series
+// @Security     BearerAuth
+// @Param        imdb_id  path  string  true  "IMDB ID of the series"
+// @Param        season   path  int     true  "Season number"
+// @Param        episode  path  int     true  "Episode number"
+// @Success      204
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /series/{imdb_id}/seasons/{season}/episodes/{episode} [delete]
+func (h *SeriesHandler) DeleteEpisode(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	seasonNumber, episodeNumber, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteEpisode(ctx, c.Param("imdb_id"), seasonNumber, episodeNumber); err != nil {
+		seriesError(c, err, "Error deleting episode")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RecordEpisodeWatch godoc
+// @Summary      Record episode watch progress
+// @Description  Record that the current user watched an episode, with their latest progress. The series then resumes from this episode, or from the next one once it is completed.
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id   path      string  true  "IMDB ID of the series"
+// @Param        season    path      int     true  "Season number"
+// @Param        episode   path      int     true  "Episode number"
+// @Param        progress  body      map[string]interface{}  true  "Progress JSON {\"progress_seconds\": 120, \"completed\": false}"
+// @Success      200       {object}  map[string]interface{}
+// @Failure      400       {object}  map[string]interface{}
+// @Failure      401       {object}  map[string]interface{}
+// @Failure      404       {object}  map[string]interface{}
+// @Failure      500       {object}  map[string]interface{}
+// @Router       /series/{imdb_id}/seasons/{season}/episodes/{episode}/watch [post]
+func (h *SeriesHandler) RecordEpisodeWatch(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+	seasonNumber, episodeNumber, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		ProgressSeconds int  `json:"progress_seconds" validate:"gte=0"`
+		Completed       bool `json:"completed"`
+	}
+	if !h.bind(c, &req) {
+		return
+	}
+
+	err = h.service.RecordEpisodeWatch(ctx, models.EpisodeProgress{
+		UserID:          userId,
+		SeriesID:        c.Param("imdb_id"),
+		SeasonNumber:    seasonNumber,
+		EpisodeNumber:   episodeNumber,
+		ProgressSeconds: req.ProgressSeconds,
+		Completed:       req.Completed,
+	})
+	if err != nil {
+		seriesError(c, err, "Error recording watch progress")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Watch progress recorded"})
+}
+
+// GetNextEpisode godoc
+// @Summary      Get the next episode to watch
+// @Description  Get the episode the current user should play next: the first episode for a new viewer, the unfinished episode to resume, or the one after the last finished episode. Specials (season 0) come after the regular seasons. Responds with no content once every episode was watched.
+// @Tags         series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID of the series"
+// @Success      200      {object}  models.NextEpisode
+// @Success      204
+// @Failure      401      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /series/{imdb_id}/next-episode [get]
+func (h *SeriesHandler) GetNextEpisode(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+
+	next, err := h.service.GetNextEpisode(ctx, userId, c.Param("imdb_id"))
+	if errors.Is(err, service.ErrSeriesFinished) {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		seriesError(c, err, "Error fetching next episode")
+		return
+	}
+
+	c.JSON(http.StatusOK, next)
+}
+
+func (h *SeriesHandler) bind(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return false
+	}
+	if err := h.validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return false
+	}
+	return true
+}
+
+func seasonParam(c *gin.Context) (int, bool) {
+	return numberParam(c, "season", 0)
+}
+
+func episodeParams(c *gin.Context) (int, int, bool) {
+	seasonNumber, ok := seasonParam(c)
+	if !ok {
+		return 0, 0, false
+	}
+	episodeNumber, ok := numberParam(c, "episode", 1)
+	return seasonNumber, episodeNumber, ok
+}
+
+// numberParam parses a numeric path parameter of at least min, answering 400 when it is not one.
+func numberParam(c *gin.Context, name string, min int) (int, bool) {
+	n, err := strconv.Atoi(c.Param(name))
+	if err != nil || n < min {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid %s number", name)})
+		return 0, false
+	}
+	return n, true
+}
+
+func seriesError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Series not found"})
+	case errors.Is(err, service.ErrNotSeries):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Title is not a series"})
+	case errors.Is(err, service.ErrSeasonNotFound), errors.Is(err, service.ErrEpisodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidStreamSource):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrEpisodeIDTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSeason(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("InvalidNumber", func(t *testing.T) {
+		mockService := new(mocks.MockSeriesService)
+		seriesHandler := NewSeriesHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/series/tt0903747/seasons/first", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt0903747"}, {Key: "season", Value: "first"}}
+
+		seriesHandler.GetSeason(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetSeason")
+	})
+
+	t.Run("NotASeries", func(t *testing.T) {
+		mockService := new(mocks.MockSeriesService)
+		seriesHandler := NewSeriesHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/series/tt1375666/seasons/1", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1375666"}, {Key: "season", Value: "1"}}
+
+		mockService.On("GetSeason", mock.Anything, "tt1375666", 1).Return(nil, service.ErrNotSeries)
+
+		seriesHandler.GetSeason(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSaveEpisode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("AdminOnly", func(t *testing.T) {
+		mockService := new(mocks.MockSeriesService)
+		seriesHandler := NewSeriesHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("PUT", "/series/tt0903747/seasons/1/episodes/1", bytes.NewBufferString(`{}`))
+		c.Set("role", "USER")
+
+		seriesHandler.SaveEpisode(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("NumbersFromPath", func(t *testing.T) {
+		mockService := new(mocks.MockSeriesService)
+		seriesHandler := NewSeriesHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"series_id":"tt0","season_number":7,"episode_number":7,"title":"Pilot","runtime":58,"youtube_id":"HhesaQXLuRY"}`
+		c.Request = httptest.NewRequest("PUT", "/series/tt0903747/seasons/1/episodes/1", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt0903747"}, {Key: "season", Value: "1"}, {Key: "episode", Value: "1"}}
+		c.Set("role", "ADMIN")
+
+		expected := models.Episode{SeriesID: "tt0903747", SeasonNumber: 1, EpisodeNumber: 1, Title: "Pilot", Runtime: 58, YouTubeID: "HhesaQXLuRY"}
+		mockService.On("SaveEpisode", mock.Anything, expected).Return(&expected, nil)
+
+		seriesHandler.SaveEpisode(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("RuntimeRequired", func(t *testing.T) {
+		mockService := new(mocks.MockSeriesService)
+		seriesHandler := NewSeriesHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		body := `{"title":"Pilot","youtube_id":"HhesaQXLuRY"}`
+		c.Request = httptest.NewRequest("PUT", "/series/tt0903747/seasons/1/episodes/1", bytes.NewBufferString(body))
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt0903747"}, {Key: "season", Value: "1"}, {Key: "episode", Value: "1"}}
+		c.Set("role", "ADMIN")
+
+		seriesHandler.SaveEpisode(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "SaveEpisode")
+	})
+}
+
+func TestRecordEpisodeWatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockSeriesService)
+	seriesHandler := NewSeriesHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/series/tt0903747/seasons/1/episodes/2/watch", bytes.NewBufferString(`{"progress_seconds":120}`))
+	c.Params = gin.Params{{Key: "imdb_id", Value: "tt0903747"}, {Key: "season", Value: "1"}, {Key: "episode", Value: "2"}}
+	c.Set("user_id", "user123")
+
+	mockService.On("RecordEpisodeWatch", mock.Anything, models.EpisodeProgress{
+		UserID: "user123", SeriesID: "tt0903747", SeasonNumber: 1, EpisodeNumber: 2, ProgressSeconds: 120,
+	}).Return(nil)
+
+	seriesHandler.RecordEpisodeWatch(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetNextEpisode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Resume", func(t *testing.T) {
+		mockService := new(mocks.MockSeriesService)
+		seriesHandler := NewSeriesHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/series/tt0903747/next-episode", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt0903747"}}
+		c.Set("user_id", "user123")
+
+		mockService.On("GetNextEpisode", mock.Anything, "user123", "tt0903747").Return(&models.NextEpisode{
+			Episode:         models.Episode{SeasonNumber: 1, EpisodeNumber: 2, Title: "Cat's in the Bag..."},
+			ProgressSeconds: 120,
+			Reason:          models.NextEpisodeResume,
+		}, nil)
+
+		seriesHandler.GetNextEpisode(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"reason":"resume"`)
+	})
+
+	t.Run("Finished", func(t *testing.T) {
+		mockService := new(mocks.MockSeriesService)
+		seriesHandler := NewSeriesHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/series/tt0903747/next-episode", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt0903747"}}
+		c.Set("user_id", "user123")
+
+		mockService.On("GetNextEpisode", mock.Anything, "user123", "tt0903747").Return(nil, service.ErrSeriesFinished)
+
+		seriesHandler.GetNextEpisode(c)
+
+		assert.Equal(t, http.StatusNoContent, c.Writer.Status())
+	})
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/urlsign"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type StreamHandler struct {
+	service  service.StreamService
+	validate *validator.Validate
+}
+
+func NewStreamHandler(s service.StreamService) *StreamHandler {
+	return &StreamHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+// Stream godoc
+// @Summary      Stream a title
+// @Description  Play a self-hosted title. Media files are served with byte range support. HLS titles redirect to their master playlist, whose variant playlists and segments are served below the same path. Titles that play from YouTube answer 409 with their youtube_id. Authenticate with a token, or without one through a signed URL from POST /movie/{imdb_id}/stream-url.
+// @Tags         streaming
+// @Produce      video/mp4,application/vnd.apple.mpegurl
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true   "IMDB ID"
+// @Param        Range    header    string  false  "Byte range, e.g. bytes=0-1048575"
+// @Param        uid      query     string  false  "Signed URL: user ID"
+// @Param        exp      query     int     false  "Signed URL: expiry, Unix seconds"
+// @Param        kid      query     string  false  "Signed URL: signing key ID"
+// @Param        sig      query     string  false  "Signed URL: signature"
+// @Success      200
+// @Success      206
+// @Success      302
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      416
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /stream/{imdb_id} [get]
+func (h *StreamHandler) Stream(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	source, err := h.service.GetPlayback(ctx, c.Param("imdb_id"))
+	cancel()
+	if err != nil {
+		streamError(c, err, "Error fetching stream")
+		return
+	}
+
+	switch source.Type {
+	case models.StreamHLS:
+		// Playlists refer to their files by relative URLs, so the master playlist is served
+		// from the directory the rest of the files are served from.
+		location := strings.TrimSuffix(c.Request.URL.Path, "/") + "/" + path.Base(source.Key)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusFound, location)
+	case models.StreamFile:
+		h.serve(c, "")
+	default:
+		c.JSON(http.StatusConflict, gin.H{"error": service.ErrNotSelfHosted.Error(), "youtube_id": source.YouTubeID})
+	}
+}
+
+// StreamFile godoc
+// @Summary      Stream an HLS file
+// @Description  Serve a playlist or segment of a self-hosted HLS title, relative to its master playlist. Playlists fetched through a signed URL have the signature added to the URLs they list.
+// @Tags         streaming
+// @Produce      application/vnd.apple.mpegurl,video/mp2t
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Param        path     path      string  true  "Playlist or segment path"
+// @Success      200
+// @Success      206
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /stream/{imdb_id}/{path} [get]
+func (h *StreamHandler) StreamFile(c *gin.Context) {
+	name := strings.TrimPrefix(c.Param("path"), "/")
+	if name == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": service.ErrStreamNotFound.Error()})
+		return
+	}
+	h.serve(c, name)
+}
+
+// serve writes the media with byte range support. A stream lasts as long as the player keeps
+// reading, so no timeout is set beyond the request's own.
+func (h *StreamHandler) serve(c *gin.Context, name string) {
+	imdbID := c.Param("imdb_id")
+	blob, err := h.service.OpenMedia(c.Request.Context(), imdbID, name)
+	if err != nil {
+		streamError(c, err, "Error fetching stream")
+		return
+	}
+	defer func() {
+		if err := blob.Close(); err != nil {
+			log.Printf("failed to close stream of %s: %v", imdbID, err)
+		}
+	}()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", blob.ContentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+	if !strings.HasSuffix(blob.Key, ".m3u8") {
+		header.Set("Cache-Control", "private, max-age=3600")
+		http.ServeContent(c.Writer, c.Request, "", blob.ModTime, blob)
+		return
+	}
+
+	// Players drop the query string when following the relative URLs in a playlist, so a
+	// signature is written into them.
+	header.Set("Cache-Control", "private, no-cache")
+	playlist, err := io.ReadAll(blob)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching stream"})
+		return
+	}
+	if query := c.Request.URL.Query(); urlsign.Signed(query) {
+		playlist = signPlaylist(playlist, urlsign.Params(query).Encode())
+	}
+	http.ServeContent(c.Writer, c.Request, "", blob.ModTime, bytes.NewReader(playlist))
+}
+
+// SignPlaybackURL godoc
+// @Summary      Get a signed playback URL
+// @Description  Issue a short-lived URL for /stream/{imdb_id} that is bound to the current user and works without an Authorization header, for players that cannot send one. HLS playlists served through it carry the signature on to their segments. The URL expires after STREAM_URL_TTL, 15 minutes by default, so players request a new one before expires_at.
+// @Tags         streaming
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Success      200      {object}  models.PlaybackURL
+// @Failure      401      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      503      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/stream-url [post]
+func (h *StreamHandler) SignPlaybackURL(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+
+	playback, err := h.service.SignPlaybackURL(ctx, userId, c.Param("imdb_id"))
+	if err != nil {
+		streamError(c, err, "Error signing playback URL")
+		return
+	}
+
+	c.JSON(http.StatusOK, playback)
+}
+
+// SetStreamSource godoc
+// @Summary      Set a title's stream source (Admin only)
+// @Description  Play the title from a media file or HLS master playlist already in the blob store, or from YouTube again with type youtube. Everything next to an HLS master playlist is served with it, so the playlist must sit in a directory, not at the root of the store.
+// @Tags         streaming
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string               true  "IMDB ID"
+// @Param        source   body      models.StreamSource  true  "Stream source"
+// @Success      200      {object}  models.Movie
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/stream [put]
+func (h *StreamHandler) SetStreamSource(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	var source models.StreamSource
+	if err := c.ShouldBindJSON(&source); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if err := h.validate.Struct(&source); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	movie, err := h.service.SetStreamSource(ctx, c.Param("imdb_id"), source)
+	if err != nil {
+		streamError(c, err, "Error updating stream source")
+		return
+	}
+
+	c.JSON(http.StatusOK, movie)
+}
+
+func streamError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case errors.Is(err, service.ErrStreamNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNotSelfHosted):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidStreamSource):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSigningUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// signPlaylist appends query to the relative URIs of an HLS playlist: the lines naming
+// playlists and segments, and the URI attributes of tags such as keys and maps.
+func signPlaylist(playlist []byte, query string) []byte {
+	lines := strings.Split(string(playlist), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+		case strings.HasPrefix(trimmed, "#"):
+			lines[i] = playlistURIAttribute.ReplaceAllStringFunc(line, func(attr string) string {
+				uri := strings.TrimSuffix(strings.TrimPrefix(attr, `URI="`), `"`)
+				return `URI="` + withQuery(uri, query) + `"`
+			})
+		default:
+			lines[i] = strings.Replace(line, trimmed, withQuery(trimmed, query), 1)
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+var playlistURIAttribute = regexp.MustCompile(`URI="[^"]*"`)
+
+// withQuery appends query to a relative URI. Absolute URIs point elsewhere and are left alone.
+func withQuery(uri string, query string) string {
+	if strings.Contains(uri, "://") || strings.HasPrefix(uri, "/") {
+		return uri
+	}
+	if strings.Contains(uri, "?") {
+		return uri + "&" + query
+	}
+	return uri + "?" + query
+}
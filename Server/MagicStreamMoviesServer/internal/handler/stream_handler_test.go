@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func testBlob(key string, content string, contentType string) *storage.Blob {
+	return &storage.Blob{
+		ReadSeekCloser: readSeekNopCloser{strings.NewReader(content)},
+		BlobInfo:       storage.BlobInfo{Key: key, Size: int64(len(content)), ContentType: contentType, ModTime: time.Now()},
+	}
+}
+
+func TestStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("FileRange", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/stream/tt1", nil)
+		c.Request.Header.Set("Range", "bytes=4-8")
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+
+		mockService.On("GetPlayback", mock.Anything, "tt1").Return(&models.StreamSource{Type: models.StreamFile, Key: "media/tt1/movie.mp4"}, nil)
+		mockService.On("OpenMedia", mock.Anything, "tt1", "").Return(testBlob("media/tt1/movie.mp4", "0123456789", "video/mp4"), nil)
+
+		streamHandler.Stream(c)
+
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "45678", w.Body.String())
+		assert.Equal(t, "bytes 4-8/10", w.Header().Get("Content-Range"))
+		assert.Equal(t, "video/mp4", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("HLSRedirect", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/stream/tt2?quality=auto", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2"}}
+
+		mockService.On("GetPlayback", mock.Anything, "tt2").Return(&models.StreamSource{Type: models.StreamHLS, Key: "media/tt2/hls/master.m3u8"}, nil)
+
+		streamHandler.Stream(c)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/stream/tt2/master.m3u8?quality=auto", w.Header().Get("Location"))
+	})
+
+	t.Run("YouTube", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/stream/tt3", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt3"}}
+
+		mockService.On("GetPlayback", mock.Anything, "tt3").Return(&models.StreamSource{Type: models.StreamYouTube, YouTubeID: "dQw4w9WgXcQ"}, nil)
+
+		streamHandler.Stream(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), `"youtube_id":"dQw4w9WgXcQ"`)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/stream/tt0", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt0"}}
+
+		mockService.On("GetPlayback", mock.Anything, "tt0").Return(nil, mongo.ErrNoDocuments)
+
+		streamHandler.Stream(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestStreamFile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Segment", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/stream/tt2/720p/seg001.ts", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2"}, {Key: "path", Value: "/720p/seg001.ts"}}
+
+		mockService.On("OpenMedia", mock.Anything, "tt2", "720p/seg001.ts").Return(testBlob("media/tt2/hls/720p/seg001.ts", "ts bytes", "video/mp2t"), nil)
+
+		streamHandler.StreamFile(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ts bytes", w.Body.String())
+		assert.Equal(t, "video/mp2t", w.Header().Get("Content-Type"))
+		assert.Equal(t, "private, max-age=3600", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/stream/tt2/../secret.txt", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2"}, {Key: "path", Value: "/../secret.txt"}}
+
+		mockService.On("OpenMedia", mock.Anything, "tt2", "../secret.txt").Return(nil, service.ErrStreamNotFound)
+
+		streamHandler.StreamFile(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestStreamFile_SignedPlaylist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(mocks.MockStreamService)
+	streamHandler := NewStreamHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/stream/tt2/master.m3u8?uid=u1&exp=99&kid=k1&sig=abc&quality=auto", nil)
+	c.Params = gin.Params{{Key: "imdb_id", Value: "tt2"}, {Key: "path", Value: "/master.m3u8"}}
+
+	playlist := "#EXTM3U\n#EXT-X-MAP:URI=\"init.mp4\"\n#EXT-X-STREAM-INF:BANDWIDTH=2800000\n720p/index.m3u8\nhttps://cdn.example.com/other.m3u8\n"
+	mockService.On("OpenMedia", mock.Anything, "tt2", "master.m3u8").Return(testBlob("media/tt2/hls/master.m3u8", playlist, "application/vnd.apple.mpegurl"), nil)
+
+	streamHandler.StreamFile(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+	signed := "exp=99&kid=k1&sig=abc&uid=u1"
+	assert.Equal(t, "#EXTM3U\n#EXT-X-MAP:URI=\"init.mp4?"+signed+"\"\n#EXT-X-STREAM-INF:BANDWIDTH=2800000\n720p/index.m3u8?"+signed+"\nhttps://cdn.example.com/other.m3u8\n", w.Body.String())
+}
+
+func TestSignPlaybackURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/stream-url", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("user_id", "user123")
+
+		playback := &models.PlaybackURL{URL: "http://localhost:8080/stream/tt1?sig=abc", ExpiresAt: time.Now().Add(time.Hour)}
+		mockService.On("SignPlaybackURL", mock.Anything, "user123", "tt1").Return(playback, nil)
+
+		streamHandler.SignPlaybackURL(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"url":"http://localhost:8080/stream/tt1?sig=abc"`)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := map[error]int{
+			mongo.ErrNoDocuments:          http.StatusNotFound,
+			service.ErrNotSelfHosted:      http.StatusConflict,
+			service.ErrSigningUnavailable: http.StatusServiceUnavailable,
+		}
+		for err, status := range cases {
+			mockService := new(mocks.MockStreamService)
+			streamHandler := NewStreamHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/movie/tt1/stream-url", nil)
+			c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+			c.Set("user_id", "user123")
+
+			mockService.On("SignPlaybackURL", mock.Anything, "user123", "tt1").Return(nil, err)
+
+			streamHandler.SignPlaybackURL(c)
+
+			assert.Equal(t, status, w.Code, err.Error())
+		}
+	})
+}
+
+func TestSetStreamSource(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("PUT", "/movie/tt1/stream", strings.NewReader(`{"type":"file","key":"media/tt1/movie.mp4"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+
+		source := models.StreamSource{Type: models.StreamFile, Key: "media/tt1/movie.mp4"}
+		movie := &models.Movie{ImdbID: "tt1", Stream: &source}
+		mockService.On("SetStreamSource", mock.Anything, "tt1", source).Return(movie, nil)
+
+		streamHandler.SetStreamSource(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"stream":{"type":"file","key":"media/tt1/movie.mp4"}`)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("PUT", "/movie/tt1/stream", strings.NewReader(`{"type":"hls"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+
+		streamHandler.SetStreamSource(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "SetStreamSource", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService := new(mocks.MockStreamService)
+		streamHandler := NewStreamHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("PUT", "/movie/tt1/stream", strings.NewReader(`{"type":"youtube"}`))
+		c.Set("role", "USER")
+
+		streamHandler.SetStreamSource(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type SubtitleHandler struct {
+	service  service.SubtitleService
+	validate *validator.Validate
+}
+
+func NewSubtitleHandler(s service.SubtitleService) *SubtitleHandler {
+	return &SubtitleHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+// UploadSubtitles godoc
+// @Summary      Upload a subtitle track (Admin only)
+// @Description  Upload an SRT or WebVTT file as the title's track in a language, replacing any earlier track in that language. SRT is converted to WebVTT, and every cue's timing is checked. A new track is labelled with its language unless a label is given. Making a track the default clears the flag on the others.
+// @Tags         subtitles
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true   "IMDB ID"
+// @Param        lang     path      string  true   "BCP 47 language tag, e.g. en or pt-BR"
+// @Param        file     formData  file    true   "SRT or WebVTT file, in UTF-8"
+// @Param        label    formData  string  false  "Name shown in the player's captions menu"
+// @Param        default  formData  bool    false  "Whether players show the track by default"
+// @Success      200      {object}  models.SubtitleTrack
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      413      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/subtitles/{lang} [put]
+func (h *SubtitleHandler) UploadSubtitles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req models.SubtitleTrackRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid multipart upload"})
+		return
+	}
+	defer file.Close()
+
+	track, err := h.service.SaveTrack(ctx, c.Param("imdb_id"), c.Param("lang"), req, file)
+	if err != nil {
+		subtitleError(c, err, "Error uploading subtitles")
+		return
+	}
+
+	c.JSON(http.StatusOK, track)
+}
+
+// UpdateSubtitles godoc
+// @Summary      Update a subtitle track (Admin only)
+// @Description  Change a track's label or default flag. Making a track the default clears the flag on the others.
+// @Tags         subtitles
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string                       true  "IMDB ID"
+// @Param        lang     path      string                       true  "BCP 47 language tag"
+// @Param        request  body      models.SubtitleTrackRequest  true  "Fields to change"
+// @Success      200      {object}  models.SubtitleTrack
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/subtitles/{lang} [patch]
+func (h *SubtitleHandler) UpdateSubtitles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	var req models.SubtitleTrackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	track, err := h.service.UpdateTrack(ctx, c.Param("imdb_id"), c.Param("lang"), req)
+	if err != nil {
+		subtitleError(c, err, "Error updating subtitles")
+		return
+	}
+
+	c.JSON(http.StatusOK, track)
+}
+
+// DeleteSubtitles godoc
+// @Summary      Delete a subtitle track (Admin only)
+// @Tags         subtitles
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path  string  true  "IMDB ID"
+// @Param        lang     path  string  true  "BCP 47 language tag"
+// @Success      204
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/subtitles/{lang} [delete]
+func (h *SubtitleHandler) DeleteSubtitles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	if err := h.service.DeleteTrack(ctx, c.Param("imdb_id"), c.Param("lang")); err != nil {
+		subtitleError(c, err, "Error deleting subtitles")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSubtitles godoc
+// @Summary      Get a subtitle track
+// @Description  Serve a track as WebVTT, as linked from the subtitles of a movie. Authenticate with a token, or without one through a signed URL from POST /movie/{imdb_id}/stream-url, whose query works here as well.
+// @Tags         subtitles
+// @Produce      text/vtt
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true   "IMDB ID"
+// @Param        lang     path      string  true   "BCP 47 language tag"
+// @Param        uid      query     string  false  "Signed URL: user ID"
+// @Param        exp      query     int     false  "Signed URL: expiry, Unix seconds"
+// @Param        kid      query     string  false  "Signed URL: signing key ID"
+// @Param        sig      query     string  false  "Signed URL: signature"
+// @Success      200
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/subtitles/{lang}.vtt [get]
+func (h *SubtitleHandler) GetSubtitles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	lang, ok := strings.CutSuffix(c.Param("lang"), ".vtt")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": service.ErrSubtitleTrackNotFound.Error()})
+		return
+	}
+	blob, err := h.service.OpenTrack(ctx, c.Param("imdb_id"), lang)
+	if err != nil {
+		subtitleError(c, err, "Error fetching subtitles")
+		return
+	}
+	defer func() {
+		if err := blob.Close(); err != nil {
+			log.Printf("failed to close subtitles %s: %v", blob.Key, err)
+		}
+	}()
+
+	// A replaced track is stored under a new key, so the key doubles as the ETag.
+	header := c.Writer.Header()
+	header.Set("Cache-Control", "private, no-cache")
+	header.Set("Content-Type", "text/vtt; charset=utf-8")
+	header.Set("ETag", `"`+blob.Key+`"`)
+	header.Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, "", blob.ModTime, blob)
+}
+
+func subtitleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case errors.Is(err, service.ErrSubtitleTrackNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidSubtitles), errors.Is(err, service.ErrInvalidLanguage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSubtitlesTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func subtitleUpload(t *testing.T, fields map[string]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		assert.NoError(t, writer.WriteField(name, value))
+	}
+	part, err := writer.CreateFormFile("file", "movie.en.srt")
+	assert.NoError(t, err)
+	part.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"))
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest("PUT", "/movie/tt2543164/subtitles/en", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadSubtitles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockSubtitleService)
+		subtitleHandler := NewSubtitleHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = subtitleUpload(t, map[string]string{"label": "English", "default": "true"})
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}, {Key: "lang", Value: "en"}}
+		c.Set("role", "ADMIN")
+
+		label, isDefault := "English", true
+		track := &models.SubtitleTrack{Language: "en", Label: "English", Default: true, URL: "http://localhost:8080/movie/tt2543164/subtitles/en.vtt"}
+		mockService.On("SaveTrack", mock.Anything, "tt2543164", "en", models.SubtitleTrackRequest{Label: &label, Default: &isDefault}, mock.Anything).Return(track, nil)
+
+		subtitleHandler.UploadSubtitles(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"url":"http://localhost:8080/movie/tt2543164/subtitles/en.vtt"`)
+		assert.NotContains(t, w.Body.String(), "key")
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		mockService := new(mocks.MockSubtitleService)
+		subtitleHandler := NewSubtitleHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = subtitleUpload(t, nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}, {Key: "lang", Value: "en"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("SaveTrack", mock.Anything, "tt2543164", "en", models.SubtitleTrackRequest{}, mock.Anything).Return(nil, service.ErrInvalidSubtitles)
+
+		subtitleHandler.UploadSubtitles(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService := new(mocks.MockSubtitleService)
+		subtitleHandler := NewSubtitleHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = subtitleUpload(t, nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}, {Key: "lang", Value: "en"}}
+		c.Set("role", "USER")
+
+		subtitleHandler.UploadSubtitles(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "SaveTrack", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateSubtitles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockSubtitleService)
+	subtitleHandler := NewSubtitleHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PATCH", "/movie/tt2543164/subtitles/fr", strings.NewReader(`{"default":true}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}, {Key: "lang", Value: "fr"}}
+	c.Set("role", "ADMIN")
+
+	isDefault := true
+	mockService.On("UpdateTrack", mock.Anything, "tt2543164", "fr", models.SubtitleTrackRequest{Default: &isDefault}).Return(nil, service.ErrSubtitleTrackNotFound)
+
+	subtitleHandler.UpdateSubtitles(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteSubtitles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockSubtitleService)
+	subtitleHandler := NewSubtitleHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("DELETE", "/movie/tt2543164/subtitles/en", nil)
+	c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}, {Key: "lang", Value: "en"}}
+	c.Set("role", "ADMIN")
+
+	mockService.On("DeleteTrack", mock.Anything, "tt2543164", "en").Return(nil)
+
+	subtitleHandler.DeleteSubtitles(c)
+
+	assert.Equal(t, http.StatusNoContent, c.Writer.Status())
+}
+
+func TestGetSubtitles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockSubtitleService)
+		subtitleHandler := NewSubtitleHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/movie/tt2543164/subtitles/pt-BR.vtt", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}, {Key: "lang", Value: "pt-BR.vtt"}}
+
+		blob := &storage.Blob{
+			ReadSeekCloser: readSeekNopCloser{strings.NewReader("WEBVTT\n")},
+			BlobInfo:       storage.BlobInfo{Key: "subtitles/tt2543164/pt-br-abc.vtt", Size: 7, ModTime: time.Now()},
+		}
+		mockService.On("OpenTrack", mock.Anything, "tt2543164", "pt-BR").Return(blob, nil)
+
+		subtitleHandler.GetSubtitles(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/vtt; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `"subtitles/tt2543164/pt-br-abc.vtt"`, w.Header().Get("ETag"))
+		assert.Equal(t, "WEBVTT\n", w.Body.String())
+	})
+
+	t.Run("NoExtension", func(t *testing.T) {
+		mockService := new(mocks.MockSubtitleService)
+		subtitleHandler := NewSubtitleHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/movie/tt2543164/subtitles/en", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt2543164"}, {Key: "lang", Value: "en"}}
+
+		subtitleHandler.GetSubtitles(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertNotCalled(t, "OpenTrack", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type TranscodeHandler struct {
+	service service.TranscodeService
+}
+
+func NewTranscodeHandler(s service.TranscodeService) *TranscodeHandler {
+	return &TranscodeHandler{service: s}
+}
+
+// TranscodeMovie godoc
+// @Summary      Transcode a title to HLS (Admin only)
+// @Description  Queue a job that transcodes media in the blob store into an adaptive HLS ladder with thumbnails. The source defaults to the title's media file, or else the source of its last transcode. The title plays from the ladder once the job is ready. Completed uploads are queued without this call.
+// @Tags         transcoding
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string                   true   "IMDB ID"
+// @Param        request  body      models.TranscodeRequest  false  "Media to transcode"
+// @Success      202      {object}  models.TranscodeJob
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      503      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/transcode [post]
+func (h *TranscodeHandler) TranscodeMovie(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+
+	var req models.TranscodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	job, err := h.service.TranscodeMovie(ctx, c.Param("imdb_id"), req, userId)
+	if err != nil {
+		transcodeError(c, err, "Error queueing transcode")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetMovieTranscodeJobs godoc
+// @Summary      List a title's transcode jobs (Admin only)
+// @Tags         transcoding
+// @Produce      json
+// @Security     BearerAuth
+// @Param        imdb_id  path      string  true  "IMDB ID"
+// @Success      200      {array}   models.TranscodeJob
+// @Failure      403      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /movie/{imdb_id}/transcode-jobs [get]
+func (h *TranscodeHandler) GetMovieTranscodeJobs(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	jobs, err := h.service.GetMovieJobs(ctx, c.Param("imdb_id"))
+	if err != nil {
+		transcodeError(c, err, "Error fetching transcode jobs")
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetTranscodeJob godoc
+// @Summary      Get a transcode job (Admin only)
+// @Description  Status and progress, from 0 to 1, of a transcode job, with its renditions and thumbnails once it is ready.
+// @Tags         transcoding
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  models.TranscodeJob
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /transcode-jobs/{id} [get]
+func (h *TranscodeHandler) GetTranscodeJob(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	job, err := h.service.GetJob(ctx, c.Param("id"))
+	if err != nil {
+		transcodeError(c, err, "Error fetching transcode job")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// CancelTranscodeJob godoc
+// @Summary      Cancel a transcode job (Admin only)
+// @Description  Stop a queued or running job. A running job stops within a few seconds and what it wrote is removed.
+// @Tags         transcoding
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  models.TranscodeJob
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /transcode-jobs/{id}/cancel [post]
+func (h *TranscodeHandler) CancelTranscodeJob(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	job, err := h.service.CancelJob(ctx, c.Param("id"))
+	if err != nil {
+		transcodeError(c, err, "Error canceling transcode job")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// RetryTranscodeJob godoc
+// @Summary      Retry a transcode job (Admin only)
+// @Description  Queue a failed or canceled job again.
+// @Tags         transcoding
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Job ID"
+// @Success      202  {object}  models.TranscodeJob
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      503  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /transcode-jobs/{id}/retry [post]
+func (h *TranscodeHandler) RetryTranscodeJob(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !requireAdmin(c) {
+		return
+	}
+
+	job, err := h.service.RetryJob(ctx, c.Param("id"))
+	if err != nil {
+		transcodeError(c, err, "Error retrying transcode job")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+func transcodeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+	case errors.Is(err, service.ErrTranscodeJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNoTranscodeSource):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTranscodeJobFinished), errors.Is(err, service.ErrTranscodeJobNotRetryable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTranscodingUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestTranscodeMovie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("DefaultSource", func(t *testing.T) {
+		mockService := new(mocks.MockTranscodeService)
+		transcodeHandler := NewTranscodeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/transcode", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+		c.Set("user_id", "admin1")
+
+		mockService.On("TranscodeMovie", mock.Anything, "tt1", models.TranscodeRequest{}, "admin1").
+			Return(&models.TranscodeJob{ImdbID: "tt1", SourceKey: "media/tt1/a.mp4", Status: models.TranscodeQueued}, nil)
+
+		transcodeHandler.TranscodeMovie(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var job models.TranscodeJob
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+		assert.Equal(t, models.TranscodeQueued, job.Status)
+	})
+
+	t.Run("GivenSource", func(t *testing.T) {
+		mockService := new(mocks.MockTranscodeService)
+		transcodeHandler := NewTranscodeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/transcode", strings.NewReader(`{"source_key":"media/tt1/b.mkv"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+		c.Set("user_id", "admin1")
+
+		mockService.On("TranscodeMovie", mock.Anything, "tt1", models.TranscodeRequest{SourceKey: "media/tt1/b.mkv"}, "admin1").
+			Return(nil, service.ErrNoTranscodeSource)
+
+		transcodeHandler.TranscodeMovie(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		mockService := new(mocks.MockTranscodeService)
+		transcodeHandler := NewTranscodeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/transcode", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "ADMIN")
+		c.Set("user_id", "admin1")
+
+		mockService.On("TranscodeMovie", mock.Anything, "tt1", models.TranscodeRequest{}, "admin1").Return(nil, service.ErrTranscodingUnavailable)
+
+		transcodeHandler.TranscodeMovie(c)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService := new(mocks.MockTranscodeService)
+		transcodeHandler := NewTranscodeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/movie/tt1/transcode", nil)
+		c.Params = gin.Params{{Key: "imdb_id", Value: "tt1"}}
+		c.Set("role", "USER")
+
+		transcodeHandler.TranscodeMovie(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "TranscodeMovie", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetMovieTranscodeJobs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockTranscodeService)
+	transcodeHandler := NewTranscodeHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/movie/tt0/transcode-jobs", nil)
+	c.Params = gin.Params{{Key: "imdb_id", Value: "tt0"}}
+	c.Set("role", "ADMIN")
+
+	mockService.On("GetMovieJobs", mock.Anything, "tt0").Return(nil, mongo.ErrNoDocuments)
+
+	transcodeHandler.GetMovieTranscodeJobs(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTranscodeJobActions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Get", func(t *testing.T) {
+		mockService := new(mocks.MockTranscodeService)
+		transcodeHandler := NewTranscodeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/transcode-jobs/job1", nil)
+		c.Params = gin.Params{{Key: "id", Value: "job1"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("GetJob", mock.Anything, "job1").Return(&models.TranscodeJob{Status: models.TranscodeRunning, Progress: 0.4}, nil)
+
+		transcodeHandler.GetTranscodeJob(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"progress":0.4`)
+	})
+
+	t.Run("CancelFinished", func(t *testing.T) {
+		mockService := new(mocks.MockTranscodeService)
+		transcodeHandler := NewTranscodeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/transcode-jobs/job1/cancel", nil)
+		c.Params = gin.Params{{Key: "id", Value: "job1"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("CancelJob", mock.Anything, "job1").Return(nil, service.ErrTranscodeJobFinished)
+
+		transcodeHandler.CancelTranscodeJob(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		mockService := new(mocks.MockTranscodeService)
+		transcodeHandler := NewTranscodeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/transcode-jobs/job1/retry", nil)
+		c.Params = gin.Params{{Key: "id", Value: "job1"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("RetryJob", mock.Anything, "job1").Return(&models.TranscodeJob{Status: models.TranscodeQueued, Attempts: 1}, nil)
+
+		transcodeHandler.RetryTranscodeJob(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService := new(mocks.MockTranscodeService)
+		transcodeHandler := NewTranscodeHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/transcode-jobs/nope/retry", nil)
+		c.Params = gin.Params{{Key: "id", Value: "nope"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("RetryJob", mock.Anything, "nope").Return(nil, service.ErrTranscodeJobNotFound)
+
+		transcodeHandler.RetryTranscodeJob(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
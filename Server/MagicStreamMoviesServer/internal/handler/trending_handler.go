@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// defaultTrendingLimit is how many trending movies are returned when no limit is given.
+const defaultTrendingLimit = 20
+
+type TrendingHandler struct {
+	service  service.TrendingService
+	validate *validator.Validate
+}
+
+func NewTrendingHandler(s service.TrendingService) *TrendingHandler {
+	return &TrendingHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+// GetTrending godoc
+// @Summary      Get trending movies
+// @Description  Get the movies with the highest time-decayed popularity over a window
+// @Tags         movies
+// @Produce      json
+// @Param        window  query     string  false  "Trending window (24h, 7d or 30d; default 7d)"
+// @Param        genre   query     string  false  "Only return movies in this genre"
+// @Param        limit   query     int     false  "Number of movies to return (default 20, max 100)"
+// @Success      200     {array}   models.TrendingMovie
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /movies/trending [get]
+func (h *TrendingHandler) GetTrending(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	limit, ok := parseLimit(c, defaultTrendingLimit)
+	if !ok {
+		return
+	}
+
+	movies, err := h.service.GetTrending(ctx, c.Query("window"), c.Query("genre"), int64(limit))
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownTrendingWindow) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching trending movies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, movies)
+}
+
+// RecordEvent godoc
+// @Summary      Record a movie event
+// @Description  Record a view or watchlist event for popularity analytics. Play and review events are recorded by the server. Repeats of the same event within the dedup window are accepted but not counted again.
+// @Tags         movies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        event  body      models.Event  true  "Event"
+// @Success      201    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      401    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /events [post]
+func (h *TrendingHandler) RecordEvent(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	var event models.Event
+	if err := c.ShouldBindJSON(&event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+	if err := h.validate.Struct(event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+	if event.Type != models.EventView && event.Type != models.EventWatchlist {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only view and watchlist events can be recorded"})
+		return
+	}
+
+	userId, err := middleware.GetUserIdFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+		return
+	}
+	event.UserID = userId
+
+	if err := h.service.RecordEvent(ctx, event); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Movie not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error recording event"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Event recorded"})
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestGetTrending(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockTrendingService)
+		trendingHandler := NewTrendingHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/movies/trending?window=24h&genre=Comedy&limit=5", nil)
+		c.Request = req
+
+		mockService.On("GetTrending", mock.Anything, "24h", "Comedy", int64(5)).
+			Return([]models.TrendingMovie{{Movie: models.Movie{ImdbID: "tt1"}, TrendingScore: 3.5}}, nil)
+
+		trendingHandler.GetTrending(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"trending_score":3.5`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("UnknownWindow", func(t *testing.T) {
+		mockService := new(mocks.MockTrendingService)
+		trendingHandler := NewTrendingHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("GET", "/movies/trending?window=1y", nil)
+		c.Request = req
+
+		mockService.On("GetTrending", mock.Anything, "1y", "", int64(defaultTrendingLimit)).
+			Return(nil, service.ErrUnknownTrendingWindow)
+
+		trendingHandler.GetTrending(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRecordEvent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockTrendingService)
+		trendingHandler := NewTrendingHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user_id", "user123")
+		req := httptest.NewRequest("POST", "/events", bytes.NewBufferString(`{"imdb_id":"tt1","type":"view"}`))
+		req.Header.Set("Content-Type", "application/json")
+		c.Request = req
+
+		mockService.On("RecordEvent", mock.Anything, mock.MatchedBy(func(e models.Event) bool {
+			return e.UserID == "user123" && e.ImdbID == "tt1" && e.Type == models.EventView
+		})).Return(nil)
+
+		trendingHandler.RecordEvent(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		mockService := new(mocks.MockTrendingService)
+		trendingHandler := NewTrendingHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("POST", "/events", bytes.NewBufferString(`{"imdb_id":"tt1","type":"view"}`))
+		req.Header.Set("Content-Type", "application/json")
+		c.Request = req
+
+		trendingHandler.RecordEvent(c)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockService.AssertNotCalled(t, "RecordEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnknownMovie", func(t *testing.T) {
+		mockService := new(mocks.MockTrendingService)
+		trendingHandler := NewTrendingHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("user_id", "user123")
+		req := httptest.NewRequest("POST", "/events", bytes.NewBufferString(`{"imdb_id":"tt0","type":"watchlist"}`))
+		req.Header.Set("Content-Type", "application/json")
+		c.Request = req
+
+		mockService.On("RecordEvent", mock.Anything, mock.Anything).Return(mongo.ErrNoDocuments)
+
+		trendingHandler.RecordEvent(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("ServerOnlyType", func(t *testing.T) {
+		mockService := new(mocks.MockTrendingService)
+		trendingHandler := NewTrendingHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		req := httptest.NewRequest("POST", "/events", bytes.NewBufferString(`{"imdb_id":"tt1","type":"play"}`))
+		req.Header.Set("Content-Type", "application/json")
+		c.Request = req
+
+		trendingHandler.RecordEvent(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "RecordEvent", mock.Anything, mock.Anything)
+	})
+}
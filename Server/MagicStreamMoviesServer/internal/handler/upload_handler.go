@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/middleware"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// tus protocol details: https://tus.io/protocols/resumable-upload
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,termination"
+	tusChunkType  = "application/offset+octet-stream"
+)
+
+// uploadTimeout bounds a single chunk. Clients are free to send the whole file as one chunk,
+// so it is generous.
+const uploadTimeout = 2 * time.Hour
+
+type UploadHandler struct {
+	service service.UploadService
+}
+
+func NewUploadHandler(s service.UploadService) *UploadHandler {
+	return &UploadHandler{service: s}
+}
+
+// UploadOptions godoc
+// @Summary      Describe the upload server
+// @Description  tus discovery: the protocol version, extensions and largest upload accepted.
+// @Tags         uploads
+// @Success      204
+// @Router       /uploads [options]
+func (h *UploadHandler) UploadOptions(c *gin.Context) {
+	header := c.Writer.Header()
+	header.Set("Tus-Resumable", tusVersion)
+	header.Set("Tus-Version", tusVersion)
+	header.Set("Tus-Extension", tusExtensions)
+	header.Set("Tus-Max-Size", strconv.FormatInt(h.service.MaxUploadSize(), 10))
+	c.Status(http.StatusNoContent)
+}
+
+// CreateUpload godoc
+// @Summary      Start a resumable media upload (Admin only)
+// @Description  tus creation. Upload-Metadata must name the title with imdb_id and may give filename and filetype. Send the data with PATCH to the URL in the Location header; once all of it has arrived the file is queued to be transcoded to HLS, or becomes the title's stream source when transcoding is off.
+// @Tags         uploads
+// @Security     BearerAuth
+// @Param        Tus-Resumable    header  string  true   "1.0.0"
+// @Param        Upload-Length    header  int     true   "Size of the file in bytes"
+// @Param        Upload-Metadata  header  string  true   "Comma separated key and base64 value pairs, e.g. imdb_id dHQyNTQzMTY0,filename bW92aWUubXA0"
+// @Success      201
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      412
+// @Failure      413  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /uploads [post]
+func (h *UploadHandler) CreateUpload(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !h.checkVersion(c) || !requireAdmin(c) {
+		return
+	}
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Upload-Length is required"})
+		return
+	}
+	metadata, err := parseUploadMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userId, _ := middleware.GetUserIdFromContext(c)
+
+	upload, err := h.service.CreateUpload(ctx, models.Upload{
+		ImdbID:      metadata["imdb_id"],
+		Filename:    metadata["filename"],
+		ContentType: metadata["filetype"],
+		Length:      length,
+		Metadata:    c.GetHeader("Upload-Metadata"),
+		CreatedBy:   userId,
+	})
+	if err != nil {
+		uploadError(c, err, "Error creating upload")
+		return
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+upload.ID.Hex())
+	c.Status(http.StatusCreated)
+}
+
+// GetUploadOffset godoc
+// @Summary      Get the offset of a resumable upload (Admin only)
+// @Description  tus HEAD: how many bytes have arrived, so an interrupted upload can resume from there.
+// @Tags         uploads
+// @Security     BearerAuth
+// @Param        id             path    string  true  "Upload ID"
+// @Param        Tus-Resumable  header  string  true  "1.0.0"
+// @Success      200
+// @Failure      403
+// @Failure      404
+// @Failure      412
+// @Router       /uploads/{id} [head]
+func (h *UploadHandler) GetUploadOffset(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !h.checkVersion(c) || !requireAdmin(c) {
+		return
+	}
+
+	upload, err := h.service.GetUpload(ctx, c.Param("id"))
+	if err != nil {
+		// HEAD responses carry no body, so only the status is sent.
+		c.Status(uploadStatus(err))
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	if upload.Metadata != "" {
+		c.Header("Upload-Metadata", upload.Metadata)
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+}
+
+// WriteUploadChunk godoc
+// @Summary      Send data of a resumable upload (Admin only)
+// @Description  tus PATCH: append the body at Upload-Offset, which must be the upload's current offset. Once the last chunk is in, the file is stored in the background and queued for transcoding, or made the title's stream source when transcoding is off; the upload shows completed_at when that is done. If storing the file fails, an empty PATCH at the final offset retries it.
+// @Tags         uploads
+// @Accept       application/offset+octet-stream
+// @Security     BearerAuth
+// @Param        id             path    string  true  "Upload ID"
+// @Param        Tus-Resumable  header  string  true  "1.0.0"
+// @Param        Upload-Offset  header  int     true  "Offset the body starts at"
+// @Success      204
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      412
+// @Failure      413  {object}  map[string]interface{}
+// @Failure      415  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /uploads/{id} [patch]
+func (h *UploadHandler) WriteUploadChunk(c *gin.Context) {
+	// The chunk is saved even when the client goes away mid-request, so it can resume after
+	// the data that arrived.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), uploadTimeout)
+	defer cancel()
+
+	if !h.checkVersion(c) || !requireAdmin(c) {
+		return
+	}
+	if c.ContentType() != tusChunkType {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + tusChunkType})
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Upload-Offset is required"})
+		return
+	}
+
+	upload, err := h.service.WriteChunk(ctx, c.Param("id"), offset, c.Request.Body, c.Request.ContentLength)
+	if err != nil {
+		uploadError(c, err, "Error writing upload")
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteUpload godoc
+// @Summary      Cancel a resumable upload (Admin only)
+// @Description  tus termination: stop an unfinished upload and remove the data received.
+// @Tags         uploads
+// @Security     BearerAuth
+// @Param        id             path  string  true  "Upload ID"
+// @Param        Tus-Resumable  header  string  true  "1.0.0"
+// @Success      204
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      412
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /uploads/{id} [delete]
+func (h *UploadHandler) DeleteUpload(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+	defer cancel()
+
+	if !h.checkVersion(c) || !requireAdmin(c) {
+		return
+	}
+
+	if err := h.service.DeleteUpload(ctx, c.Param("id")); err != nil {
+		uploadError(c, err, "Error deleting upload")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// checkVersion sets Tus-Resumable on the response and rejects clients speaking another
+// protocol version.
+func (h *UploadHandler) checkVersion(c *gin.Context) bool {
+	c.Header("Tus-Resumable", tusVersion)
+	if c.GetHeader("Tus-Resumable") != tusVersion {
+		c.Header("Tus-Version", tusVersion)
+		c.AbortWithStatus(http.StatusPreconditionFailed)
+		return false
+	}
+	return true
+}
+
+// parseUploadMetadata decodes an Upload-Metadata header: comma separated pairs of a key and a
+// base64 value, where the value may be left out.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, errors.New("Upload-Metadata has an empty key")
+		}
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, errors.New("Upload-Metadata value of " + key + " is not base64")
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+func uploadStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUploadNotFound), errors.Is(err, mongo.ErrNoDocuments):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrUploadOffset), errors.Is(err, service.ErrUploadCompleted):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrUploadTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrInvalidUpload):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func uploadError(c *gin.Context, err error, message string) {
+	status := uploadStatus(err)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(status, gin.H{"error": "Movie not found"})
+	case status == http.StatusInternalServerError:
+		c.JSON(status, gin.H{"error": message})
+	default:
+		c.JSON(status, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func tusRequest(method string, target string, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	return req
+}
+
+func TestUploadOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(mocks.MockUploadService)
+	uploadHandler := NewUploadHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("OPTIONS", "/uploads", nil)
+
+	mockService.On("MaxUploadSize").Return(int64(1 << 30))
+
+	uploadHandler.UploadOptions(c)
+
+	assert.Equal(t, http.StatusNoContent, c.Writer.Status())
+	assert.Equal(t, "1.0.0", w.Header().Get("Tus-Version"))
+	assert.Equal(t, "creation,termination", w.Header().Get("Tus-Extension"))
+	assert.Equal(t, "1073741824", w.Header().Get("Tus-Max-Size"))
+}
+
+func TestCreateUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockUploadService)
+		uploadHandler := NewUploadHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = tusRequest("POST", "/uploads", "")
+		c.Request.Header.Set("Upload-Length", "2048")
+		// imdb_id tt1, filename movie.mp4, and a key without a value.
+		c.Request.Header.Set("Upload-Metadata", "imdb_id dHQx,filename bW92aWUubXA0,is_confidential")
+		c.Set("role", "ADMIN")
+		c.Set("user_id", "admin1")
+
+		id := bson.NewObjectID()
+		expected := models.Upload{
+			ImdbID:    "tt1",
+			Filename:  "movie.mp4",
+			Length:    2048,
+			Metadata:  "imdb_id dHQx,filename bW92aWUubXA0,is_confidential",
+			CreatedBy: "admin1",
+		}
+		mockService.On("CreateUpload", mock.Anything, expected).Return(&models.Upload{ID: id, ImdbID: "tt1", Length: 2048}, nil)
+
+		uploadHandler.CreateUpload(c)
+
+		assert.Equal(t, http.StatusCreated, c.Writer.Status())
+		assert.Equal(t, "/uploads/"+id.Hex(), w.Header().Get("Location"))
+		assert.Equal(t, "1.0.0", w.Header().Get("Tus-Resumable"))
+	})
+
+	t.Run("WrongVersion", func(t *testing.T) {
+		mockService := new(mocks.MockUploadService)
+		uploadHandler := NewUploadHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/uploads", nil)
+		c.Request.Header.Set("Tus-Resumable", "0.2.2")
+		c.Set("role", "ADMIN")
+
+		uploadHandler.CreateUpload(c)
+
+		assert.Equal(t, http.StatusPreconditionFailed, c.Writer.Status())
+		assert.Equal(t, "1.0.0", w.Header().Get("Tus-Version"))
+	})
+
+	t.Run("BadMetadata", func(t *testing.T) {
+		mockService := new(mocks.MockUploadService)
+		uploadHandler := NewUploadHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = tusRequest("POST", "/uploads", "")
+		c.Request.Header.Set("Upload-Length", "2048")
+		c.Request.Header.Set("Upload-Metadata", "imdb_id not*base64")
+		c.Set("role", "ADMIN")
+
+		uploadHandler.CreateUpload(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService := new(mocks.MockUploadService)
+		uploadHandler := NewUploadHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = tusRequest("POST", "/uploads", "")
+		c.Set("role", "USER")
+
+		uploadHandler.CreateUpload(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestGetUploadOffset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockUploadService)
+		uploadHandler := NewUploadHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = tusRequest("HEAD", "/uploads/abc", "")
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("GetUpload", mock.Anything, "abc").Return(&models.Upload{Length: 2048, Offset: 512, Metadata: "imdb_id dHQx"}, nil)
+
+		uploadHandler.GetUploadOffset(c)
+
+		assert.Equal(t, http.StatusOK, c.Writer.Status())
+		assert.Equal(t, "512", w.Header().Get("Upload-Offset"))
+		assert.Equal(t, "2048", w.Header().Get("Upload-Length"))
+		assert.Equal(t, "imdb_id dHQx", w.Header().Get("Upload-Metadata"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService := new(mocks.MockUploadService)
+		uploadHandler := NewUploadHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = tusRequest("HEAD", "/uploads/abc", "")
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("GetUpload", mock.Anything, "abc").Return(nil, service.ErrUploadNotFound)
+
+		uploadHandler.GetUploadOffset(c)
+
+		assert.Equal(t, http.StatusNotFound, c.Writer.Status())
+	})
+}
+
+func TestWriteUploadChunk(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(mocks.MockUploadService)
+		uploadHandler := NewUploadHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = tusRequest("PATCH", "/uploads/abc", "hello")
+		c.Request.Header.Set("Content-Type", "application/offset+octet-stream")
+		c.Request.Header.Set("Upload-Offset", "512")
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+		c.Set("role", "ADMIN")
+
+		mockService.On("WriteChunk", mock.Anything, "abc", int64(512), mock.Anything, int64(5)).Return(&models.Upload{Length: 2048, Offset: 517}, nil)
+
+		uploadHandler.WriteUploadChunk(c)
+
+		assert.Equal(t, http.StatusNoContent, c.Writer.Status())
+		assert.Equal(t, "517", w.Header().Get("Upload-Offset"))
+	})
+
+	t.Run("WrongContentType", func(t *testing.T) {
+		mockService := new(mocks.MockUploadService)
+		uploadHandler := NewUploadHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = tusRequest("PATCH", "/uploads/abc", "hello")
+		c.Request.Header.Set("Content-Type", "application/octet-stream")
+		c.Request.Header.Set("Upload-Offset", "0")
+		c.Set("role", "ADMIN")
+
+		uploadHandler.WriteUploadChunk(c)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := map[error]int{
+			service.ErrUploadNotFound:  http.StatusNotFound,
+			service.ErrUploadOffset:    http.StatusConflict,
+			service.ErrUploadCompleted: http.StatusConflict,
+			service.ErrUploadTooLarge:  http.StatusRequestEntityTooLarge,
+			mongo.ErrNoDocuments:       http.StatusNotFound,
+		}
+		for err, status := range cases {
+			mockService := new(mocks.MockUploadService)
+			uploadHandler := NewUploadHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = tusRequest("PATCH", "/uploads/abc", "hello")
+			c.Request.Header.Set("Content-Type", "application/offset+octet-stream")
+			c.Request.Header.Set("Upload-Offset", "0")
+			c.Params = gin.Params{{Key: "id", Value: "abc"}}
+			c.Set("role", "ADMIN")
+
+			mockService.On("WriteChunk", mock.Anything, "abc", int64(0), mock.Anything, int64(5)).Return(nil, err)
+
+			uploadHandler.WriteUploadChunk(c)
+
+			assert.Equal(t, status, w.Code, err.Error())
+		}
+	})
+}
+
+func TestDeleteUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(mocks.MockUploadService)
+	uploadHandler := NewUploadHandler(mockService)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = tusRequest("DELETE", "/uploads/abc", "")
+	c.Params = gin.Params{{Key: "id", Value: "abc"}}
+	c.Set("role", "ADMIN")
+
+	mockService.On("DeleteUpload", mock.Anything, "abc").Return(nil)
+
+	uploadHandler.DeleteUpload(c)
+
+	assert.Equal(t, http.StatusNoContent, c.Writer.Status())
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -25,7 +26,7 @@ func NewUserHandler(s service.UserService) *UserHandler {
 
 // RegisterUser godoc
 // @Summary      Register a new user
-// @Description  Register a new user with the provided details
+// @Description  Register a new user with the provided details. Favourite genres are referenced by genre_id and must exist; their names are taken from the genre list.
 // @Tags         users
 // @Accept       json
 // @Produce      json
@@ -55,6 +56,8 @@ func (h *UserHandler) RegisterUser(c *gin.Context) {
 		status := http.StatusInternalServerError
 		if err.Error() == "user already exists" {
 			status = http.StatusConflict
+		} else if errors.Is(err, service.ErrUnknownGenres) {
+			status = http.StatusBadRequest
 		}
 		c.JSON(status, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,193 @@
+// Package imaging validates uploaded images and renders their resized copies as JPEG and WebP.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// Content types accepted for uploads.
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeWebP = "image/webp"
+)
+
+// maxPixels bounds decoded images, so a small file claiming huge dimensions is rejected before
+// it is decoded.
+const maxPixels = 50_000_000
+
+const jpegQuality = 85
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type, expected JPEG, PNG or WebP")
+	ErrTooManyPixels   = errors.New("image dimensions are too large")
+)
+
+// Variant is a resized copy that fits within Width x Height, keeping the aspect ratio.
+// Images are never enlarged.
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// Sizes of the poster (2:3) and backdrop (16:9) copies.
+var (
+	PosterVariants = []Variant{
+		{Name: "thumb", Width: 185, Height: 278},
+		{Name: "medium", Width: 342, Height: 513},
+		{Name: "large", Width: 780, Height: 1170},
+	}
+	BackdropVariants = []Variant{
+		{Name: "thumb", Width: 300, Height: 169},
+		{Name: "medium", Width: 780, Height: 439},
+		{Name: "large", Width: 1280, Height: 720},
+	}
+)
+
+// File is an encoded image ready to be stored.
+type File struct {
+	// Name is the variant name, with a "_webp" suffix for WebP copies, or "original".
+	Name        string
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Result holds the files rendered from an upload.
+type Result struct {
+	Width  int
+	Height int
+	Files  []File
+}
+
+// Detect sniffs the content type of data and checks that it is a supported image type.
+func Detect(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case TypeJPEG, TypePNG, TypeWebP:
+		return contentType, nil
+	}
+	return "", fmt.Errorf("%w: got %s", ErrUnsupportedType, contentType)
+}
+
+// Process validates the upload and renders every variant as JPEG and WebP. The upload itself
+// is kept as the "original" file.
+func Process(data []byte, variants []Variant) (*Result, error) {
+	contentType, err := Detect(data)
+	if err != nil {
+		return nil, err
+	}
+	img, err := decode(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	result := &Result{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+		Files: []File{{
+			Name:        "original",
+			Filename:    "original" + extension(contentType),
+			ContentType: contentType,
+			Data:        data,
+		}},
+	}
+	for _, variant := range variants {
+		resized := Resize(img, variant.Width, variant.Height)
+
+		var jpg bytes.Buffer
+		if err := jpeg.Encode(&jpg, flatten(resized), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		var wp bytes.Buffer
+		if err := EncodeWebP(&wp, resized); err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files,
+			File{Name: variant.Name, Filename: variant.Name + ".jpg", ContentType: TypeJPEG, Data: jpg.Bytes()},
+			File{Name: variant.Name + "_webp", Filename: variant.Name + ".webp", ContentType: TypeWebP, Data: wp.Bytes()},
+		)
+	}
+	return result, nil
+}
+
+// Resize scales img to fit within width x height, keeping its aspect ratio. Smaller images are
+// returned as they are.
+func Resize(img image.Image, width int, height int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= width && h <= height {
+		return img
+	}
+	scale := min(float64(width)/float64(w), float64(height)/float64(h))
+	target := image.Rect(0, 0, max(1, int(float64(w)*scale+0.5)), max(1, int(float64(h)*scale+0.5)))
+	resized := image.NewNRGBA(target)
+	draw.CatmullRom.Scale(resized, target, img, bounds, draw.Src, nil)
+	return resized
+}
+
+func decode(data []byte, contentType string) (image.Image, error) {
+	var config image.Config
+	var err error
+	switch contentType {
+	case TypeJPEG:
+		config, err = jpeg.DecodeConfig(bytes.NewReader(data))
+	case TypePNG:
+		config, err = png.DecodeConfig(bytes.NewReader(data))
+	case TypeWebP:
+		config, err = webp.DecodeConfig(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	var img image.Image
+	switch contentType {
+	case TypeJPEG:
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	case TypePNG:
+		img, err = png.Decode(bytes.NewReader(data))
+	case TypeWebP:
+		img, err = webp.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+	return img, nil
+}
+
+// flatten draws img over white, since JPEG has no transparency.
+func flatten(img image.Image) image.Image {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return img
+	}
+	bounds := img.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Rect, image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Rect, img, bounds.Min, draw.Over)
+	return flat
+}
+
+func extension(contentType string) string {
+	switch contentType {
+	case TypePNG:
+		return ".png"
+	case TypeWebP:
+		return ".webp"
+	}
+	return ".jpg"
+}
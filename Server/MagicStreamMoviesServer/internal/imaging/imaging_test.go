@@ -0,0 +1,125 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/webp"
+)
+
+func gradient(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	random := rand.New(rand.NewSource(1))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(x * 255 / width),
+				G: uint8(y * 255 / height),
+				B: uint8(random.Intn(256)),
+				A: uint8(255 - x%3),
+			})
+		}
+	}
+	return img
+}
+
+func TestEncodeWebP_RoundTrip(t *testing.T) {
+	solid := image.NewNRGBA(image.Rect(0, 0, 7, 5))
+	for i := range solid.Pix {
+		solid.Pix[i] = 0xff
+	}
+	twoColors := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := 0; i < 16; i++ {
+		twoColors.SetNRGBA(i%4, i/4, color.NRGBA{R: uint8(200 * (i % 2)), A: 0xff})
+	}
+
+	for name, img := range map[string]*image.NRGBA{"gradient": gradient(97, 61), "solid": solid, "two colors": twoColors} {
+		var out bytes.Buffer
+		assert.NoError(t, EncodeWebP(&out, img), name)
+
+		decoded, err := webp.Decode(&out)
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+		assert.Equal(t, img.Bounds(), decoded.Bounds(), name)
+		for y := 0; y < img.Rect.Dy(); y++ {
+			for x := 0; x < img.Rect.Dx(); x++ {
+				if !assert.Equal(t, img.NRGBAAt(x, y), color.NRGBAModel.Convert(decoded.At(x, y)), "%s at %d,%d", name, x, y) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	img := gradient(1000, 500)
+
+	resized := Resize(img, 300, 300)
+	assert.Equal(t, image.Rect(0, 0, 300, 150), resized.Bounds())
+
+	assert.Same(t, img, Resize(img, 2000, 2000))
+}
+
+func TestProcess(t *testing.T) {
+	var upload bytes.Buffer
+	assert.NoError(t, png.Encode(&upload, gradient(400, 600)))
+
+	result, err := Process(upload.Bytes(), PosterVariants)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 400, result.Width)
+	assert.Equal(t, 600, result.Height)
+	names := map[string]string{}
+	for _, file := range result.Files {
+		names[file.Name] = file.Filename
+		detected, err := Detect(file.Data)
+		assert.NoError(t, err, file.Name)
+		assert.Equal(t, file.ContentType, detected, file.Name)
+	}
+	assert.Equal(t, map[string]string{
+		"original": "original.png",
+		"thumb":    "thumb.jpg", "thumb_webp": "thumb.webp",
+		"medium": "medium.jpg", "medium_webp": "medium.webp",
+		"large": "large.jpg", "large_webp": "large.webp",
+	}, names)
+
+	// The large copy would be bigger than the upload, so it keeps the upload's size.
+	large, err := webp.DecodeConfig(bytes.NewReader(result.Files[len(result.Files)-1].Data))
+	assert.NoError(t, err)
+	assert.Equal(t, 400, large.Width)
+}
+
+func TestProcess_RejectsOtherTypes(t *testing.T) {
+	_, err := Process([]byte("<svg xmlns='http://www.w3.org/2000/svg'></svg>"), PosterVariants)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+	// A PNG signature followed by garbage is sniffed as PNG but cannot be decoded.
+	_, err = Process(append([]byte("\x89PNG\x0d\x0a\x1a\x0a"), make([]byte, 32)...), PosterVariants)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func TestHuffmanLengths_Limited(t *testing.T) {
+	// Fibonacci counts make the optimal code as deep as it can be.
+	histogram := make([]int, 30)
+	a, b := 1, 1
+	for i := range histogram {
+		histogram[i] = a
+		a, b = b, a+b
+	}
+
+	lengths := huffmanLengths(histogram, vp8lMaxCodeLength)
+
+	kraft := 0.0
+	for _, length := range lengths {
+		assert.LessOrEqual(t, int(length), vp8lMaxCodeLength)
+		kraft += 1 / float64(int(1)<<length)
+	}
+	assert.InDelta(t, 1, kraft, 1e-9)
+}
@@ -0,0 +1,325 @@
+package imaging
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"io"
+)
+
+// WebP lossless (VP8L) bitstream limits and alphabet sizes.
+const (
+	vp8lSignature        = 0x2f
+	vp8lMaxDimension     = 1 << 14
+	vp8lMaxCodeLength    = 15
+	vp8lMaxLengthCodeLen = 7
+	vp8lSubtractGreen    = 2
+	vp8lGreenAlphabet    = 256 + 24 // literals and backward reference lengths
+	vp8lDistanceAlphabet = 40
+)
+
+// vp8lCodeLengthOrder is the order in which the code length code lengths are written.
+var vp8lCodeLengthOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// EncodeWebP writes img as a lossless WebP. The encoder only applies the subtract green
+// transform and entropy codes the pixels, without backward references or a color cache: the
+// files are larger than those of a full encoder, but any WebP decoder reads them.
+func EncodeWebP(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > vp8lMaxDimension || height > vp8lMaxDimension {
+		return errors.New("webp: image size out of range")
+	}
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok || nrgba.Rect.Min != (image.Point{}) {
+		nrgba = image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(nrgba, nrgba.Rect, img, bounds.Min, draw.Src)
+	}
+
+	// Pixels after the subtract green transform, as green, red, blue and alpha symbols.
+	pixels := make([][4]uint16, 0, width*height)
+	var histograms [4][]int
+	histograms[0] = make([]int, vp8lGreenAlphabet)
+	for i := 1; i < 4; i++ {
+		histograms[i] = make([]int, 256)
+	}
+	hasAlpha := false
+	for y := 0; y < height; y++ {
+		row := nrgba.Pix[y*nrgba.Stride : y*nrgba.Stride+width*4]
+		for x := 0; x < width*4; x += 4 {
+			r, g, b, a := row[x], row[x+1], row[x+2], row[x+3]
+			pixel := [4]uint16{uint16(g), uint16(r - g), uint16(b - g), uint16(a)}
+			for i, symbol := range pixel {
+				histograms[i][symbol]++
+			}
+			hasAlpha = hasAlpha || a != 0xff
+			pixels = append(pixels, pixel)
+		}
+	}
+
+	bw := &bitWriter{}
+	bw.write(vp8lSignature, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	bw.write(boolBit(hasAlpha), 1)
+	bw.write(0, 3) // version
+	bw.write(1, 1) // a transform follows
+	bw.write(vp8lSubtractGreen, 2)
+	bw.write(0, 1) // no more transforms
+	bw.write(0, 1) // no color cache
+	bw.write(0, 1) // a single group of prefix codes
+
+	var codes [4]prefixCode
+	for i, histogram := range histograms {
+		codes[i] = writePrefixCode(bw, histogram)
+	}
+	// Distances are never used, as there are no backward references.
+	writePrefixCode(bw, make([]int, vp8lDistanceAlphabet))
+
+	for _, pixel := range pixels {
+		for i, symbol := range pixel {
+			codes[i].write(bw, symbol)
+		}
+	}
+	data := bw.bytes()
+
+	// RIFF container with a single VP8L chunk, padded to an even size.
+	chunkSize := len(data)
+	padded := chunkSize + chunkSize&1
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+8+padded))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(chunkSize))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if padded != chunkSize {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	return nil
+}
+
+// prefixCode holds the bit reversed canonical codes of a Huffman code, ready to be written
+// least significant bit first.
+type prefixCode struct {
+	codes   []uint16
+	lengths []uint8
+}
+
+func (c prefixCode) write(bw *bitWriter, symbol uint16) {
+	if n := c.lengths[symbol]; n > 0 {
+		bw.write(uint32(c.codes[symbol]), uint(n))
+	}
+}
+
+// writePrefixCode writes the code for the histogram and returns it. A code with a single used
+// symbol takes no bits per symbol.
+func writePrefixCode(bw *bitWriter, histogram []int) prefixCode {
+	var used []int
+	for symbol, count := range histogram {
+		if count > 0 {
+			used = append(used, symbol)
+		}
+	}
+	if len(used) <= 1 {
+		symbol := 0
+		if len(used) == 1 {
+			symbol = used[0]
+		}
+		bw.write(1, 1) // simple code
+		bw.write(0, 1) // one symbol
+		if symbol < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(symbol), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(symbol), 8)
+		}
+		return prefixCode{codes: make([]uint16, len(histogram)), lengths: make([]uint8, len(histogram))}
+	}
+
+	lengths := huffmanLengths(histogram, vp8lMaxCodeLength)
+
+	// The code lengths are themselves Huffman coded, written as literal lengths 0 to 15.
+	lengthHistogram := make([]int, len(vp8lCodeLengthOrder))
+	for _, length := range lengths {
+		lengthHistogram[length]++
+	}
+	lengthLengths := huffmanLengths(lengthHistogram, vp8lMaxLengthCodeLen)
+	if countNonZero(lengthLengths) == 1 {
+		// A single code length symbol is read without bits; its length only has to be set.
+		for i := range lengthLengths {
+			if lengthHistogram[i] > 0 {
+				lengthLengths[i] = 1
+			}
+		}
+	}
+	count := len(vp8lCodeLengthOrder)
+	for count > 4 && lengthLengths[vp8lCodeLengthOrder[count-1]] == 0 {
+		count--
+	}
+	bw.write(0, 1) // normal code
+	bw.write(uint32(count-4), 4)
+	for _, symbol := range vp8lCodeLengthOrder[:count] {
+		bw.write(uint32(lengthLengths[symbol]), 3)
+	}
+	bw.write(0, 1) // every symbol's length follows
+
+	lengthCode := canonicalCode(lengthLengths)
+	if countNonZero(lengthLengths) == 1 {
+		lengthCode.lengths = make([]uint8, len(lengthLengths))
+	}
+	for _, length := range lengths {
+		lengthCode.write(bw, uint16(length))
+	}
+	return canonicalCode(lengths)
+}
+
+// canonicalCode assigns canonical Huffman codes, shorter codes and then lower symbols first,
+// and reverses their bits for the least significant bit first writer.
+func canonicalCode(lengths []uint8) prefixCode {
+	var lengthCounts [vp8lMaxCodeLength + 1]int
+	for _, length := range lengths {
+		lengthCounts[length]++
+	}
+	lengthCounts[0] = 0
+	var next [vp8lMaxCodeLength + 2]int
+	code := 0
+	for length := 1; length <= vp8lMaxCodeLength; length++ {
+		code = (code + lengthCounts[length-1]) << 1
+		next[length] = code
+	}
+
+	c := prefixCode{codes: make([]uint16, len(lengths)), lengths: lengths}
+	for symbol, length := range lengths {
+		if length == 0 {
+			continue
+		}
+		value := next[length]
+		next[length]++
+		reversed := 0
+		for i := 0; i < int(length); i++ {
+			reversed = reversed<<1 | (value>>i)&1
+		}
+		c.codes[symbol] = uint16(reversed)
+	}
+	return c
+}
+
+// huffmanLengths builds Huffman code lengths for the histogram no longer than limit. When the
+// optimal code is too deep, rare symbols are counted as more frequent until it fits.
+func huffmanLengths(histogram []int, limit int) []uint8 {
+	for minCount := 1; ; minCount *= 2 {
+		lengths := make([]uint8, len(histogram))
+		nodes := &huffmanHeap{}
+		// Leaves take the first ids, in the order of symbols; parents[id] links each node to
+		// the node merging it.
+		var parents, symbols []int
+		for symbol, count := range histogram {
+			if count > 0 {
+				heap.Push(nodes, huffmanNode{count: max(count, minCount), id: len(parents)})
+				parents = append(parents, -1)
+				symbols = append(symbols, symbol)
+			}
+		}
+		leaves := len(parents)
+		if leaves == 1 {
+			lengths[symbols[0]] = 1
+			return lengths
+		}
+		for nodes.Len() > 1 {
+			a := heap.Pop(nodes).(huffmanNode)
+			b := heap.Pop(nodes).(huffmanNode)
+			parents = append(parents, -1)
+			parent := len(parents) - 1
+			parents[a.id], parents[b.id] = parent, parent
+			heap.Push(nodes, huffmanNode{count: a.count + b.count, id: parent})
+		}
+
+		deepest := 0
+		for leaf := 0; leaf < leaves; leaf++ {
+			depth := 0
+			for node := leaf; parents[node] != -1; node = parents[node] {
+				depth++
+			}
+			lengths[symbols[leaf]] = uint8(depth)
+			deepest = max(deepest, depth)
+		}
+		if deepest <= limit {
+			return lengths
+		}
+	}
+}
+
+type huffmanNode struct {
+	count int
+	id    int
+}
+
+// huffmanHeap orders nodes by count, breaking ties by creation so codes are deterministic.
+type huffmanHeap []huffmanNode
+
+func (h huffmanHeap) Len() int { return len(h) }
+func (h huffmanHeap) Less(i, j int) bool {
+	if h[i].count != h[j].count {
+		return h[i].count < h[j].count
+	}
+	return h[i].id < h[j].id
+}
+func (h huffmanHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *huffmanHeap) Push(x any)   { *h = append(*h, x.(huffmanNode)) }
+func (h *huffmanHeap) Pop() any {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return node
+}
+
+func countNonZero(lengths []uint8) int {
+	n := 0
+	for _, length := range lengths {
+		if length > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// bitWriter packs bits least significant bit first, as VP8L reads them.
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func (w *bitWriter) write(bits uint32, n uint) {
+	w.acc |= uint64(bits) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.nbits = 0, 0
+	}
+	return w.buf
+}
+
+func boolBit(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,179 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// listSeparator separates the values of list columns such as genre and keywords.
+const listSeparator = "|"
+
+// csvColumns maps the CSV header, named after the movie's JSON fields, to setters. Genres are
+// genre IDs or names.
+var csvColumns = map[string]func(movie *models.Movie, value string) error{
+	"imdb_id":           func(m *models.Movie, v string) error { m.ImdbID = v; return nil },
+	"content_type":      func(m *models.Movie, v string) error { m.ContentType = v; return nil },
+	"title":             func(m *models.Movie, v string) error { m.Title = v; return nil },
+	"poster_path":       func(m *models.Movie, v string) error { m.PosterPath = v; return nil },
+	"youtube_id":        func(m *models.Movie, v string) error { m.YouTubeID = v; return nil },
+	"admin_review":      func(m *models.Movie, v string) error { m.AdminReview = v; return nil },
+	"ranking_name":      func(m *models.Movie, v string) error { m.Ranking.RankingName = v; return nil },
+	"synopsis":          func(m *models.Movie, v string) error { m.Synopsis = v; return nil },
+	"original_language": func(m *models.Movie, v string) error { m.OriginalLanguage = v; return nil },
+	"country":           func(m *models.Movie, v string) error { m.Country = v; return nil },
+	"maturity_rating":   func(m *models.Movie, v string) error { m.MaturityRating = v; return nil },
+	"keywords":          func(m *models.Movie, v string) error { m.Keywords = splitList(v); return nil },
+	"backdrops":         func(m *models.Movie, v string) error { m.Backdrops = splitList(v); return nil },
+	"genre":             func(m *models.Movie, v string) error { m.Genre = parseGenres(splitList(v)); return nil },
+	"ranking_value":     func(m *models.Movie, v string) error { return setInt(&m.Ranking.RankingValue, "ranking_value", v) },
+	"runtime":           func(m *models.Movie, v string) error { return setInt(&m.Runtime, "runtime", v) },
+	"release_date": func(m *models.Movie, v string) error {
+		date, err := parseDate(v)
+		if err != nil {
+			return err
+		}
+		m.ReleaseDate = &date
+		return nil
+	},
+}
+
+// exportOnlyColumns are written by catalog exports but maintained by the server, so they are
+// accepted and ignored, letting an edited export be imported again.
+var exportOnlyColumns = map[string]bool{
+	"average_rating": true,
+	"review_count":   true,
+	"mood_tags":      true,
+	"created_at":     true,
+	"updated_at":     true,
+}
+
+type csvReader struct {
+	csv    *csv.Reader
+	header []string
+}
+
+// newCSVReader reads a header row naming the columns, in any order. Only the columns present
+// are imported, and empty cells keep the stored value.
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty csv file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, len(header))
+	hasID := false
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, ok := csvColumns[name]; !ok && !exportOnlyColumns[name] {
+			return nil, fmt.Errorf("unknown csv column %q", name)
+		}
+		hasID = hasID || name == "imdb_id"
+		columns[i] = name
+	}
+	if !hasID {
+		return nil, errors.New("csv header has no imdb_id column")
+	}
+	return &csvReader{csv: reader, header: columns}, nil
+}
+
+func (r *csvReader) Next() (*Record, error) {
+	row, err := r.csv.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RowError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+		return nil, err
+	}
+	line, _ := r.csv.FieldPos(0)
+
+	values := make(map[string]string, len(row))
+	for i, value := range row {
+		values[r.header[i]] = unescapeFormula(strings.TrimSpace(value))
+	}
+	if values["imdb_id"] == "" {
+		return nil, &RowError{Line: line, Err: errors.New("imdb_id is empty")}
+	}
+	return &Record{
+		Line:   line,
+		ImdbID: values["imdb_id"],
+		Apply: func(movie *models.Movie) error {
+			for _, column := range r.header {
+				if values[column] == "" || exportOnlyColumns[column] {
+					continue
+				}
+				if err := csvColumns[column](movie, values[column]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, nil
+}
+
+// unescapeFormula drops the quote catalog exports put in front of text that spreadsheets would
+// otherwise read as a formula.
+func unescapeFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.IndexByte("=+-@\t\r", value[1]) >= 0 {
+		return value[1:]
+	}
+	return value
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseGenres reads genre IDs, or names when a value is not a number.
+func parseGenres(values []string) []models.Genre {
+	genres := make([]models.Genre, 0, len(values))
+	for _, value := range values {
+		if id, err := strconv.Atoi(value); err == nil {
+			genres = append(genres, models.Genre{GenreID: id})
+		} else {
+			genres = append(genres, models.Genre{GenreName: value})
+		}
+	}
+	return genres
+}
+
+func setInt(field *int, name string, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%s: %q is not a number", name, value)
+	}
+	*field = n
+	return nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("release_date: %q is not a date (YYYY-MM-DD)", value)
+	}
+	return date.UTC(), nil
+}
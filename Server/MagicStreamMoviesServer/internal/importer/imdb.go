@@ -0,0 +1,118 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// imdbNull marks a missing value in the IMDb datasets.
+const imdbNull = `\N`
+
+// imdbTitleTypes maps the IMDb title types that are imported to content types.
+var imdbTitleTypes = map[string]string{
+	"movie":        models.ContentTypeMovie,
+	"tvMovie":      models.ContentTypeMovie,
+	"tvSeries":     models.ContentTypeSeries,
+	"tvMiniSeries": models.ContentTypeSeries,
+}
+
+var imdbColumns = []string{"tconst", "titleType", "primaryTitle", "startYear", "runtimeMinutes", "genres"}
+
+// imdbReader reads title.basics.tsv. It carries the title, content type, release year,
+// runtime and genre names; posters, trailers and rankings have to come from elsewhere, so new
+// titles only pass validation once those are imported too.
+type imdbReader struct {
+	scanner *bufio.Scanner
+	line    int
+	columns map[string]int
+}
+
+func newIMDbReader(r io.Reader) (*imdbReader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("empty tsv file")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range strings.Split(strings.TrimRight(scanner.Text(), "\r"), "\t") {
+		columns[name] = i
+	}
+	for _, name := range imdbColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("tsv header has no %s column, expected IMDb title.basics.tsv", name)
+		}
+	}
+	return &imdbReader{scanner: scanner, line: 1, columns: columns}, nil
+}
+
+func (r *imdbReader) Next() (*Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimRight(r.scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		// IMDb values are not quoted, so a plain split is used instead of encoding/csv.
+		fields := strings.Split(text, "\t")
+		if len(fields) < len(r.columns) {
+			return nil, &RowError{Line: r.line, Err: fmt.Errorf("expected %d columns, got %d", len(r.columns), len(fields))}
+		}
+		value := func(name string) string {
+			if v := fields[r.columns[name]]; v != imdbNull {
+				return v
+			}
+			return ""
+		}
+
+		record := &Record{Line: r.line, ImdbID: value("tconst")}
+		contentType, ok := imdbTitleTypes[value("titleType")]
+		if !ok {
+			record.Skip = true
+			return record, nil
+		}
+		title, year, runtime, genres := value("primaryTitle"), value("startYear"), value("runtimeMinutes"), value("genres")
+		record.Apply = func(movie *models.Movie) error {
+			movie.ContentType = contentType
+			movie.Title = title
+			if year != "" {
+				y, err := strconv.Atoi(year)
+				if err != nil {
+					return fmt.Errorf("startYear: %q is not a year", year)
+				}
+				// Only the year is known; keep a more precise stored date from the same year.
+				if movie.ReleaseDate == nil || movie.ReleaseDate.Year() != y {
+					date := time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+					movie.ReleaseDate = &date
+				}
+			}
+			if runtime != "" {
+				if err := setInt(&movie.Runtime, "runtimeMinutes", runtime); err != nil {
+					return err
+				}
+			}
+			if genres != "" {
+				movie.Genre = nil
+				for _, name := range strings.Split(genres, ",") {
+					movie.Genre = append(movie.Genre, models.Genre{GenreName: name})
+				}
+			}
+			return nil
+		}
+		return record, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,101 @@
+// Package importer reads movies from bulk data files. Records are streamed one at a time, so
+// files of any size can be imported without loading them into memory.
+//
+// A record does not hold a complete movie: it patches the fields its source carries onto the
+// stored movie, or onto an empty one for new titles, so partial datasets such as IMDb's
+// title.basics.tsv can update titles already in the catalog.
+package importer
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// Supported formats.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	// FormatIMDb is the tab separated title.basics.tsv file of the IMDb datasets.
+	FormatIMDb = "imdb"
+)
+
+var ErrUnknownFormat = errors.New("unknown import format, expected csv, jsonl or imdb")
+
+// Record is one row of an import file.
+type Record struct {
+	// Line is the line the record starts on, for error reports.
+	Line   int
+	ImdbID string
+	// Skip is set for rows the format does not import, such as TV episodes in IMDb datasets.
+	Skip bool
+	// Apply writes the fields carried by the record over movie. Genres may be given by name
+	// only, leaving their genre_id zero for the caller to resolve.
+	Apply func(movie *models.Movie) error
+}
+
+// RowError is a row that could not be parsed. The rows after it can still be read.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Reader streams the records of an import file.
+type Reader interface {
+	// Next returns the next record, or io.EOF after the last one. A malformed row is reported
+	// as a *RowError; any other error ends the import.
+	Next() (*Record, error)
+}
+
+// Open returns a reader for r. An empty format is inferred from the file name, and names
+// ending in .gz are decompressed.
+func Open(r io.Reader, name string, format string) (Reader, error) {
+	name = strings.ToLower(name)
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		r = gz
+		name = strings.TrimSuffix(name, ".gz")
+	}
+	if format == "" {
+		format = formatFromName(name)
+	}
+
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatJSONL:
+		return newJSONLReader(r), nil
+	case FormatIMDb:
+		return newIMDbReader(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func formatFromName(name string) string {
+	switch filepath.Ext(name) {
+	case ".csv":
+		return FormatCSV
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	case ".tsv":
+		return FormatIMDb
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// readAll applies every record to an empty movie and collects the row errors.
+func readAll(t *testing.T, reader Reader) ([]models.Movie, []*Record, []*RowError) {
+	t.Helper()
+	var movies []models.Movie
+	var records []*Record
+	var rowErrs []*RowError
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return movies, records, rowErrs
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrs = append(rowErrs, rowErr)
+			continue
+		}
+		if !assert.NoError(t, err) {
+			return movies, records, rowErrs
+		}
+		records = append(records, record)
+		if record.Skip {
+			continue
+		}
+		var movie models.Movie
+		assert.NoError(t, record.Apply(&movie))
+		movies = append(movies, movie)
+	}
+}
+
+func TestCSV(t *testing.T) {
+	data := "title,imdb_id,genre,keywords,runtime,release_date\n" +
+		"Arrival,tt2543164,3|Drama,linguistics|aliens,116,2016-11-11\n" +
+		"Broken,tt0000001\n" +
+		"\"Blade Runner, The Final Cut\",tt0083658,,,117,\n"
+
+	reader, err := Open(strings.NewReader(data), "movies.csv", "")
+	assert.NoError(t, err)
+	movies, records, rowErrs := readAll(t, reader)
+
+	assert.Len(t, movies, 2)
+	assert.Equal(t, "tt2543164", records[0].ImdbID)
+	assert.Equal(t, 2, records[0].Line)
+	assert.Equal(t, []models.Genre{{GenreID: 3}, {GenreName: "Drama"}}, movies[0].Genre)
+	assert.Equal(t, []string{"linguistics", "aliens"}, movies[0].Keywords)
+	assert.Equal(t, 116, movies[0].Runtime)
+	assert.Equal(t, time.Date(2016, time.November, 11, 0, 0, 0, 0, time.UTC), *movies[0].ReleaseDate)
+	assert.Equal(t, "Blade Runner, The Final Cut", movies[1].Title)
+	assert.Nil(t, movies[1].ReleaseDate)
+
+	assert.Len(t, rowErrs, 1)
+	assert.Equal(t, 3, rowErrs[0].Line)
+}
+
+func TestCSV_RejectsUnknownColumns(t *testing.T) {
+	_, err := Open(strings.NewReader("imdb_id,director\n"), "movies.csv", "")
+	assert.ErrorContains(t, err, "director")
+
+	_, err = Open(strings.NewReader("title\n"), "movies.csv", "")
+	assert.ErrorContains(t, err, "imdb_id")
+}
+
+func TestCSV_IgnoresExportOnlyColumns(t *testing.T) {
+	data := "imdb_id,title,average_rating,review_count,created_at\n" +
+		"tt2543164,Arrival,4.25,8,2024-01-02T03:04:05Z\n"
+
+	reader, err := Open(strings.NewReader(data), "movies.csv", "")
+	assert.NoError(t, err)
+	movies, _, rowErrs := readAll(t, reader)
+
+	assert.Empty(t, rowErrs)
+	if assert.Len(t, movies, 1) {
+		assert.Equal(t, "Arrival", movies[0].Title)
+		assert.True(t, movies[0].CreatedAt.IsZero())
+	}
+}
+
+func TestCSV_UnescapesFormulas(t *testing.T) {
+	data := "imdb_id,title,keywords\n" +
+		"tt2543164,'=Arrival,'-1|'quoted\n"
+
+	reader, err := Open(strings.NewReader(data), "movies.csv", "")
+	assert.NoError(t, err)
+	movies, _, rowErrs := readAll(t, reader)
+
+	assert.Empty(t, rowErrs)
+	if assert.Len(t, movies, 1) {
+		assert.Equal(t, "=Arrival", movies[0].Title)
+		assert.Equal(t, []string{"-1", "'quoted"}, movies[0].Keywords)
+	}
+}
+
+func TestJSONL_KeepsMissingFields(t *testing.T) {
+	data := `{"imdb_id":"tt2543164","runtime":118}` + "\n\n" + `{"imdb_id":` + "\n" + `{"title":"No ID"}` + "\n"
+
+	reader, err := Open(strings.NewReader(data), "", FormatJSONL)
+	assert.NoError(t, err)
+
+	record, err := reader.Next()
+	assert.NoError(t, err)
+	movie := models.Movie{ImdbID: "tt2543164", Title: "Arrival", Runtime: 116}
+	assert.NoError(t, record.Apply(&movie))
+	assert.Equal(t, "Arrival", movie.Title)
+	assert.Equal(t, 118, movie.Runtime)
+
+	var rowErr *RowError
+	_, err = reader.Next()
+	assert.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 3, rowErr.Line)
+	_, err = reader.Next()
+	assert.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 4, rowErr.Line)
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestIMDb_Gzipped(t *testing.T) {
+	data := "tconst\ttitleType\tprimaryTitle\toriginalTitle\tisAdult\tstartYear\tendYear\truntimeMinutes\tgenres\n" +
+		"tt2543164\tmovie\tArrival\tArrival\t0\t2016\t\\N\t116\tDrama,Sci-Fi\n" +
+		"tt0959621\ttvEpisode\tPilot\tPilot\t0\t2008\t\\N\t58\tCrime,Drama\n" +
+		"tt0903747\ttvSeries\tBreaking Bad\tBreaking Bad\t0\t2008\t2013\t\\N\tCrime,Drama,Thriller\n"
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(data))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+
+	reader, err := Open(&buf, "title.basics.tsv.gz", "")
+	assert.NoError(t, err)
+	movies, records, rowErrs := readAll(t, reader)
+
+	assert.Empty(t, rowErrs)
+	assert.Len(t, records, 3)
+	assert.True(t, records[1].Skip)
+	assert.Len(t, movies, 2)
+	assert.Equal(t, models.ContentTypeMovie, movies[0].ContentType)
+	assert.Equal(t, 2016, movies[0].ReleaseDate.Year())
+	assert.Equal(t, []models.Genre{{GenreName: "Drama"}, {GenreName: "Sci-Fi"}}, movies[0].Genre)
+	assert.Equal(t, models.ContentTypeSeries, movies[1].ContentType)
+	assert.Equal(t, 0, movies[1].Runtime)
+}
+
+func TestOpen_UnknownFormat(t *testing.T) {
+	_, err := Open(strings.NewReader(""), "movies.xml", "")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
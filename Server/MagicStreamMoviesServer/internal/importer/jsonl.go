@@ -0,0 +1,60 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// maxLineSize bounds a single line of a JSON Lines or TSV file.
+const maxLineSize = 1 << 20
+
+// jsonlReader reads one movie JSON object per line. Fields missing from an object keep their
+// stored value.
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newJSONLReader(r io.Reader) *jsonlReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &jsonlReader{scanner: scanner}
+}
+
+func (r *jsonlReader) Next() (*Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+		raw := r.scanner.Bytes()
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		var key struct {
+			ImdbID string `json:"imdb_id"`
+		}
+		if err := json.Unmarshal(raw, &key); err != nil {
+			return nil, &RowError{Line: r.line, Err: err}
+		}
+		if key.ImdbID == "" {
+			return nil, &RowError{Line: r.line, Err: errors.New("imdb_id is empty")}
+		}
+		// The scanner reuses its buffer, so the line is copied for Apply.
+		data := append([]byte(nil), raw...)
+		return &Record{
+			Line:   r.line,
+			ImdbID: key.ImdbID,
+			Apply: func(movie *models.Movie) error {
+				return json.Unmarshal(data, movie)
+			},
+		}, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
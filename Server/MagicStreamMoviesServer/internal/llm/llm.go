@@ -0,0 +1,16 @@
+package llm
+
+import (
+	"errors"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+// New builds an OpenAI chat model.
+func New(apiKey string, model string) (llms.Model, error) {
+	if apiKey == "" {
+		return nil, errors.New("could not read OPEN_API_KEY")
+	}
+	return openai.New(openai.WithToken(apiKey), openai.WithModel(model))
+}
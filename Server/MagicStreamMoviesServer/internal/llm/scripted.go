@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// Scripted is a fake chat model for tests. It answers with its responses in order and streams
+// each one word by word when a streaming function is passed. The messages of every call are
+// kept so tests can check the prompt.
+type Scripted struct {
+	mu        sync.Mutex
+	responses []string
+	calls     [][]llms.MessageContent
+}
+
+func NewScripted(responses ...string) *Scripted {
+	return &Scripted{responses: responses}
+}
+
+func (s *Scripted) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	s.mu.Lock()
+	if len(s.calls) >= len(s.responses) {
+		s.mu.Unlock()
+		return nil, errors.New("scripted llm has no response left")
+	}
+	response := s.responses[len(s.calls)]
+	s.calls = append(s.calls, messages)
+	s.mu.Unlock()
+
+	opts := llms.CallOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.StreamingFunc != nil {
+		for _, chunk := range strings.SplitAfter(response, " ") {
+			if err := opts.StreamingFunc(ctx, []byte(chunk)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: response}}}, nil
+}
+
+func (s *Scripted) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, s, prompt, options...)
+}
+
+// Calls returns the messages sent with every call so far.
+func (s *Scripted) Calls() [][]llms.MessageContent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([][]llms.MessageContent(nil), s.calls...)
+}
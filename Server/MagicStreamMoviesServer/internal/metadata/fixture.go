@@ -0,0 +1,76 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// imdbIDPattern keeps lookups inside the fixtures directory.
+var imdbIDPattern = regexp.MustCompile(`^tt[0-9]+$`)
+
+type fixtureProvider struct {
+	dir string
+}
+
+// NewFixtureProvider reads title details from <dir>/<imdb_id>.json files, for offline
+// development and tests. A file looks like
+//
+//	{"title": "Arrival", "poster_url": "https://...", "genres": ["Drama", "Sci-Fi"],
+//	 "runtime": 116, "synopsis": "...", "release_date": "2016-11-11"}
+func NewFixtureProvider(dir string) MetadataProvider {
+	return &fixtureProvider{dir: dir}
+}
+
+type fixture struct {
+	Title       string   `json:"title"`
+	PosterURL   string   `json:"poster_url"`
+	Genres      []string `json:"genres"`
+	Runtime     int      `json:"runtime"`
+	Synopsis    string   `json:"synopsis"`
+	ReleaseDate string   `json:"release_date"`
+}
+
+func (p *fixtureProvider) Lookup(ctx context.Context, imdbID string) (*Details, error) {
+	if !imdbIDPattern.MatchString(imdbID) {
+		return nil, ErrNotFound
+	}
+	data, err := os.ReadFile(filepath.Join(p.dir, imdbID+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("fixture %s: %w", imdbID, err)
+	}
+	details := &Details{
+		ImdbID:    imdbID,
+		Title:     f.Title,
+		PosterURL: f.PosterURL,
+		Genres:    f.Genres,
+		Runtime:   f.Runtime,
+		Synopsis:  f.Synopsis,
+	}
+	if f.ReleaseDate != "" {
+		date, err := time.Parse(time.DateOnly, f.ReleaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("fixture %s: %w", imdbID, err)
+		}
+		details.ReleaseDate = &date
+	}
+	return details, nil
+}
+
+func (p *fixtureProvider) Name() string {
+	return ProviderLocal
+}
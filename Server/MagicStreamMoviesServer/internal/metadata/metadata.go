@@ -0,0 +1,88 @@
+// Package metadata looks up catalog details of a title by its IMDb ID from an external
+// database, so admins do not have to type them in by hand.
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+)
+
+const (
+	ProviderOMDb  = "omdb"
+	ProviderTMDb  = "tmdb"
+	ProviderLocal = "local"
+)
+
+// ErrNotFound is returned when the provider does not know the title.
+var ErrNotFound = errors.New("title not found")
+
+// Details are the fields a provider knows about a title. Empty fields are unknown.
+type Details struct {
+	ImdbID    string
+	Title     string
+	PosterURL string
+	// Genres are genre names as the provider spells them.
+	Genres      []string
+	Runtime     int // minutes
+	Synopsis    string
+	ReleaseDate *time.Time
+}
+
+// MetadataProvider fetches title details by IMDb ID.
+type MetadataProvider interface {
+	Lookup(ctx context.Context, imdbID string) (*Details, error)
+	// Name identifies the provider in logs and responses.
+	Name() string
+}
+
+// New builds the provider selected by cfg.MetadataProvider.
+func New(cfg *config.Config) (MetadataProvider, error) {
+	switch cfg.MetadataProvider {
+	case ProviderOMDb:
+		return NewOMDbProvider(cfg.MetadataBaseURL, cfg.MetadataAPIKey)
+	case ProviderTMDb:
+		return NewTMDbProvider(cfg.MetadataBaseURL, cfg.MetadataAPIKey)
+	case ProviderLocal:
+		return NewFixtureProvider(cfg.MetadataFixturesDir), nil
+	default:
+		return nil, fmt.Errorf("unknown metadata provider %q", cfg.MetadataProvider)
+	}
+}
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// maxResponseBytes caps the provider responses read. A title's details are a few kilobytes.
+const maxResponseBytes = 2 << 20
+
+// getJSON decodes the response to a GET request into v. A 404 is reported as ErrNotFound.
+func getJSON(ctx context.Context, url string, header http.Header, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(v)
+}
@@ -0,0 +1,135 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOMDbProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.URL.Query().Get("apikey"))
+		if r.URL.Query().Get("i") != "tt2543164" {
+			w.Write([]byte(`{"Response":"False","Error":"Incorrect IMDb ID."}`))
+			return
+		}
+		w.Write([]byte(`{"Response":"True","Title":"Arrival","Released":"11 Nov 2016","Runtime":"116 min",
+			"Genre":"Drama, Mystery, Sci-Fi","Plot":"A linguist works with the military.","Poster":"N/A"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOMDbProvider(server.URL, "secret")
+	assert.NoError(t, err)
+
+	details, err := provider.Lookup(context.Background(), "tt2543164")
+	assert.NoError(t, err)
+	assert.Equal(t, "Arrival", details.Title)
+	assert.Equal(t, []string{"Drama", "Mystery", "Sci-Fi"}, details.Genres)
+	assert.Equal(t, 116, details.Runtime)
+	assert.Equal(t, "A linguist works with the military.", details.Synopsis)
+	assert.Empty(t, details.PosterURL)
+	assert.Equal(t, time.Date(2016, time.November, 11, 0, 0, 0, 0, time.UTC), *details.ReleaseDate)
+
+	_, err = provider.Lookup(context.Background(), "tt0000000")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	_, err = NewOMDbProvider(server.URL, "")
+	assert.Error(t, err)
+}
+
+func TestOMDbProvider_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Response":"False","Error":"Movie not found!"}`))
+	}))
+	defer server.Close()
+
+	provider, _ := NewOMDbProvider(server.URL, "secret")
+	_, err := provider.Lookup(context.Background(), "tt0000000")
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestOMDbProvider_LimitsResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Response":"True","Title":"Arrival","Plot":"`))
+		w.Write([]byte(strings.Repeat("a", maxResponseBytes)))
+		w.Write([]byte(`"}`))
+	}))
+	defer server.Close()
+
+	provider, _ := NewOMDbProvider(server.URL, "secret")
+	_, err := provider.Lookup(context.Background(), "tt2543164")
+
+	assert.Error(t, err)
+}
+
+func TestTMDbProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/find/tt2543164":
+			assert.Equal(t, "imdb_id", r.URL.Query().Get("external_source"))
+			w.Write([]byte(`{"movie_results":[{"id":329865}],"tv_results":[]}`))
+		case "/find/tt0903747":
+			w.Write([]byte(`{"movie_results":[],"tv_results":[{"id":1396}]}`))
+		case "/find/tt0000000":
+			w.Write([]byte(`{"movie_results":[],"tv_results":[]}`))
+		case "/movie/329865":
+			w.Write([]byte(`{"title":"Arrival","overview":"Aliens land.","poster_path":"/arrival.jpg",
+				"release_date":"2016-11-10","runtime":116,"genres":[{"id":18,"name":"Drama"}]}`))
+		case "/tv/1396":
+			w.Write([]byte(`{"name":"Breaking Bad","first_air_date":"2008-01-20","episode_run_time":[47],"genres":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewTMDbProvider(server.URL, "token")
+	assert.NoError(t, err)
+
+	details, err := provider.Lookup(context.Background(), "tt2543164")
+	assert.NoError(t, err)
+	assert.Equal(t, "Arrival", details.Title)
+	assert.Equal(t, "https://image.tmdb.org/t/p/w500/arrival.jpg", details.PosterURL)
+	assert.Equal(t, []string{"Drama"}, details.Genres)
+	assert.Equal(t, 116, details.Runtime)
+	assert.Equal(t, time.Date(2016, time.November, 10, 0, 0, 0, 0, time.UTC), *details.ReleaseDate)
+
+	series, err := provider.Lookup(context.Background(), "tt0903747")
+	assert.NoError(t, err)
+	assert.Equal(t, "Breaking Bad", series.Title)
+	assert.Equal(t, 47, series.Runtime)
+	assert.Equal(t, 2008, series.ReleaseDate.Year())
+
+	_, err = provider.Lookup(context.Background(), "tt0000000")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestFixtureProvider(t *testing.T) {
+	dir := t.TempDir()
+	fixture := `{"title":"Arrival","poster_url":"https://example.com/arrival.jpg","genres":["Drama"],"runtime":116,"release_date":"2016-11-11"}`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tt2543164.json"), []byte(fixture), 0o644))
+	provider := NewFixtureProvider(dir)
+
+	details, err := provider.Lookup(context.Background(), "tt2543164")
+	assert.NoError(t, err)
+	assert.Equal(t, "tt2543164", details.ImdbID)
+	assert.Equal(t, "Arrival", details.Title)
+	assert.Equal(t, "https://example.com/arrival.jpg", details.PosterURL)
+	assert.Equal(t, 2016, details.ReleaseDate.Year())
+
+	_, err = provider.Lookup(context.Background(), "tt0000000")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	_, err = provider.Lookup(context.Background(), "../tt2543164")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
@@ -0,0 +1,88 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const omdbBaseURL = "https://www.omdbapi.com/"
+
+type omdbProvider struct {
+	baseURL string
+	apiKey  string
+}
+
+// NewOMDbProvider looks titles up in the OMDb API. An empty baseURL selects the public API.
+func NewOMDbProvider(baseURL string, apiKey string) (MetadataProvider, error) {
+	if apiKey == "" {
+		return nil, errors.New("could not read METADATA_API_KEY")
+	}
+	if baseURL == "" {
+		baseURL = omdbBaseURL
+	}
+	return &omdbProvider{baseURL: baseURL, apiKey: apiKey}, nil
+}
+
+// omdbTitle is the OMDb response. Unknown values are "N/A".
+type omdbTitle struct {
+	Response string `json:"Response"`
+	Error    string `json:"Error"`
+	ImdbID   string `json:"imdbID"`
+	Title    string `json:"Title"`
+	Released string `json:"Released"`
+	Runtime  string `json:"Runtime"`
+	Genre    string `json:"Genre"`
+	Plot     string `json:"Plot"`
+	Poster   string `json:"Poster"`
+}
+
+func (p *omdbProvider) Lookup(ctx context.Context, imdbID string) (*Details, error) {
+	query := url.Values{"i": {imdbID}, "apikey": {p.apiKey}, "plot": {"short"}}
+	var title omdbTitle
+	if err := getJSON(ctx, p.baseURL+"?"+query.Encode(), nil, &title); err != nil {
+		return nil, fmt.Errorf("omdb: %w", err)
+	}
+	// OMDb answers with 200 and Response "False" for unknown titles.
+	if title.Response != "True" {
+		if strings.Contains(strings.ToLower(title.Error), "not found") {
+			return nil, fmt.Errorf("omdb: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("omdb: %s", title.Error)
+	}
+
+	details := &Details{
+		ImdbID:    imdbID,
+		Title:     omdbValue(title.Title),
+		PosterURL: omdbValue(title.Poster),
+		Synopsis:  omdbValue(title.Plot),
+	}
+	for _, genre := range strings.Split(omdbValue(title.Genre), ",") {
+		if genre = strings.TrimSpace(genre); genre != "" {
+			details.Genres = append(details.Genres, genre)
+		}
+	}
+	// Runtimes look like "116 min".
+	if minutes, err := strconv.Atoi(strings.TrimSuffix(omdbValue(title.Runtime), " min")); err == nil && minutes > 0 {
+		details.Runtime = minutes
+	}
+	if released, err := time.Parse("02 Jan 2006", omdbValue(title.Released)); err == nil {
+		details.ReleaseDate = &released
+	}
+	return details, nil
+}
+
+func (p *omdbProvider) Name() string {
+	return ProviderOMDb
+}
+
+func omdbValue(value string) string {
+	if value == "N/A" {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,113 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	tmdbBaseURL = "https://api.themoviedb.org/3"
+	// tmdbImageURL serves posters at a width that suits the catalog.
+	tmdbImageURL = "https://image.tmdb.org/t/p/w500"
+)
+
+type tmdbProvider struct {
+	baseURL string
+	header  http.Header
+}
+
+// NewTMDbProvider looks titles up in the TMDb API with an API read access token. An empty
+// baseURL selects the public API.
+func NewTMDbProvider(baseURL string, token string) (MetadataProvider, error) {
+	if token == "" {
+		return nil, errors.New("could not read METADATA_API_KEY")
+	}
+	if baseURL == "" {
+		baseURL = tmdbBaseURL
+	}
+	return &tmdbProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		header:  http.Header{"Authorization": {"Bearer " + token}},
+	}, nil
+}
+
+type tmdbFindResult struct {
+	MovieResults []struct {
+		ID int `json:"id"`
+	} `json:"movie_results"`
+	TVResults []struct {
+		ID int `json:"id"`
+	} `json:"tv_results"`
+}
+
+// tmdbTitle holds the fields of both the movie and the TV details responses.
+type tmdbTitle struct {
+	Title          string `json:"title"`
+	Name           string `json:"name"`
+	Overview       string `json:"overview"`
+	PosterPath     string `json:"poster_path"`
+	ReleaseDate    string `json:"release_date"`
+	FirstAirDate   string `json:"first_air_date"`
+	Runtime        int    `json:"runtime"`
+	EpisodeRunTime []int  `json:"episode_run_time"`
+	Genres         []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+}
+
+func (p *tmdbProvider) Lookup(ctx context.Context, imdbID string) (*Details, error) {
+	// TMDb has its own IDs, so the IMDb ID is resolved first.
+	var found tmdbFindResult
+	findURL := fmt.Sprintf("%s/find/%s?external_source=imdb_id", p.baseURL, url.PathEscape(imdbID))
+	if err := getJSON(ctx, findURL, p.header, &found); err != nil {
+		return nil, fmt.Errorf("tmdb: %w", err)
+	}
+	var detailsURL string
+	switch {
+	case len(found.MovieResults) > 0:
+		detailsURL = fmt.Sprintf("%s/movie/%d", p.baseURL, found.MovieResults[0].ID)
+	case len(found.TVResults) > 0:
+		detailsURL = fmt.Sprintf("%s/tv/%d", p.baseURL, found.TVResults[0].ID)
+	default:
+		return nil, fmt.Errorf("tmdb: %w", ErrNotFound)
+	}
+
+	var title tmdbTitle
+	if err := getJSON(ctx, detailsURL, p.header, &title); err != nil {
+		return nil, fmt.Errorf("tmdb: %w", err)
+	}
+
+	details := &Details{
+		ImdbID:   imdbID,
+		Title:    title.Title,
+		Synopsis: title.Overview,
+		Runtime:  title.Runtime,
+	}
+	released := title.ReleaseDate
+	if details.Title == "" {
+		details.Title = title.Name
+		released = title.FirstAirDate
+		if len(title.EpisodeRunTime) > 0 {
+			details.Runtime = title.EpisodeRunTime[0]
+		}
+	}
+	if title.PosterPath != "" {
+		details.PosterURL = tmdbImageURL + title.PosterPath
+	}
+	for _, genre := range title.Genres {
+		details.Genres = append(details.Genres, genre.Name)
+	}
+	if date, err := time.Parse(time.DateOnly, released); err == nil {
+		details.ReleaseDate = &date
+	}
+	return details, nil
+}
+
+func (p *tmdbProvider) Name() string {
+	return ProviderTMDb
+}
@@ -50,6 +50,20 @@ func NewAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 	}
 }
 
+// NewOptionalAuthMiddleware authenticates the request when an Authorization header is present
+// and lets anonymous requests through untouched. A token that is sent but invalid is still
+// rejected so clients know to refresh it.
+func NewOptionalAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+	required := NewAuthMiddleware(cfg)
+	return func(c *gin.Context) {
+		if c.Request.Header.Get("Authorization") == "" {
+			c.Next()
+			return
+		}
+		required(c)
+	}
+}
+
 func GetAccessToken(c *gin.Context) (string, error) {
 	authHeader := c.Request.Header.Get("Authorization")
 	if authHeader == "" {
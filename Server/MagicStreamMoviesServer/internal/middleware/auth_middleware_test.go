@@ -129,3 +129,46 @@ func TestGetRoleFromContext(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "ADMIN", role)
 }
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		SecretKey: "secret",
+	}
+
+	token, _, err := utils.GenerateAllTokens("test@example.com", "First", "Last", "USER", "user123", "secret", "refreshsecret")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+		expectUser     bool
+	}{
+		{name: "Anonymous", header: "", expectedStatus: http.StatusOK, expectUser: false},
+		{name: "Valid Token", header: "Bearer " + token, expectedStatus: http.StatusOK, expectUser: true},
+		{name: "Invalid Token", header: "Bearer invalid-token", expectedStatus: http.StatusUnauthorized, expectUser: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			req := httptest.NewRequest("GET", "/home", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			c.Request = req
+
+			NewOptionalAuthMiddleware(cfg)(c)
+			if !c.IsAborted() {
+				c.Status(http.StatusOK)
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			_, err := GetUserIdFromContext(c)
+			assert.Equal(t, tt.expectUser, err == nil)
+		})
+	}
+}
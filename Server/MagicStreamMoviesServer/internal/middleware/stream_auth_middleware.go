@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/urlsign"
+)
+
+// NewStreamAuthMiddleware lets stream requests through on a signed URL for the title in the
+// imdb_id path parameter, since media players often cannot send an Authorization header.
+// Requests without a signature need a token like any protected route. A nil signer only
+// accepts tokens.
+func NewStreamAuthMiddleware(cfg *config.Config, signer *urlsign.Signer) gin.HandlerFunc {
+	required := NewAuthMiddleware(cfg)
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+		if !urlsign.Signed(query) {
+			required(c)
+			return
+		}
+		if signer == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: signed URLs are not enabled"})
+			c.Abort()
+			return
+		}
+
+		userID, err := signer.Verify(query, c.Param("imdb_id"), time.Now())
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Set(ContextKeyUserID, userID)
+		c.Next()
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/urlsign"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{SecretKey: "secret"}
+	signer := urlsign.NewSigner(urlsign.Key{ID: "k1", Secret: []byte(strings.Repeat("s", 32))})
+
+	token, _, err := utils.GenerateAllTokens("test@example.com", "First", "Last", "USER", "user123", "secret", "refreshsecret")
+	assert.NoError(t, err)
+	signed := signer.Sign("user456", "tt1", time.Now().Add(time.Hour)).Encode()
+	expired := signer.Sign("user456", "tt1", time.Now().Add(-time.Minute)).Encode()
+
+	tests := []struct {
+		name           string
+		signer         *urlsign.Signer
+		path           string
+		token          string
+		expectedStatus int
+		expectedUserID string
+	}{
+		{"Signed URL", signer, "/stream/tt1?" + signed, "", http.StatusOK, "user456"},
+		{"Signed URL for HLS file", signer, "/stream/tt1/720p/seg001.ts?" + signed, "", http.StatusOK, "user456"},
+		{"Signed URL for another title", signer, "/stream/tt2?" + signed, "", http.StatusUnauthorized, ""},
+		{"Expired URL", signer, "/stream/tt1?" + expired, "", http.StatusUnauthorized, ""},
+		{"Signing disabled", nil, "/stream/tt1?" + signed, "", http.StatusUnauthorized, ""},
+		{"Token", signer, "/stream/tt1", "Bearer " + token, http.StatusOK, "user123"},
+		{"Neither", signer, "/stream/tt1", "", http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			var userID any
+			handler := func(c *gin.Context) {
+				userID, _ = c.Get(ContextKeyUserID)
+				c.Status(http.StatusOK)
+			}
+			streams := router.Group("/stream", NewStreamAuthMiddleware(cfg, tt.signer))
+			streams.GET("/:imdb_id", handler)
+			streams.GET("/:imdb_id/*path", handler)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedUserID != "" {
+				assert.Equal(t, tt.expectedUserID, userID)
+			}
+		})
+	}
+}
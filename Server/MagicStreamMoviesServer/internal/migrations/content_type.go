@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// contentTypeBackfill marks every title created before series existed as a movie, so the type
+// filter and facet count them.
+var contentTypeBackfill = Migration{
+	ID:          "0002_content_type_backfill",
+	Description: "Backfill content_type on movies",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection("movies").UpdateMany(ctx,
+			bson.M{"content_type": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"content_type": models.ContentTypeMovie}},
+		)
+		return err
+	},
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// episodeIndexes keeps the IMDb IDs of episodes unique, since self-hosted episodes stream under
+// them. Episodes without an ID store an empty one and are left out.
+var episodeIndexes = Migration{
+	ID:          "0005_episode_indexes",
+	Description: "Index episodes by their own IMDb ID",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection("episodes").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "imdb_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"imdb_id": bson.M{"$gt": ""}}),
+		})
+		return err
+	},
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// eventRetention is how long analytics events are kept: a day past the longest trending
+// window, after which the trending job never reads them again.
+const eventRetention = 31 * 24 * time.Hour
+
+// eventIndexes expires old analytics events and indexes the lookup that drops repeated events.
+var eventIndexes = Migration{
+	ID:          "0004_event_indexes",
+	Description: "Expire old events and index events by user, movie and type",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		_, err := db.Collection("events").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(int32(eventRetention.Seconds())),
+			},
+			{
+				Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "imdb_id", Value: 1}, {Key: "type", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+		})
+		return err
+	},
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// genresSeed creates the genres collection from the genres embedded in movies and users'
+// favourites. A genre_id seen under several names keeps the first name found in movies;
+// duplicates under different IDs are kept and can be merged by an admin afterwards.
+var genresSeed = Migration{
+	ID:          "0003_genres_seed",
+	Description: "Create the genres collection from the genres embedded in movies and users",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		genres := db.Collection("genres")
+		if _, err := genres.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "genre_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}); err != nil {
+			return err
+		}
+
+		sources := []struct {
+			collection string
+			field      string
+		}{
+			{"movies", "genre"},
+			{"users", "favourite_genres"},
+		}
+		for _, source := range sources {
+			cursor, err := db.Collection(source.collection).Aggregate(ctx, mongo.Pipeline{
+				{{Key: "$unwind", Value: "$" + source.field}},
+				{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+				{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: "$" + source.field + ".genre_id"},
+					{Key: "genre_name", Value: bson.D{{Key: "$first", Value: "$" + source.field + ".genre_name"}}},
+				}}},
+			})
+			if err != nil {
+				return err
+			}
+			var found []struct {
+				GenreID   int    `bson:"_id"`
+				GenreName string `bson:"genre_name"`
+			}
+			if err := cursor.All(ctx, &found); err != nil {
+				return err
+			}
+
+			for _, genre := range found {
+				if genre.GenreID == 0 || genre.GenreName == "" {
+					continue
+				}
+				_, err := genres.UpdateOne(ctx,
+					bson.M{"genre_id": genre.GenreID},
+					bson.M{"$setOnInsert": models.Genre{GenreID: genre.GenreID, GenreName: genre.GenreName}},
+					options.UpdateOne().SetUpsert(true),
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	},
+}
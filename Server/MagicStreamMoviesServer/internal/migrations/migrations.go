@@ -0,0 +1,79 @@
+// Package migrations holds one-off changes to existing documents. Each migration runs once per
+// database; applied migrations are recorded in the migrations collection. Migrations must be
+// safe to re-run, since a failure halfway leaves them unrecorded.
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type Migration struct {
+	// ID orders migrations and identifies them in the migrations collection.
+	ID          string
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// All lists every migration in the order it must be applied.
+var All = []Migration{
+	movieMetadataBackfill,
+	contentTypeBackfill,
+	genresSeed,
+	eventIndexes,
+	episodeIndexes,
+}
+
+type record struct {
+	ID        string    `bson:"_id"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// Pending returns the migrations that have not been applied to db yet.
+func Pending(ctx context.Context, db *mongo.Database, migrations []Migration) ([]Migration, error) {
+	cursor, err := db.Collection("migrations").Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]bool, len(records))
+	for _, r := range records {
+		applied[r.ID] = true
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if !applied[m.ID] {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Run applies the pending migrations in order and returns the IDs of those it applied. It stops
+// at the first failure.
+func Run(ctx context.Context, db *mongo.Database, migrations []Migration) ([]string, error) {
+	pending, err := Pending(ctx, db, migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []string{}
+	for _, m := range pending {
+		if err := m.Up(ctx, db); err != nil {
+			return applied, fmt.Errorf("migration %s: %w", m.ID, err)
+		}
+		if _, err := db.Collection("migrations").InsertOne(ctx, record{ID: m.ID, AppliedAt: time.Now().UTC()}); err != nil {
+			return applied, err
+		}
+		applied = append(applied, m.ID)
+	}
+	return applied, nil
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// movieMetadataBackfill gives movies created before the richer movie model its timestamps and
+// a maturity rating. created_at is recovered from the ObjectID, which embeds the insert time.
+var movieMetadataBackfill = Migration{
+	ID:          "0001_movie_metadata_backfill",
+	Description: "Backfill created_at, updated_at and maturity_rating on movies",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		movies := db.Collection("movies")
+
+		steps := []struct {
+			filter bson.M
+			update any
+		}{
+			{
+				filter: bson.M{"created_at": bson.M{"$exists": false}},
+				update: mongo.Pipeline{{{Key: "$set", Value: bson.M{"created_at": bson.M{"$toDate": "$_id"}}}}},
+			},
+			{
+				filter: bson.M{"updated_at": bson.M{"$exists": false}},
+				update: mongo.Pipeline{{{Key: "$set", Value: bson.M{"updated_at": "$created_at"}}}},
+			},
+			{
+				filter: bson.M{"maturity_rating": bson.M{"$exists": false}},
+				update: bson.M{"$set": bson.M{"maturity_rating": models.MaturityNR}},
+			},
+		}
+		for _, step := range steps {
+			if _, err := movies.UpdateMany(ctx, step.filter, step.update); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockActivityRepository) UpsertWatchHistory(ctx context.Context, entry models.WatchHistory) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) GetWatchHistory(ctx context.Context, userId string) ([]models.WatchHistory, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WatchHistory), args.Error(1)
+}
+
+func (m *MockActivityRepository) UpsertReview(ctx context.Context, review models.Review) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) GetUserReviews(ctx context.Context, userId string) ([]models.Review, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Review), args.Error(1)
+}
+
+func (m *MockActivityRepository) GetSeenMovieIDs(ctx context.Context, userId string) ([]string, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockActivityRepository) GetAllWatchHistory(ctx context.Context) ([]models.WatchHistory, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.WatchHistory), args.Error(1)
+}
+
+func (m *MockActivityRepository) GetAllReviews(ctx context.Context) ([]models.Review, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Review), args.Error(1)
+}
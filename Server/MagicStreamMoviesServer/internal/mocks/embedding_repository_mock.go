@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEmbeddingRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmbeddingRepository) UpsertEmbeddings(ctx context.Context, embeddings []models.MovieEmbedding) error {
+	args := m.Called(ctx, embeddings)
+	return args.Error(0)
+}
+
+func (m *MockEmbeddingRepository) GetEmbeddings(ctx context.Context, model string) ([]models.MovieEmbedding, error) {
+	args := m.Called(ctx, model)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MovieEmbedding), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventRepository) InsertEvent(ctx context.Context, event models.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) InsertEventOnce(ctx context.Context, event models.Event, since time.Time) (bool, error) {
+	args := m.Called(ctx, event, since)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockEventRepository) AggregateTrendingScores(ctx context.Context, since time.Time, now time.Time, halfLife time.Duration, weights map[string]float64) ([]models.TrendingScore, error) {
+	args := m.Called(ctx, since, now, halfLife, weights)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TrendingScore), args.Error(1)
+}
+
+func (m *MockEventRepository) ReplaceTrendingScores(ctx context.Context, window string, scores []models.TrendingScore, computedAt time.Time) error {
+	args := m.Called(ctx, window, scores, computedAt)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) GetTrendingScores(ctx context.Context, window string, genre string, limit int64) ([]models.TrendingScore, error) {
+	args := m.Called(ctx, window, genre, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TrendingScore), args.Error(1)
+}
@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGenreRepository struct {
+	mock.Mock
+}
+
+func (m *MockGenreRepository) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Genre), args.Error(1)
+}
+
+func (m *MockGenreRepository) GetGenre(ctx context.Context, id int) (*models.Genre, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Genre), args.Error(1)
+}
+
+func (m *MockGenreRepository) GetGenresByIDs(ctx context.Context, ids []int) ([]models.Genre, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Genre), args.Error(1)
+}
+
+func (m *MockGenreRepository) GetGenreByName(ctx context.Context, name string) (*models.Genre, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Genre), args.Error(1)
+}
+
+func (m *MockGenreRepository) CreateGenre(ctx context.Context, name string) (*models.Genre, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Genre), args.Error(1)
+}
+
+func (m *MockGenreRepository) UpdateGenre(ctx context.Context, genre models.Genre) error {
+	args := m.Called(ctx, genre)
+	return args.Error(0)
+}
+
+func (m *MockGenreRepository) DeleteGenre(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
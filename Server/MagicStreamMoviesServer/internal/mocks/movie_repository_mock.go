@@ -28,6 +28,14 @@ func (m *MockMovieRepository) GetMovie(ctx context.Context, imdbID string) (*mod
 	return args.Get(0).(*models.Movie), args.Error(1)
 }
 
+func (m *MockMovieRepository) GetMoviesByIDs(ctx context.Context, imdbIDs []string) ([]models.Movie, error) {
+	args := m.Called(ctx, imdbIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Movie), args.Error(1)
+}
+
 func (m *MockMovieRepository) CreateMovie(ctx context.Context, movie models.Movie) (*mongo.InsertOneResult, error) {
 	args := m.Called(ctx, movie)
 	if args.Get(0) == nil {
@@ -52,18 +60,140 @@ func (m *MockMovieRepository) GetRankings(ctx context.Context) ([]models.Ranking
 	return args.Get(0).([]models.Ranking), args.Error(1)
 }
 
-func (m *MockMovieRepository) GetRecommendedMovies(ctx context.Context, genres []string, limit int64) ([]models.Movie, error) {
-	args := m.Called(ctx, genres, limit)
+func (m *MockMovieRepository) GetRecommendedMovies(ctx context.Context, genres []string, excludeIDs []string, skip int64, limit int64) ([]models.Movie, error) {
+	args := m.Called(ctx, genres, excludeIDs, skip, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.Movie), args.Error(1)
 }
 
-func (m *MockMovieRepository) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
-	args := m.Called(ctx)
+func (m *MockMovieRepository) GetNewestMovies(ctx context.Context, skip int64, limit int64) ([]models.Movie, error) {
+	args := m.Called(ctx, skip, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Movie), args.Error(1)
+}
+
+func (m *MockMovieRepository) FilterMovies(ctx context.Context, imdbIDs []string, filters models.MovieFilters) ([]models.Movie, error) {
+	args := m.Called(ctx, imdbIDs, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Movie), args.Error(1)
+}
+
+func (m *MockMovieRepository) GetFacets(ctx context.Context, imdbIDs []string, filters models.MovieFilters) (*models.Facets, error) {
+	args := m.Called(ctx, imdbIDs, filters)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Genre), args.Error(1)
+	return args.Get(0).(*models.Facets), args.Error(1)
+}
+
+func (m *MockMovieRepository) SetMovieMetadata(ctx context.Context, movie models.Movie) error {
+	args := m.Called(ctx, movie)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) SetMoviePoster(ctx context.Context, imdbID string, posterPath string, image models.ImageSet) error {
+	args := m.Called(ctx, imdbID, posterPath, image)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) SetMovieStream(ctx context.Context, imdbID string, source *models.StreamSource) error {
+	args := m.Called(ctx, imdbID, source)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) SaveMovieSubtitleTrack(ctx context.Context, imdbID string, track models.SubtitleTrack) (*models.SubtitleTrack, error) {
+	args := m.Called(ctx, imdbID, track)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SubtitleTrack), args.Error(1)
+}
+
+func (m *MockMovieRepository) UpdateMovieSubtitleTrack(ctx context.Context, imdbID string, lang string, label *string, isDefault *bool) error {
+	args := m.Called(ctx, imdbID, lang, label, isDefault)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) DeleteMovieSubtitleTrack(ctx context.Context, imdbID string, lang string) (*models.SubtitleTrack, error) {
+	args := m.Called(ctx, imdbID, lang)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SubtitleTrack), args.Error(1)
+}
+
+func (m *MockMovieRepository) AddMovieBackdrop(ctx context.Context, imdbID string, backdropPath string, image models.ImageSet, limit int) error {
+	args := m.Called(ctx, imdbID, backdropPath, image, limit)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) SetMovieDraft(ctx context.Context, imdbID string, draft models.MovieDraft) error {
+	args := m.Called(ctx, imdbID, draft)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) PublishMovieDraft(ctx context.Context, imdbID string, draft models.MovieDraft) error {
+	args := m.Called(ctx, imdbID, draft)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) ClearMovieDraft(ctx context.Context, imdbID string) error {
+	args := m.Called(ctx, imdbID)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) SetMovieCreditNames(ctx context.Context, imdbID string, names []string) error {
+	args := m.Called(ctx, imdbID, names)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) SetMovieEpisodeTitles(ctx context.Context, imdbID string, titles []string) error {
+	args := m.Called(ctx, imdbID, titles)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) SetMovieCollections(ctx context.Context, imdbID string, collections []models.CollectionMembership) error {
+	args := m.Called(ctx, imdbID, collections)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) GetMovieIDsWithGenre(ctx context.Context, genreID int) ([]string, error) {
+	args := m.Called(ctx, genreID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockMovieRepository) RenameGenre(ctx context.Context, genre models.Genre) error {
+	args := m.Called(ctx, genre)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) MergeGenre(ctx context.Context, from int, target models.Genre) error {
+	args := m.Called(ctx, from, target)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) UpsertMovies(ctx context.Context, movies []models.Movie) error {
+	args := m.Called(ctx, movies)
+	return args.Error(0)
+}
+
+func (m *MockMovieRepository) StreamMovies(ctx context.Context, filters models.MovieFilters, withRatings bool, fn func(movie models.MovieExport) error) error {
+	args := m.Called(ctx, filters, withRatings, fn)
+	if movies, ok := args.Get(0).([]models.MovieExport); ok {
+		for _, movie := range movies {
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
 }
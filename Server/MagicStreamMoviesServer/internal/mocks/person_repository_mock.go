@@ -0,0 +1,100 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type MockPersonRepository struct {
+	mock.Mock
+}
+
+func (m *MockPersonRepository) CreatePerson(ctx context.Context, person models.Person) (*models.Person, error) {
+	args := m.Called(ctx, person)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Person), args.Error(1)
+}
+
+func (m *MockPersonRepository) GetPerson(ctx context.Context, id bson.ObjectID) (*models.Person, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Person), args.Error(1)
+}
+
+func (m *MockPersonRepository) UpdatePerson(ctx context.Context, person models.Person) error {
+	args := m.Called(ctx, person)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) DeletePerson(ctx context.Context, id bson.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) SearchPeople(ctx context.Context, query string, skip int64, limit int64) ([]models.Person, error) {
+	args := m.Called(ctx, query, skip, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Person), args.Error(1)
+}
+
+func (m *MockPersonRepository) CreateCredit(ctx context.Context, credit models.Credit) (*models.Credit, error) {
+	args := m.Called(ctx, credit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Credit), args.Error(1)
+}
+
+func (m *MockPersonRepository) GetCredit(ctx context.Context, id bson.ObjectID) (*models.Credit, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Credit), args.Error(1)
+}
+
+func (m *MockPersonRepository) DeleteCredit(ctx context.Context, id bson.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) DeleteCreditsForPerson(ctx context.Context, personID bson.ObjectID) ([]string, error) {
+	args := m.Called(ctx, personID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPersonRepository) GetMovieCredits(ctx context.Context, imdbID string) ([]models.MovieCredit, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MovieCredit), args.Error(1)
+}
+
+func (m *MockPersonRepository) GetFilmography(ctx context.Context, personID bson.ObjectID, skip int64, limit int64) ([]models.FilmographyEntry, error) {
+	args := m.Called(ctx, personID, skip, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.FilmographyEntry), args.Error(1)
+}
+
+func (m *MockPersonRepository) GetCreditedMovieIDs(ctx context.Context, personID bson.ObjectID) ([]string, error) {
+	args := m.Called(ctx, personID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
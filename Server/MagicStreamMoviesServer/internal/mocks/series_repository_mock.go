@@ -0,0 +1,99 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeriesRepository) UpsertSeason(ctx context.Context, season models.Season) (*models.Season, error) {
+	args := m.Called(ctx, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetSeason(ctx context.Context, seriesID string, seasonNumber int) (*models.Season, error) {
+	args := m.Called(ctx, seriesID, seasonNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetSeasons(ctx context.Context, seriesID string) ([]models.Season, error) {
+	args := m.Called(ctx, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Season), args.Error(1)
+}
+
+func (m *MockSeriesRepository) DeleteSeason(ctx context.Context, seriesID string, seasonNumber int) error {
+	args := m.Called(ctx, seriesID, seasonNumber)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) UpsertEpisode(ctx context.Context, episode models.Episode) (*models.Episode, error) {
+	args := m.Called(ctx, episode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Episode), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetEpisode(ctx context.Context, seriesID string, seasonNumber int, episodeNumber int) (*models.Episode, error) {
+	args := m.Called(ctx, seriesID, seasonNumber, episodeNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Episode), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetEpisodeByImdbID(ctx context.Context, imdbID string) (*models.Episode, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Episode), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetEpisodes(ctx context.Context, seriesID string, seasonNumber int) ([]models.Episode, error) {
+	args := m.Called(ctx, seriesID, seasonNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Episode), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetSeriesEpisodes(ctx context.Context, seriesID string) ([]models.Episode, error) {
+	args := m.Called(ctx, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Episode), args.Error(1)
+}
+
+func (m *MockSeriesRepository) DeleteEpisode(ctx context.Context, seriesID string, seasonNumber int, episodeNumber int) error {
+	args := m.Called(ctx, seriesID, seasonNumber, episodeNumber)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) UpsertEpisodeProgress(ctx context.Context, progress models.EpisodeProgress) error {
+	args := m.Called(ctx, progress)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) GetLatestEpisodeProgress(ctx context.Context, userId string, seriesID string) (*models.EpisodeProgress, error) {
+	args := m.Called(ctx, userId, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EpisodeProgress), args.Error(1)
+}
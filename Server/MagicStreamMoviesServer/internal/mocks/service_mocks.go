@@ -2,8 +2,11 @@ package mocks
 
 import (
 	"context"
+	"io"
 
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/importer"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -41,14 +44,34 @@ type MockMovieService struct {
 	mock.Mock
 }
 
-func (m *MockMovieService) GetMovies(ctx context.Context) ([]models.Movie, error) {
-	args := m.Called(ctx)
+func (m *MockMovieService) ExportMovies(ctx context.Context, filters models.MovieFilters, withRatings bool, fn func(movie models.MovieExport) error) error {
+	args := m.Called(ctx, filters, withRatings, fn)
+	if movies, ok := args.Get(0).([]models.MovieExport); ok {
+		for _, movie := range movies {
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockMovieService) GetMovies(ctx context.Context, filters models.MovieFilters) ([]models.Movie, error) {
+	args := m.Called(ctx, filters)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.Movie), args.Error(1)
 }
 
+func (m *MockMovieService) GetFacets(ctx context.Context, filters models.MovieFilters) (*models.Facets, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Facets), args.Error(1)
+}
+
 func (m *MockMovieService) GetMovie(ctx context.Context, imdbID string) (*models.Movie, error) {
 	args := m.Called(ctx, imdbID)
 	if args.Get(0) == nil {
@@ -67,18 +90,634 @@ func (m *MockMovieService) UpdateAdminReview(ctx context.Context, imdbID string,
 	return args.String(0), args.String(1), args.Error(2)
 }
 
-func (m *MockMovieService) GetRecommendedMovies(ctx context.Context, userId string) ([]models.Movie, error) {
-	args := m.Called(ctx, userId)
+func (m *MockMovieService) GetRecommendedMovies(ctx context.Context, userId string, page models.Pagination) ([]models.RecommendedMovie, error) {
+	args := m.Called(ctx, userId, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RecommendedMovie), args.Error(1)
+}
+
+func (m *MockMovieService) ExplainRecommendation(ctx context.Context, userId string, imdbID string) (*models.RecommendationExplanation, error) {
+	args := m.Called(ctx, userId, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RecommendationExplanation), args.Error(1)
+}
+
+func (m *MockMovieService) GetSimilarMovies(ctx context.Context, imdbID string, limit int) ([]models.Movie, error) {
+	args := m.Called(ctx, imdbID, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.Movie), args.Error(1)
 }
 
-func (m *MockMovieService) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
+func (m *MockMovieService) RecordWatch(ctx context.Context, entry models.WatchHistory) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockMovieService) AddReview(ctx context.Context, review models.Review) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockMovieService) IndexMovie(ctx context.Context, movie models.Movie) error {
+	args := m.Called(ctx, movie)
+	return args.Error(0)
+}
+
+type MockHomeService struct {
+	mock.Mock
+}
+
+func (m *MockHomeService) GetHome(ctx context.Context, userId string) (*models.HomePage, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.HomePage), args.Error(1)
+}
+
+func (m *MockHomeService) GetRail(ctx context.Context, userId string, railType string, key string, page models.Pagination) (*models.Rail, error) {
+	args := m.Called(ctx, userId, railType, key, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Rail), args.Error(1)
+}
+
+type MockTrendingService struct {
+	mock.Mock
+}
+
+func (m *MockTrendingService) RecordEvent(ctx context.Context, event models.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockTrendingService) GetTrending(ctx context.Context, window string, genre string, limit int64) ([]models.TrendingMovie, error) {
+	args := m.Called(ctx, window, genre, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TrendingMovie), args.Error(1)
+}
+
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) Search(ctx context.Context, query string, filters models.MovieFilters, limit int) (*models.SearchResponse, error) {
+	args := m.Called(ctx, query, filters, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SearchResponse), args.Error(1)
+}
+
+func (m *MockSearchService) Suggest(ctx context.Context, prefix string, limit int) ([]models.Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Suggestion), args.Error(1)
+}
+
+func (m *MockSearchService) Reindex(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockSearchService) IndexMovie(ctx context.Context, movie models.Movie) error {
+	args := m.Called(ctx, movie)
+	return args.Error(0)
+}
+
+type MockSemanticService struct {
+	mock.Mock
+}
+
+func (m *MockSemanticService) Search(ctx context.Context, query string, limit int) ([]models.SearchResult, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SearchResult), args.Error(1)
+}
+
+func (m *MockSemanticService) Sync(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockSemanticService) IndexMovie(ctx context.Context, movie models.Movie) error {
+	args := m.Called(ctx, movie)
+	return args.Error(0)
+}
+
+type MockAssistantService struct {
+	mock.Mock
+}
+
+func (m *MockAssistantService) Chat(ctx context.Context, userId string, message string, onChunk func(string) error) (*models.AssistantReply, error) {
+	args := m.Called(ctx, userId, message, onChunk)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AssistantReply), args.Error(1)
+}
+
+func (m *MockAssistantService) ResetConversation(userId string) {
+	m.Called(userId)
+}
+
+type MockDraftService struct {
+	mock.Mock
+}
+
+func (m *MockDraftService) GenerateDraft(ctx context.Context, imdbID string) (*models.MovieDraft, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MovieDraft), args.Error(1)
+}
+
+func (m *MockDraftService) GetDraft(ctx context.Context, imdbID string) (*models.MovieDraft, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MovieDraft), args.Error(1)
+}
+
+func (m *MockDraftService) ApproveDraft(ctx context.Context, imdbID string, edits *models.MovieDraft) (*models.Movie, error) {
+	args := m.Called(ctx, imdbID, edits)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Movie), args.Error(1)
+}
+
+func (m *MockDraftService) DiscardDraft(ctx context.Context, imdbID string) error {
+	args := m.Called(ctx, imdbID)
+	return args.Error(0)
+}
+
+func (m *MockDraftService) GenerateMissingDrafts(ctx context.Context, overwrite bool) (int, error) {
+	args := m.Called(ctx, overwrite)
+	return args.Int(0), args.Error(1)
+}
+
+type MockMetadataService struct {
+	mock.Mock
+}
+
+// FillMovie returns the configured error; tests change the movie with Run.
+func (m *MockMetadataService) FillMovie(ctx context.Context, movie *models.Movie) error {
+	args := m.Called(ctx, movie)
+	return args.Error(0)
+}
+
+func (m *MockMetadataService) EnrichMovie(ctx context.Context, imdbID string, overwrite bool) (*models.Movie, error) {
+	args := m.Called(ctx, imdbID, overwrite)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Movie), args.Error(1)
+}
+
+type MockImageService struct {
+	mock.Mock
+}
+
+func (m *MockImageService) UploadPoster(ctx context.Context, imdbID string, r io.Reader) (*models.ImageSet, error) {
+	args := m.Called(ctx, imdbID, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImageSet), args.Error(1)
+}
+
+func (m *MockImageService) UploadBackdrop(ctx context.Context, imdbID string, r io.Reader) (*models.ImageSet, error) {
+	args := m.Called(ctx, imdbID, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImageSet), args.Error(1)
+}
+
+func (m *MockImageService) OpenImage(ctx context.Context, key string) (*storage.Blob, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Blob), args.Error(1)
+}
+
+type MockStreamService struct {
+	mock.Mock
+}
+
+func (m *MockStreamService) GetPlayback(ctx context.Context, imdbID string) (*models.StreamSource, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StreamSource), args.Error(1)
+}
+
+func (m *MockStreamService) OpenMedia(ctx context.Context, imdbID string, name string) (*storage.Blob, error) {
+	args := m.Called(ctx, imdbID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Blob), args.Error(1)
+}
+
+func (m *MockStreamService) SetStreamSource(ctx context.Context, imdbID string, source models.StreamSource) (*models.Movie, error) {
+	args := m.Called(ctx, imdbID, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Movie), args.Error(1)
+}
+
+func (m *MockStreamService) Invalidate(imdbID string) {
+	m.Called(imdbID)
+}
+
+func (m *MockStreamService) SignPlaybackURL(ctx context.Context, userID string, imdbID string) (*models.PlaybackURL, error) {
+	args := m.Called(ctx, userID, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PlaybackURL), args.Error(1)
+}
+
+type MockUploadService struct {
+	mock.Mock
+}
+
+func (m *MockUploadService) CreateUpload(ctx context.Context, upload models.Upload) (*models.Upload, error) {
+	args := m.Called(ctx, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Upload), args.Error(1)
+}
+
+func (m *MockUploadService) GetUpload(ctx context.Context, id string) (*models.Upload, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Upload), args.Error(1)
+}
+
+func (m *MockUploadService) WriteChunk(ctx context.Context, id string, offset int64, r io.Reader, size int64) (*models.Upload, error) {
+	args := m.Called(ctx, id, offset, r, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Upload), args.Error(1)
+}
+
+func (m *MockUploadService) DeleteUpload(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUploadService) MaxUploadSize() int64 {
+	args := m.Called()
+	return args.Get(0).(int64)
+}
+
+type MockTranscodeService struct {
+	mock.Mock
+}
+
+func (m *MockTranscodeService) QueueTranscode(ctx context.Context, imdbID string, sourceKey string, createdBy string) (*models.TranscodeJob, error) {
+	args := m.Called(ctx, imdbID, sourceKey, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeService) TranscodeMovie(ctx context.Context, imdbID string, req models.TranscodeRequest, createdBy string) (*models.TranscodeJob, error) {
+	args := m.Called(ctx, imdbID, req, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeService) GetJob(ctx context.Context, id string) (*models.TranscodeJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeService) GetMovieJobs(ctx context.Context, imdbID string) ([]models.TranscodeJob, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeService) CancelJob(ctx context.Context, id string) (*models.TranscodeJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeService) RetryJob(ctx context.Context, id string) (*models.TranscodeJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeService) RunQueue(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockTranscodeService) RequeueInterrupted(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockSubtitleService struct {
+	mock.Mock
+}
+
+func (m *MockSubtitleService) SaveTrack(ctx context.Context, imdbID string, lang string, req models.SubtitleTrackRequest, r io.Reader) (*models.SubtitleTrack, error) {
+	args := m.Called(ctx, imdbID, lang, req, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SubtitleTrack), args.Error(1)
+}
+
+func (m *MockSubtitleService) UpdateTrack(ctx context.Context, imdbID string, lang string, req models.SubtitleTrackRequest) (*models.SubtitleTrack, error) {
+	args := m.Called(ctx, imdbID, lang, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SubtitleTrack), args.Error(1)
+}
+
+func (m *MockSubtitleService) DeleteTrack(ctx context.Context, imdbID string, lang string) error {
+	args := m.Called(ctx, imdbID, lang)
+	return args.Error(0)
+}
+
+func (m *MockSubtitleService) OpenTrack(ctx context.Context, imdbID string, lang string) (*storage.Blob, error) {
+	args := m.Called(ctx, imdbID, lang)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storage.Blob), args.Error(1)
+}
+
+type MockPersonService struct {
+	mock.Mock
+}
+
+func (m *MockPersonService) GetPerson(ctx context.Context, id string, page models.Pagination) (*models.PersonPage, error) {
+	args := m.Called(ctx, id, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PersonPage), args.Error(1)
+}
+
+func (m *MockPersonService) SearchPeople(ctx context.Context, query string, page models.Pagination) ([]models.Person, error) {
+	args := m.Called(ctx, query, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Person), args.Error(1)
+}
+
+func (m *MockPersonService) CreatePerson(ctx context.Context, person models.Person) (*models.Person, error) {
+	args := m.Called(ctx, person)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Person), args.Error(1)
+}
+
+func (m *MockPersonService) UpdatePerson(ctx context.Context, id string, person models.Person) (*models.Person, error) {
+	args := m.Called(ctx, id, person)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Person), args.Error(1)
+}
+
+func (m *MockPersonService) DeletePerson(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPersonService) GetMovieCredits(ctx context.Context, imdbID string) ([]models.MovieCredit, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MovieCredit), args.Error(1)
+}
+
+func (m *MockPersonService) AddCredit(ctx context.Context, imdbID string, req models.CreditRequest) (*models.MovieCredit, error) {
+	args := m.Called(ctx, imdbID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MovieCredit), args.Error(1)
+}
+
+func (m *MockPersonService) DeleteCredit(ctx context.Context, imdbID string, creditID string) error {
+	args := m.Called(ctx, imdbID, creditID)
+	return args.Error(0)
+}
+
+type MockSeriesService struct {
+	mock.Mock
+}
+
+func (m *MockSeriesService) GetSeries(ctx context.Context, imdbID string) (*models.SeriesDetail, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SeriesDetail), args.Error(1)
+}
+
+func (m *MockSeriesService) GetSeason(ctx context.Context, imdbID string, seasonNumber int) (*models.SeasonDetail, error) {
+	args := m.Called(ctx, imdbID, seasonNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SeasonDetail), args.Error(1)
+}
+
+func (m *MockSeriesService) GetEpisode(ctx context.Context, imdbID string, seasonNumber int, episodeNumber int) (*models.Episode, error) {
+	args := m.Called(ctx, imdbID, seasonNumber, episodeNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Episode), args.Error(1)
+}
+
+func (m *MockSeriesService) SaveSeason(ctx context.Context, season models.Season) (*models.Season, error) {
+	args := m.Called(ctx, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
+}
+
+func (m *MockSeriesService) DeleteSeason(ctx context.Context, imdbID string, seasonNumber int) error {
+	args := m.Called(ctx, imdbID, seasonNumber)
+	return args.Error(0)
+}
+
+func (m *MockSeriesService) SaveEpisode(ctx context.Context, episode models.Episode) (*models.Episode, error) {
+	args := m.Called(ctx, episode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Episode), args.Error(1)
+}
+
+func (m *MockSeriesService) DeleteEpisode(ctx context.Context, imdbID string, seasonNumber int, episodeNumber int) error {
+	args := m.Called(ctx, imdbID, seasonNumber, episodeNumber)
+	return args.Error(0)
+}
+
+func (m *MockSeriesService) RecordEpisodeWatch(ctx context.Context, progress models.EpisodeProgress) error {
+	args := m.Called(ctx, progress)
+	return args.Error(0)
+}
+
+func (m *MockSeriesService) GetNextEpisode(ctx context.Context, userId string, imdbID string) (*models.NextEpisode, error) {
+	args := m.Called(ctx, userId, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NextEpisode), args.Error(1)
+}
+
+type MockCollectionService struct {
+	mock.Mock
+}
+
+func (m *MockCollectionService) GetCollections(ctx context.Context, page models.Pagination) ([]models.Collection, error) {
+	args := m.Called(ctx, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) GetCollection(ctx context.Context, id string) (*models.CollectionDetail, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CollectionDetail), args.Error(1)
+}
+
+func (m *MockCollectionService) CreateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error) {
+	args := m.Called(ctx, collection)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) UpdateCollection(ctx context.Context, id string, collection models.Collection) (*models.Collection, error) {
+	args := m.Called(ctx, id, collection)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) DeleteCollection(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockGenreService struct {
+	mock.Mock
+}
+
+func (m *MockGenreService) GetGenres(ctx context.Context) ([]models.Genre, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.Genre), args.Error(1)
 }
+
+func (m *MockGenreService) GetGenre(ctx context.Context, id int) (*models.Genre, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Genre), args.Error(1)
+}
+
+func (m *MockGenreService) CreateGenre(ctx context.Context, req models.GenreRequest) (*models.Genre, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Genre), args.Error(1)
+}
+
+func (m *MockGenreService) RenameGenre(ctx context.Context, id int, req models.GenreRequest) (*models.Genre, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Genre), args.Error(1)
+}
+
+func (m *MockGenreService) DeleteGenre(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGenreService) MergeGenre(ctx context.Context, id int, merge models.GenreMerge) (*models.Genre, error) {
+	args := m.Called(ctx, id, merge)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Genre), args.Error(1)
+}
+
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) ImportMovies(ctx context.Context, reader importer.Reader, dryRun bool) (*models.ImportReport, error) {
+	args := m.Called(ctx, reader, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImportReport), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSimilarityRepository struct {
+	mock.Mock
+}
+
+func (m *MockSimilarityRepository) ReplaceSimilarities(ctx context.Context, similarities []models.MovieSimilarity, computedAt time.Time) error {
+	args := m.Called(ctx, similarities, computedAt)
+	return args.Error(0)
+}
+
+func (m *MockSimilarityRepository) GetSimilarities(ctx context.Context, imdbIDs []string) ([]models.MovieSimilarity, error) {
+	args := m.Called(ctx, imdbIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MovieSimilarity), args.Error(1)
+}
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type MockTranscodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranscodeRepository) CreateTranscodeJob(ctx context.Context, job models.TranscodeJob) (*models.TranscodeJob, error) {
+	args := m.Called(ctx, job)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeRepository) GetTranscodeJob(ctx context.Context, id bson.ObjectID) (*models.TranscodeJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeRepository) GetTranscodeJobs(ctx context.Context, imdbID string) ([]models.TranscodeJob, error) {
+	args := m.Called(ctx, imdbID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeRepository) ClaimTranscodeJob(ctx context.Context, leaseUntil time.Time) (*models.TranscodeJob, error) {
+	args := m.Called(ctx, leaseUntil)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscodeJob), args.Error(1)
+}
+
+func (m *MockTranscodeRepository) UpdateTranscodeProgress(ctx context.Context, id bson.ObjectID, progress float64, leaseUntil time.Time) error {
+	args := m.Called(ctx, id, progress, leaseUntil)
+	return args.Error(0)
+}
+
+func (m *MockTranscodeRepository) FinishTranscodeJob(ctx context.Context, job models.TranscodeJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockTranscodeRepository) CancelTranscodeJob(ctx context.Context, id bson.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTranscodeRepository) RetryTranscodeJob(ctx context.Context, id bson.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTranscodeRepository) RequeueExpiredTranscodeJobs(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type MockUploadRepository struct {
+	mock.Mock
+}
+
+func (m *MockUploadRepository) CreateUpload(ctx context.Context, upload models.Upload) (*models.Upload, error) {
+	args := m.Called(ctx, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Upload), args.Error(1)
+}
+
+func (m *MockUploadRepository) GetUpload(ctx context.Context, id bson.ObjectID) (*models.Upload, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Upload), args.Error(1)
+}
+
+func (m *MockUploadRepository) AppendUploadPart(ctx context.Context, id bson.ObjectID, offset int64, part models.UploadPart) error {
+	args := m.Called(ctx, id, offset, part)
+	return args.Error(0)
+}
+
+func (m *MockUploadRepository) ClaimUploadJoin(ctx context.Context, id bson.ObjectID, until time.Time) error {
+	args := m.Called(ctx, id, until)
+	return args.Error(0)
+}
+
+func (m *MockUploadRepository) ReleaseUploadJoin(ctx context.Context, id bson.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUploadRepository) CompleteUpload(ctx context.Context, id bson.ObjectID, key string) error {
+	args := m.Called(ctx, id, key)
+	return args.Error(0)
+}
+
+func (m *MockUploadRepository) DeleteUpload(ctx context.Context, id bson.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -54,3 +54,18 @@ func (m *MockUserRepository) GetUserFavouriteGenres(ctx context.Context, userId
 	}
 	return args.Get(0).([]string), args.Error(1)
 }
+
+func (m *MockUserRepository) RenameFavouriteGenre(ctx context.Context, genre models.Genre) error {
+	args := m.Called(ctx, genre)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) MergeFavouriteGenre(ctx context.Context, from int, target models.Genre) error {
+	args := m.Called(ctx, from, target)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RemoveFavouriteGenre(ctx context.Context, genreID int) error {
+	args := m.Called(ctx, genreID)
+	return args.Error(0)
+}
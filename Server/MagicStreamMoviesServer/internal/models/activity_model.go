@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type WatchHistory struct {
+	ID              bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID          string        `json:"user_id" bson:"user_id"`
+	ImdbID          string        `json:"imdb_id" bson:"imdb_id"`
+	ProgressSeconds int           `json:"progress_seconds" bson:"progress_seconds" validate:"gte=0"`
+	Completed       bool          `json:"completed" bson:"completed"`
+	WatchedAt       time.Time     `json:"watched_at" bson:"watched_at"`
+	// SeasonNumber and EpisodeNumber are only set for series. They point at the episode to
+	// play next, and ProgressSeconds is the position within it.
+	SeasonNumber  int `json:"season_number,omitempty" bson:"season_number,omitempty"`
+	EpisodeNumber int `json:"episode_number,omitempty" bson:"episode_number,omitempty"`
+}
+
+type Review struct {
+	ID        bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID    string        `json:"user_id" bson:"user_id"`
+	ImdbID    string        `json:"imdb_id" bson:"imdb_id"`
+	Rating    int           `json:"rating" bson:"rating" validate:"required,min=1,max=5"`
+	Comment   string        `json:"comment" bson:"comment" validate:"max=2000"`
+	CreatedAt time.Time     `json:"created_at" bson:"created_at"`
+}
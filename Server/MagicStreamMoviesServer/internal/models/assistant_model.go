@@ -0,0 +1,23 @@
+package models
+
+// ChatMessage is one turn of a conversation with the assistant.
+type ChatMessage struct {
+	Role    string `json:"role" bson:"role"`
+	Content string `json:"content" bson:"content"`
+}
+
+const (
+	ChatRoleUser      = "user"
+	ChatRoleAssistant = "assistant"
+)
+
+type ChatRequest struct {
+	Message string `json:"message" validate:"required,max=2000"`
+}
+
+// AssistantReply is the assistant's answer and the catalog movies it picked, in the order
+// they were mentioned.
+type AssistantReply struct {
+	Message string  `json:"message"`
+	Picks   []Movie `json:"picks"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Collection groups titles, such as the films of a franchise, in their viewing order.
+type Collection struct {
+	ID          bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	Name        string        `json:"name" bson:"name" validate:"required,min=2,max=200"`
+	Description string        `json:"description,omitempty" bson:"description,omitempty" validate:"max=2000"`
+	PosterPath  string        `json:"poster_path,omitempty" bson:"poster_path,omitempty" validate:"omitempty,url"`
+	ImdbIDs     []string      `json:"imdb_ids" bson:"imdb_ids" validate:"required,min=1,max=500,unique,dive,required"`
+	// Featured collections get a rail on the home page.
+	Featured  bool      `json:"featured" bson:"featured"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// CollectionDetail is a collection with its titles in viewing order.
+type CollectionDetail struct {
+	Collection `bson:",inline"`
+	Movies     []Movie `json:"movies"`
+}
+
+// CollectionMembership places a movie in a collection. Position counts from 1.
+type CollectionMembership struct {
+	ID       bson.ObjectID `json:"id" bson:"id"`
+	Name     string        `json:"name" bson:"name"`
+	Position int           `json:"position" bson:"position"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// MovieEmbedding is the stored vector of a movie's text. TextHash and Model tell whether the
+// vector is still current.
+type MovieEmbedding struct {
+	ImdbID    string    `json:"imdb_id" bson:"imdb_id"`
+	Model     string    `json:"model" bson:"model"`
+	TextHash  string    `json:"text_hash" bson:"text_hash"`
+	Vector    []float32 `json:"vector" bson:"vector"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
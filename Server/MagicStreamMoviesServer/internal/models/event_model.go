@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	EventView      = "view"
+	EventPlay      = "play"
+	EventWatchlist = "watchlist"
+	EventReview    = "review"
+)
+
+// Event is a single user interaction with a movie, recorded for popularity analytics.
+type Event struct {
+	ID        bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID    string        `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	ImdbID    string        `json:"imdb_id" bson:"imdb_id" validate:"required"`
+	Type      string        `json:"type" bson:"type" validate:"required,oneof=view play watchlist review"`
+	CreatedAt time.Time     `json:"created_at" bson:"created_at"`
+}
+
+// TrendingScore is the time-decayed popularity of a movie over one trending window.
+type TrendingScore struct {
+	ImdbID     string    `json:"imdb_id" bson:"imdb_id"`
+	Window     string    `json:"window" bson:"window"`
+	Score      float64   `json:"score" bson:"score"`
+	Genres     []string  `json:"genres" bson:"genres"`
+	ComputedAt time.Time `json:"computed_at" bson:"computed_at"`
+}
+
+type TrendingMovie struct {
+	Movie         `bson:",inline"`
+	TrendingScore float64 `json:"trending_score" bson:"trending_score"`
+}
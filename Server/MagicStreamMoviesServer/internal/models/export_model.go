@@ -0,0 +1,9 @@
+package models
+
+// MovieExport is a movie with the user review figures that catalog exports can include.
+type MovieExport struct {
+	Movie `bson:",inline"`
+	// AverageRating is nil for movies without reviews.
+	AverageRating *float64 `json:"average_rating" bson:"average_rating"`
+	ReviewCount   int      `json:"review_count" bson:"review_count"`
+}
@@ -0,0 +1,40 @@
+package models
+
+// MovieFilters are multi-select facet filters. A movie matches a facet when it matches any of
+// the selected values; it must match every facet that has a selection.
+type MovieFilters struct {
+	Genres   []string `json:"genres,omitempty"`
+	Rankings []string `json:"rankings,omitempty"`
+	// Decades are the first years of release decades, e.g. 1990.
+	Decades []int `json:"decades,omitempty"`
+	// Ratings are average user rating buckets: 4 matches averages from 4 up to, but not
+	// including, 5.
+	Ratings []int `json:"ratings,omitempty"`
+	// Types are content types: movie or series.
+	Types []string `json:"types,omitempty"`
+}
+
+func (f MovieFilters) IsEmpty() bool {
+	return len(f.Genres) == 0 && len(f.Rankings) == 0 && len(f.Decades) == 0 && len(f.Ratings) == 0 && len(f.Types) == 0
+}
+
+type FacetCount struct {
+	Value string `json:"value" bson:"value"`
+	Count int64  `json:"count" bson:"count"`
+}
+
+// Facets holds the number of matching movies per facet value. Counts for a facet honour the
+// selections of every other facet but not its own, so selecting a value keeps its siblings
+// visible.
+type Facets struct {
+	Genres   []FacetCount `json:"genres" bson:"genres"`
+	Rankings []FacetCount `json:"rankings" bson:"rankings"`
+	Decades  []FacetCount `json:"decades" bson:"decades"`
+	Ratings  []FacetCount `json:"ratings" bson:"ratings"`
+	Types    []FacetCount `json:"types" bson:"types"`
+}
+
+type SearchResponse struct {
+	Results []SearchResult `json:"results"`
+	Facets  Facets         `json:"facets"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// GenreRequest names a genre on creation or rename. Genre IDs are assigned by the server and
+// never change.
+type GenreRequest struct {
+	GenreName string `json:"genre_name" validate:"required,min=2,max=100"`
+}
+
+// GenreMerge folds a duplicate genre into the target genre.
+type GenreMerge struct {
+	TargetID int `json:"target_id" validate:"required"`
+}
@@ -0,0 +1,46 @@
+package models
+
+const (
+	RailContinueWatching = "continue_watching"
+	RailRecommended      = "recommended"
+	RailTopRanked        = "top_ranked"
+	RailNewArrivals      = "new_arrivals"
+	RailGenre            = "genre"
+	RailTrending         = "trending"
+	RailCollection       = "collection"
+
+	// RailFavouriteGenres is only used in rail configuration; it expands into one RailGenre
+	// rail per favourite genre of the user.
+	RailFavouriteGenres = "favourite_genres"
+	// RailFeaturedCollections is only used in rail configuration; it expands into one
+	// RailCollection rail per featured collection.
+	RailFeaturedCollections = "featured_collections"
+)
+
+// RailItem is a movie shown on a home rail, with the extra context some rails carry.
+type RailItem struct {
+	Movie           `bson:",inline"`
+	ProgressSeconds int `json:"progress_seconds,omitempty" bson:"progress_seconds,omitempty"`
+	// SeasonNumber and EpisodeNumber name the episode to resume when the item is a series.
+	SeasonNumber  int                    `json:"season_number,omitempty" bson:"season_number,omitempty"`
+	EpisodeNumber int                    `json:"episode_number,omitempty" bson:"episode_number,omitempty"`
+	Reasons       []RecommendationReason `json:"reasons,omitempty" bson:"reasons,omitempty"`
+}
+
+// Rail is one titled row of the home page holding a page of items.
+type Rail struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Genre string `json:"genre,omitempty"`
+	// Collection is the ID of the collection shown by a collection rail.
+	Collection string     `json:"collection,omitempty"`
+	Items      []RailItem `json:"items"`
+	Page       int64      `json:"page"`
+	PageSize   int64      `json:"page_size"`
+	HasMore    bool       `json:"has_more"`
+}
+
+type HomePage struct {
+	Personalized bool   `json:"personalized"`
+	Rails        []Rail `json:"rails"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// ImageSet is an uploaded image with its resized copies.
+type ImageSet struct {
+	Width  int `json:"width" bson:"width"`
+	Height int `json:"height" bson:"height"`
+	// Variants maps copy names to URLs: original, thumb, medium and large, the last three
+	// also as WebP under thumb_webp, medium_webp and large_webp.
+	Variants map[string]string `json:"variants" bson:"variants"`
+	// Key is the storage prefix of the files, so they can be removed when replaced.
+	Key string `json:"-" bson:"key"`
+}
+
+// MovieImages are the images uploaded for a movie.
+type MovieImages struct {
+	Poster    *ImageSet  `json:"poster,omitempty" bson:"poster,omitempty"`
+	Backdrops []ImageSet `json:"backdrops,omitempty" bson:"backdrops,omitempty"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// ImportReport summarises a bulk import. Rows count every record read, including skipped and
+// failed ones.
+type ImportReport struct {
+	DryRun  bool `json:"dry_run"`
+	Rows    int  `json:"rows"`
+	Created int  `json:"created"`
+	Updated int  `json:"updated"`
+	// Skipped rows are of a kind the format does not import, such as TV episodes.
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+	// Errors lists the failed rows, up to a limit; Failed has the full count.
+	Errors          []ImportRowError `json:"errors"`
+	ErrorsTruncated bool             `json:"errors_truncated,omitempty"`
+}
+
+type ImportRowError struct {
+	Line   int    `json:"line"`
+	ImdbID string `json:"imdb_id,omitempty"`
+	Error  string `json:"error"`
+}
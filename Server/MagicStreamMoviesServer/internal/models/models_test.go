@@ -118,6 +118,31 @@ func TestMovieValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "Valid Metadata",
+			movie:   withMetadata(Movie{Runtime: 142, OriginalLanguage: "en", Country: "US", MaturityRating: MaturityPG13, Keywords: []string{"heist"}, Backdrops: []string{"http://example.com/backdrop.jpg"}}),
+			wantErr: false,
+		},
+		{
+			name:    "Unknown Maturity Rating",
+			movie:   withMetadata(Movie{MaturityRating: "X"}),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Country",
+			movie:   withMetadata(Movie{Country: "USA"}),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid Backdrop URL",
+			movie:   withMetadata(Movie{Backdrops: []string{"backdrop.jpg"}}),
+			wantErr: true,
+		},
+		{
+			name:    "Negative Runtime",
+			movie:   withMetadata(Movie{Runtime: -5}),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -131,3 +156,31 @@ func TestMovieValidation(t *testing.T) {
 		})
 	}
 }
+
+// withMetadata fills in the required fields of a movie that only sets metadata.
+func withMetadata(movie Movie) Movie {
+	movie.ImdbID = "tt1234567"
+	movie.Title = "Test Movie"
+	movie.PosterPath = "http://example.com/poster.jpg"
+	movie.YouTubeID = "dQw4w9WgXcQ"
+	movie.Genre = []Genre{{GenreID: 1, GenreName: "Action"}}
+	movie.Ranking = Ranking{RankingValue: 8, RankingName: "Good"}
+	return movie
+}
+
+func TestMoviePlayback(t *testing.T) {
+	movie := Movie{YouTubeID: "dQw4w9WgXcQ"}
+	assert.Equal(t, StreamSource{Type: StreamYouTube, YouTubeID: "dQw4w9WgXcQ"}, movie.Playback())
+
+	movie.Stream = &StreamSource{Type: StreamHLS, Key: "media/tt1/hls/master.m3u8"}
+	assert.Equal(t, *movie.Stream, movie.Playback())
+	assert.True(t, movie.Playback().SelfHosted())
+}
+
+func TestStreamSourceValidation(t *testing.T) {
+	validate := validator.New()
+	assert.NoError(t, validate.Struct(StreamSource{Type: StreamYouTube}))
+	assert.NoError(t, validate.Struct(StreamSource{Type: StreamFile, Key: "media/tt1/movie.mp4"}))
+	assert.Error(t, validate.Struct(StreamSource{Type: StreamFile}))
+	assert.Error(t, validate.Struct(StreamSource{Type: "dash", Key: "media/tt1/manifest.mpd"}))
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -14,13 +16,81 @@ type Ranking struct {
 	RankingName  string `json:"ranking_name" bson:"ranking_name" validate:"required"`
 }
 
+// Content types of a catalog title. A series is a title like any other, with its seasons and
+// episodes stored alongside it; titles without a content type are movies.
+const (
+	ContentTypeMovie  = "movie"
+	ContentTypeSeries = "series"
+)
+
+// Maturity ratings a movie can carry, following the MPA scale. NR is for unrated movies.
+const (
+	MaturityG    = "G"
+	MaturityPG   = "PG"
+	MaturityPG13 = "PG-13"
+	MaturityR    = "R"
+	MaturityNC17 = "NC-17"
+	MaturityNR   = "NR"
+)
+
 type Movie struct {
-	ID          bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
-	ImdbID      string        `json:"imdb_id" bson:"imdb_id" validate:"required"`
-	Title       string        `json:"title" bson:"title" validate:"required,min=2,max=500"`
-	PosterPath  string        `json:"poster_path" bson:"poster_path" validate:"required,url"`
-	YouTubeID   string        `json:"youtube_id" bson:"youtube_id" validate:"required"`
-	Genre       []Genre       `json:"genre" bson:"genre" validate:"required,dive"`
-	AdminReview string        `json:"admin_review" bson:"admin_review"`
-	Ranking     Ranking       `json:"ranking" bson:"ranking" validate:"required"`
+	ID               bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	ImdbID           string        `json:"imdb_id" bson:"imdb_id" validate:"required"`
+	ContentType      string        `json:"content_type,omitempty" bson:"content_type,omitempty" validate:"omitempty,oneof=movie series"`
+	Title            string        `json:"title" bson:"title" validate:"required,min=2,max=500"`
+	PosterPath       string        `json:"poster_path" bson:"poster_path" validate:"required,url"`
+	YouTubeID        string        `json:"youtube_id" bson:"youtube_id" validate:"required"`
+	Genre            []Genre       `json:"genre" bson:"genre" validate:"required,dive"`
+	AdminReview      string        `json:"admin_review" bson:"admin_review"`
+	Ranking          Ranking       `json:"ranking" bson:"ranking" validate:"required"`
+	ReleaseDate      *time.Time    `json:"release_date,omitempty" bson:"release_date,omitempty"`
+	Runtime          int           `json:"runtime,omitempty" bson:"runtime,omitempty" validate:"omitempty,min=1,max=1000"` // minutes
+	Synopsis         string        `json:"synopsis,omitempty" bson:"synopsis,omitempty" validate:"max=2000"`
+	OriginalLanguage string        `json:"original_language,omitempty" bson:"original_language,omitempty" validate:"omitempty,bcp47_language_tag"` // e.g. "en"
+	Country          string        `json:"country,omitempty" bson:"country,omitempty" validate:"omitempty,iso3166_1_alpha2"`                       // e.g. "US"
+	MaturityRating   string        `json:"maturity_rating,omitempty" bson:"maturity_rating,omitempty" validate:"omitempty,oneof=G PG PG-13 R NC-17 NR"`
+	Keywords         []string      `json:"keywords,omitempty" bson:"keywords,omitempty" validate:"max=30,dive,min=1,max=100"`
+	MoodTags         []string      `json:"mood_tags,omitempty" bson:"mood_tags,omitempty"`
+	Backdrops        []string      `json:"backdrops,omitempty" bson:"backdrops,omitempty" validate:"max=20,dive,url"`
+	AIDraft          *MovieDraft   `json:"ai_draft,omitempty" bson:"ai_draft,omitempty"`
+	// Images are maintained by the image uploads, which also point PosterPath and Backdrops at
+	// the large copies; values sent by clients are ignored.
+	Images *MovieImages `json:"images,omitempty" bson:"images,omitempty"`
+	// Stream is set through the stream source endpoint; titles without one play from YouTube.
+	// Values sent by clients are ignored.
+	Stream *StreamSource `json:"stream,omitempty" bson:"stream,omitempty"`
+	// Subtitles are maintained by the subtitle endpoints; values sent by clients are ignored.
+	Subtitles []SubtitleTrack `json:"subtitles,omitempty" bson:"subtitles,omitempty"`
+	// Collections are maintained from the collections that list the movie; values sent by
+	// clients are ignored.
+	Collections []CollectionMembership `json:"collections,omitempty" bson:"collections,omitempty"`
+	// CreditNames mirrors the names of the credited people so search can match them.
+	CreditNames []string `json:"-" bson:"credit_names,omitempty"`
+	// EpisodeTitles mirrors the titles of a series' episodes so search can match them.
+	EpisodeTitles []string `json:"-" bson:"episode_titles,omitempty"`
+	// CreatedAt and UpdatedAt are maintained by the repository; values sent by clients are ignored.
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+func (m Movie) IsSeries() bool {
+	return m.ContentType == ContentTypeSeries
+}
+
+// Playback returns where the title plays from: its stream source, or YouTube when it has none.
+func (m Movie) Playback() StreamSource {
+	if m.Stream != nil && m.Stream.SelfHosted() {
+		return *m.Stream
+	}
+	return StreamSource{Type: StreamYouTube, YouTubeID: m.YouTubeID}
+}
+
+// MovieDraft is LLM generated descriptive text for a movie. It is kept apart from the published
+// fields until an admin approves it.
+type MovieDraft struct {
+	Synopsis    string    `json:"synopsis" bson:"synopsis"`
+	Keywords    []string  `json:"keywords" bson:"keywords"`
+	MoodTags    []string  `json:"mood_tags" bson:"mood_tags"`
+	Model       string    `json:"model" bson:"model"`
+	GeneratedAt time.Time `json:"generated_at" bson:"generated_at"`
 }
@@ -0,0 +1,13 @@
+package models
+
+type Pagination struct {
+	Page     int64 `json:"page"`
+	PageSize int64 `json:"page_size"`
+}
+
+func (p Pagination) Skip() int64 {
+	if p.Page < 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.PageSize
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	CreditActor    = "actor"
+	CreditDirector = "director"
+	CreditWriter   = "writer"
+)
+
+// Person is someone in a movie's cast or crew.
+type Person struct {
+	ID        bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name      string        `json:"name" bson:"name" validate:"required,min=1,max=200"`
+	Bio       string        `json:"bio,omitempty" bson:"bio,omitempty" validate:"max=5000"`
+	PhotoURL  string        `json:"photo_url,omitempty" bson:"photo_url,omitempty" validate:"omitempty,url"`
+	BirthDate *time.Time    `json:"birth_date,omitempty" bson:"birth_date,omitempty"`
+	CreatedAt time.Time     `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at" bson:"updated_at"`
+}
+
+// Credit links a person to a movie in a role. Character is only set for actors; Order is the
+// billing position within the movie's credits.
+type Credit struct {
+	ID        bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	PersonID  bson.ObjectID `json:"person_id" bson:"person_id"`
+	ImdbID    string        `json:"imdb_id" bson:"imdb_id"`
+	Role      string        `json:"role" bson:"role" validate:"required,oneof=actor director writer"`
+	Character string        `json:"character,omitempty" bson:"character,omitempty" validate:"max=200,excluded_unless=Role actor"`
+	Order     int           `json:"order" bson:"order" validate:"gte=0"`
+}
+
+// CreditRequest is the body an admin sends to credit a person on a movie.
+type CreditRequest struct {
+	PersonID  string `json:"person_id" validate:"required"`
+	Role      string `json:"role" validate:"required,oneof=actor director writer"`
+	Character string `json:"character,omitempty" validate:"max=200,excluded_unless=Role actor"`
+	Order     int    `json:"order" validate:"gte=0"`
+}
+
+// MovieCredit is a credit of a movie together with the credited person.
+type MovieCredit struct {
+	Credit `bson:",inline"`
+	Person Person `json:"person" bson:"person"`
+}
+
+// FilmographyEntry is a credit of a person together with the credited movie.
+type FilmographyEntry struct {
+	Role      string `json:"role" bson:"role"`
+	Character string `json:"character,omitempty" bson:"character,omitempty"`
+	Movie     Movie  `json:"movie" bson:"movie"`
+}
+
+// PersonPage is a person with one page of their filmography, newest releases first.
+type PersonPage struct {
+	Person      Person             `json:"person"`
+	Filmography []FilmographyEntry `json:"filmography"`
+	Page        int64              `json:"page"`
+	PageSize    int64              `json:"page_size"`
+	HasMore     bool               `json:"has_more"`
+}
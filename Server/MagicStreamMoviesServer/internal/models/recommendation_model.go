@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+type SimilarMovie struct {
+	ImdbID string  `json:"imdb_id" bson:"imdb_id"`
+	Score  float64 `json:"score" bson:"score"`
+}
+
+// MovieSimilarity holds the precomputed item-item neighbours of a movie.
+type MovieSimilarity struct {
+	ImdbID     string         `json:"imdb_id" bson:"imdb_id"`
+	Neighbors  []SimilarMovie `json:"neighbors" bson:"neighbors"`
+	ComputedAt time.Time      `json:"computed_at" bson:"computed_at"`
+}
+
+const (
+	ReasonGenreMatch        = "genre_match"
+	ReasonBecauseYouWatched = "because_you_watched"
+	ReasonTopRanked         = "top_ranked"
+)
+
+// RecommendationReason is a machine-readable answer to "why am I seeing this?".
+type RecommendationReason struct {
+	Type        string `json:"type" bson:"type"`
+	Genre       string `json:"genre,omitempty" bson:"genre,omitempty"`
+	ImdbID      string `json:"imdb_id,omitempty" bson:"imdb_id,omitempty"`
+	Title       string `json:"title,omitempty" bson:"title,omitempty"`
+	RankingName string `json:"ranking_name,omitempty" bson:"ranking_name,omitempty"`
+}
+
+// RecommendedMovie is a movie as returned by /recommendedMovies: the movie fields plus its score
+// and the reasons it was recommended.
+type RecommendedMovie struct {
+	Movie   `bson:",inline"`
+	Score   float64                `json:"score" bson:"score"`
+	Reasons []RecommendationReason `json:"reasons" bson:"reasons"`
+}
+
+// ScoreComponent is one weighted signal of a recommendation score.
+type ScoreComponent struct {
+	Signal       string  `json:"signal"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+// RecommendationExplanation is the full score breakdown of a movie for a user.
+type RecommendationExplanation struct {
+	ImdbID      string                 `json:"imdb_id"`
+	Title       string                 `json:"title"`
+	Strategy    string                 `json:"strategy"`
+	Score       float64                `json:"score"`
+	AlreadySeen bool                   `json:"already_seen"`
+	Components  []ScoreComponent       `json:"components"`
+	Reasons     []RecommendationReason `json:"reasons"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// SearchHit is a movie matched by the search index and its relevance to the query.
+type SearchHit struct {
+	ImdbID string  `json:"imdb_id" bson:"imdb_id"`
+	Score  float64 `json:"score" bson:"score"`
+}
+
+type SearchResult struct {
+	Movie `bson:",inline"`
+	Score float64 `json:"score" bson:"score"`
+}
+
+// Suggestion is an autocomplete entry for a title prefix.
+type Suggestion struct {
+	ImdbID string `json:"imdb_id" bson:"imdb_id"`
+	Title  string `json:"title" bson:"title"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Season groups the episodes of a series. Season 0 holds specials. SeriesID is the imdb_id of
+// the series title and, like SeasonNumber, is taken from the request path.
+type Season struct {
+	ID           bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	SeriesID     string        `json:"series_id" bson:"series_id"`
+	SeasonNumber int           `json:"season_number" bson:"season_number"`
+	Title        string        `json:"title,omitempty" bson:"title,omitempty" validate:"max=500"`
+	Overview     string        `json:"overview,omitempty" bson:"overview,omitempty" validate:"max=2000"`
+	PosterPath   string        `json:"poster_path,omitempty" bson:"poster_path,omitempty" validate:"omitempty,url"`
+	AirDate      *time.Time    `json:"air_date,omitempty" bson:"air_date,omitempty"`
+	CreatedAt    time.Time     `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at" bson:"updated_at"`
+}
+
+// Episode is a single playable part of a series, with its own stream and runtime.
+type Episode struct {
+	ID            bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	SeriesID      string        `json:"series_id" bson:"series_id"`
+	SeasonNumber  int           `json:"season_number" bson:"season_number"`
+	EpisodeNumber int           `json:"episode_number" bson:"episode_number"`
+	ImdbID        string        `json:"imdb_id,omitempty" bson:"imdb_id,omitempty" validate:"required_with=Stream"`
+	Title         string        `json:"title" bson:"title" validate:"required,min=1,max=500"`
+	Synopsis      string        `json:"synopsis,omitempty" bson:"synopsis,omitempty" validate:"max=2000"`
+	Runtime       int           `json:"runtime" bson:"runtime" validate:"required,min=1,max=1000"` // minutes
+	YouTubeID     string        `json:"youtube_id" bson:"youtube_id" validate:"required_without=Stream"`
+	AirDate       *time.Time    `json:"air_date,omitempty" bson:"air_date,omitempty"`
+	// Stream points the episode at media in the blob store, like the stream source of a movie;
+	// episodes without one play from YouTube. A self-hosted episode plays from /stream under its
+	// own imdb_id, so it needs one.
+	Stream    *StreamSource `json:"stream,omitempty" bson:"stream,omitempty"`
+	CreatedAt time.Time     `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at" bson:"updated_at"`
+}
+
+// Playback returns where the episode plays from: its stream source, or YouTube when it has none.
+func (e Episode) Playback() StreamSource {
+	if e.Stream != nil && e.Stream.SelfHosted() {
+		return *e.Stream
+	}
+	return StreamSource{Type: StreamYouTube, YouTubeID: e.YouTubeID}
+}
+
+// EpisodeProgress is a user's latest position in one episode.
+type EpisodeProgress struct {
+	ID              bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	UserID          string        `json:"user_id" bson:"user_id"`
+	SeriesID        string        `json:"series_id" bson:"series_id"`
+	SeasonNumber    int           `json:"season_number" bson:"season_number"`
+	EpisodeNumber   int           `json:"episode_number" bson:"episode_number"`
+	ProgressSeconds int           `json:"progress_seconds" bson:"progress_seconds" validate:"gte=0"`
+	Completed       bool          `json:"completed" bson:"completed"`
+	WatchedAt       time.Time     `json:"watched_at" bson:"watched_at"`
+}
+
+// SeriesDetail is a series title with its seasons in order.
+type SeriesDetail struct {
+	Movie   `bson:",inline"`
+	Seasons []Season `json:"seasons"`
+}
+
+// SeasonDetail is a season with its episodes in order.
+type SeasonDetail struct {
+	Season   `bson:",inline"`
+	Episodes []Episode `json:"episodes"`
+}
+
+// Why an episode is the one to watch next.
+const (
+	NextEpisodeStart  = "start"  // nothing of the series was watched yet
+	NextEpisodeResume = "resume" // the episode was started but not finished
+	NextEpisodeNext   = "next"   // the episode follows the last finished one
+)
+
+// NextEpisode is the episode a user should play next and where to start it.
+type NextEpisode struct {
+	Episode         Episode `json:"episode"`
+	ProgressSeconds int     `json:"progress_seconds"`
+	Reason          string  `json:"reason"`
+}
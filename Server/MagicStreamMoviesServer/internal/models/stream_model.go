@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Stream source types. YouTube titles play the trailer-style embed from YouTubeID; file and
+// hls titles are served by the API from the blob store.
+const (
+	StreamYouTube = "youtube"
+	StreamFile    = "file"
+	StreamHLS     = "hls"
+)
+
+// StreamSource is where a title plays from.
+type StreamSource struct {
+	Type string `json:"type" bson:"type" validate:"required,oneof=youtube file hls"`
+	// Key is the blob key of the media file, or of the master playlist for HLS. Segments and
+	// variant playlists are stored next to the master playlist.
+	Key string `json:"key,omitempty" bson:"key,omitempty" validate:"required_unless=Type youtube"`
+	// YouTubeID is set on the source returned for YouTube titles, from the movie's youtube_id.
+	YouTubeID string `json:"youtube_id,omitempty" bson:"-"`
+}
+
+// SelfHosted reports whether the API serves the stream itself.
+func (s StreamSource) SelfHosted() bool {
+	return s.Type == StreamFile || s.Type == StreamHLS
+}
+
+// PlaybackURL is a signed URL that plays a title without an Authorization header until it
+// expires.
+type PlaybackURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// SubtitleTrack is a caption track of a title, stored as WebVTT. A title has at most one track
+// per language.
+type SubtitleTrack struct {
+	// Language is a BCP 47 tag in canonical form, e.g. "en" or "pt-BR".
+	Language string `json:"language" bson:"language"`
+	// Label is the name players show in their captions menu.
+	Label   string `json:"label" bson:"label"`
+	Default bool   `json:"default" bson:"default"`
+	// URL serves the track at /movie/{imdb_id}/subtitles/{language}.vtt.
+	URL string `json:"url" bson:"url"`
+	// Key is the blob key of the WebVTT file, so it can be removed when replaced.
+	Key       string    `json:"-" bson:"key"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// SubtitleTrackRequest sets a track's label and default flag. Fields left out keep their
+// values; a new track is labelled with its language and is not the default.
+type SubtitleTrackRequest struct {
+	Label   *string `json:"label" form:"label" validate:"omitempty,min=1,max=100"`
+	Default *bool   `json:"default" form:"default"`
+}
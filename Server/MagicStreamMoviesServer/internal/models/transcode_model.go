@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Transcode job statuses. A job is queued, picked up by the worker as running and ends ready,
+// failed or canceled. Failed and canceled jobs can be queued again.
+const (
+	TranscodeQueued   = "queued"
+	TranscodeRunning  = "running"
+	TranscodeReady    = "ready"
+	TranscodeFailed   = "failed"
+	TranscodeCanceled = "canceled"
+)
+
+// TranscodeJob turns a title's uploaded media into an HLS ladder with thumbnails. The title
+// plays from the job's master playlist once the job is ready.
+type TranscodeJob struct {
+	ID        bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	ImdbID    string        `json:"imdb_id" bson:"imdb_id"`
+	SourceKey string        `json:"source_key" bson:"source_key"`
+	Status    string        `json:"status" bson:"status"`
+	// Progress is the share of the work done, from 0 to 1.
+	Progress float64 `json:"progress" bson:"progress"`
+	Attempts int     `json:"attempts" bson:"attempts"`
+	// PlaylistKey is the blob key of the master playlist, set once the job is ready.
+	PlaylistKey string               `json:"playlist_key,omitempty" bson:"playlist_key,omitempty"`
+	Renditions  []TranscodeRendition `json:"renditions,omitempty" bson:"renditions,omitempty"`
+	// Thumbnails are blob keys of stills spread over the video, in order.
+	Thumbnails []string   `json:"thumbnails,omitempty" bson:"thumbnails,omitempty"`
+	Error      string     `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedBy  string     `json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" bson:"updated_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+	// LeaseUntil is how long a running job stays claimed. Its worker keeps renewing it, and a
+	// job whose lease ran out is queued again.
+	LeaseUntil *time.Time `json:"-" bson:"lease_until,omitempty"`
+}
+
+// TranscodeRendition is one rung of a job's HLS ladder.
+type TranscodeRendition struct {
+	Name      string `json:"name" bson:"name"`
+	Width     int    `json:"width" bson:"width"`
+	Height    int    `json:"height" bson:"height"`
+	Bandwidth int    `json:"bandwidth" bson:"bandwidth"`
+	// PlaylistKey is the blob key of the rendition's variant playlist.
+	PlaylistKey string `json:"playlist_key" bson:"playlist_key"`
+}
+
+// TranscodeRequest queues a transcode of a title's media.
+type TranscodeRequest struct {
+	// SourceKey is the blob key of the media, such as the key of a completed upload. It
+	// defaults to the title's media file, or else the source of its last transcode.
+	SourceKey string `json:"source_key,omitempty"`
+}
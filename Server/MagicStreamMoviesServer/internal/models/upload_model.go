@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Upload is a resumable upload of a title's media over the tus protocol. The session is saved
+// so an interrupted upload can carry on after a server restart.
+type Upload struct {
+	ID          bson.ObjectID `json:"id" bson:"_id,omitempty"`
+	ImdbID      string        `json:"imdb_id" bson:"imdb_id"`
+	Filename    string        `json:"filename,omitempty" bson:"filename,omitempty"`
+	ContentType string        `json:"content_type,omitempty" bson:"content_type,omitempty"`
+	Length      int64         `json:"length" bson:"length"`
+	Offset      int64         `json:"offset" bson:"offset"`
+	// Metadata is the Upload-Metadata header the upload was created with, returned as is.
+	Metadata string `json:"-" bson:"metadata,omitempty"`
+	// Parts are the chunks received so far in order, each kept as a blob until the upload
+	// completes and they are joined.
+	Parts []UploadPart `json:"-" bson:"parts"`
+	// Key is the blob key of the joined media, set once the upload completes.
+	Key         string     `json:"key,omitempty" bson:"key,omitempty"`
+	CreatedBy   string     `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bson:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+	// JoiningUntil is set while the parts are being joined, so only one join runs at a time.
+	JoiningUntil *time.Time `json:"-" bson:"joining_until,omitempty"`
+}
+
+func (u Upload) Completed() bool {
+	return u.CompletedAt != nil
+}
+
+// UploadPart is a chunk of an upload, stored under its own blob key.
+type UploadPart struct {
+	Key    string `bson:"key"`
+	Offset int64  `bson:"offset"`
+	Size   int64  `bson:"size"`
+}
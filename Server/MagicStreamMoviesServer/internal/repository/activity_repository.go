@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type ActivityRepository interface {
+	UpsertWatchHistory(ctx context.Context, entry models.WatchHistory) error
+	GetWatchHistory(ctx context.Context, userId string) ([]models.WatchHistory, error)
+	UpsertReview(ctx context.Context, review models.Review) error
+	GetUserReviews(ctx context.Context, userId string) ([]models.Review, error)
+	GetSeenMovieIDs(ctx context.Context, userId string) ([]string, error)
+	GetAllWatchHistory(ctx context.Context) ([]models.WatchHistory, error)
+	GetAllReviews(ctx context.Context) ([]models.Review, error)
+}
+
+type mongoActivityRepository struct {
+	watchHistoryCollection *mongo.Collection
+	reviewCollection       *mongo.Collection
+}
+
+func NewActivityRepository(db *mongo.Database) ActivityRepository {
+	return &mongoActivityRepository{
+		watchHistoryCollection: db.Collection("watch_history"),
+		reviewCollection:       db.Collection("reviews"),
+	}
+}
+
+// UpsertWatchHistory keeps a single entry per user and movie, holding the latest progress. For a
+// series the entry also records the episode the progress belongs to.
+func (r *mongoActivityRepository) UpsertWatchHistory(ctx context.Context, entry models.WatchHistory) error {
+	filter := bson.M{"user_id": entry.UserID, "imdb_id": entry.ImdbID}
+	update := bson.M{
+		"$set": bson.M{
+			"progress_seconds": entry.ProgressSeconds,
+			"completed":        entry.Completed,
+			"watched_at":       entry.WatchedAt,
+			"season_number":    entry.SeasonNumber,
+			"episode_number":   entry.EpisodeNumber,
+		},
+	}
+	_, err := r.watchHistoryCollection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (r *mongoActivityRepository) GetWatchHistory(ctx context.Context, userId string) ([]models.WatchHistory, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "watched_at", Value: -1}})
+	cursor, err := r.watchHistoryCollection.Find(ctx, bson.M{"user_id": userId}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var history []models.WatchHistory
+	if err = cursor.All(ctx, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// UpsertReview keeps a single review per user and movie; reviewing again replaces the rating.
+func (r *mongoActivityRepository) UpsertReview(ctx context.Context, review models.Review) error {
+	filter := bson.M{"user_id": review.UserID, "imdb_id": review.ImdbID}
+	update := bson.M{
+		"$set": bson.M{
+			"rating":     review.Rating,
+			"comment":    review.Comment,
+			"created_at": review.CreatedAt,
+		},
+	}
+	_, err := r.reviewCollection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (r *mongoActivityRepository) GetUserReviews(ctx context.Context, userId string) ([]models.Review, error) {
+	cursor, err := r.reviewCollection.Find(ctx, bson.M{"user_id": userId})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []models.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GetSeenMovieIDs returns the imdb_ids the user has either watched or reviewed.
+func (r *mongoActivityRepository) GetSeenMovieIDs(ctx context.Context, userId string) ([]string, error) {
+	filter := bson.M{"user_id": userId}
+
+	var watched []string
+	if err := r.watchHistoryCollection.Distinct(ctx, "imdb_id", filter).Decode(&watched); err != nil {
+		return nil, err
+	}
+
+	var reviewed []string
+	if err := r.reviewCollection.Distinct(ctx, "imdb_id", filter).Decode(&reviewed); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(watched)+len(reviewed))
+	ids := make([]string, 0, len(watched)+len(reviewed))
+	for _, id := range append(watched, reviewed...) {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (r *mongoActivityRepository) GetAllWatchHistory(ctx context.Context) ([]models.WatchHistory, error) {
+	cursor, err := r.watchHistoryCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var history []models.WatchHistory
+	if err = cursor.All(ctx, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (r *mongoActivityRepository) GetAllReviews(ctx context.Context) ([]models.Review, error) {
+	cursor, err := r.reviewCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []models.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
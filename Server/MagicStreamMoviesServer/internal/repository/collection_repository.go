@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type CollectionRepository interface {
+	CreateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error)
+	GetCollection(ctx context.Context, id bson.ObjectID) (*models.Collection, error)
+	UpdateCollection(ctx context.Context, collection models.Collection) error
+	DeleteCollection(ctx context.Context, id bson.ObjectID) error
+	// GetCollections lists collections ordered by name.
+	GetCollections(ctx context.Context, skip int64, limit int64) ([]models.Collection, error)
+	// GetFeaturedCollections returns the collections featured on the home page, ordered by name.
+	GetFeaturedCollections(ctx context.Context) ([]models.Collection, error)
+	// GetCollectionsContaining returns every collection that lists the movie.
+	GetCollectionsContaining(ctx context.Context, imdbID string) ([]models.Collection, error)
+}
+
+type mongoCollectionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCollectionRepository(db *mongo.Database) CollectionRepository {
+	return &mongoCollectionRepository{
+		collection: db.Collection("collections"),
+	}
+}
+
+func (r *mongoCollectionRepository) CreateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error) {
+	now := time.Now().UTC()
+	collection.ID = bson.NewObjectID()
+	collection.CreatedAt = now
+	collection.UpdatedAt = now
+	if _, err := r.collection.InsertOne(ctx, collection); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *mongoCollectionRepository) GetCollection(ctx context.Context, id bson.ObjectID) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&collection); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *mongoCollectionRepository) UpdateCollection(ctx context.Context, collection models.Collection) error {
+	update := bson.M{
+		"$set": bson.M{
+			"name":        collection.Name,
+			"description": collection.Description,
+			"poster_path": collection.PosterPath,
+			"imdb_ids":    collection.ImdbIDs,
+			"featured":    collection.Featured,
+		},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": collection.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoCollectionRepository) DeleteCollection(ctx context.Context, id bson.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoCollectionRepository) GetCollections(ctx context.Context, skip int64, limit int64) ([]models.Collection, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+	return r.find(ctx, bson.M{}, findOptions)
+}
+
+func (r *mongoCollectionRepository) GetFeaturedCollections(ctx context.Context) ([]models.Collection, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	return r.find(ctx, bson.M{"featured": true}, findOptions)
+}
+
+func (r *mongoCollectionRepository) GetCollectionsContaining(ctx context.Context, imdbID string) ([]models.Collection, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	return r.find(ctx, bson.M{"imdb_ids": imdbID}, findOptions)
+}
+
+func (r *mongoCollectionRepository) find(ctx context.Context, filter bson.M, findOptions *options.FindOptionsBuilder) ([]models.Collection, error) {
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	collections := []models.Collection{}
+	if err = cursor.All(ctx, &collections); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type EmbeddingRepository interface {
+	UpsertEmbeddings(ctx context.Context, embeddings []models.MovieEmbedding) error
+	// GetEmbeddings returns every stored embedding made by model.
+	GetEmbeddings(ctx context.Context, model string) ([]models.MovieEmbedding, error)
+}
+
+type mongoEmbeddingRepository struct {
+	embeddingCollection *mongo.Collection
+}
+
+func NewEmbeddingRepository(db *mongo.Database) EmbeddingRepository {
+	return &mongoEmbeddingRepository{
+		embeddingCollection: db.Collection("movie_embeddings"),
+	}
+}
+
+func (r *mongoEmbeddingRepository) UpsertEmbeddings(ctx context.Context, embeddings []models.MovieEmbedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(embeddings))
+	for _, embedding := range embeddings {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"imdb_id": embedding.ImdbID}).
+			SetReplacement(embedding).
+			SetUpsert(true))
+	}
+	_, err := r.embeddingCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *mongoEmbeddingRepository) GetEmbeddings(ctx context.Context, model string) ([]models.MovieEmbedding, error) {
+	cursor, err := r.embeddingCollection.Find(ctx, bson.M{"model": model})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var embeddings []models.MovieEmbedding
+	if err = cursor.All(ctx, &embeddings); err != nil {
+		return nil, err
+	}
+	return embeddings, nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type EventRepository interface {
+	InsertEvent(ctx context.Context, event models.Event) error
+	// InsertEventOnce stores the event unless the same user already has an event of the same
+	// type for the movie since the given time, and reports whether it was stored.
+	InsertEventOnce(ctx context.Context, event models.Event, since time.Time) (bool, error)
+	AggregateTrendingScores(ctx context.Context, since time.Time, now time.Time, halfLife time.Duration, weights map[string]float64) ([]models.TrendingScore, error)
+	ReplaceTrendingScores(ctx context.Context, window string, scores []models.TrendingScore, computedAt time.Time) error
+	GetTrendingScores(ctx context.Context, window string, genre string, limit int64) ([]models.TrendingScore, error)
+}
+
+type mongoEventRepository struct {
+	eventCollection    *mongo.Collection
+	trendingCollection *mongo.Collection
+}
+
+func NewEventRepository(db *mongo.Database) EventRepository {
+	return &mongoEventRepository{
+		eventCollection:    db.Collection("events"),
+		trendingCollection: db.Collection("trending_scores"),
+	}
+}
+
+func (r *mongoEventRepository) InsertEvent(ctx context.Context, event models.Event) error {
+	_, err := r.eventCollection.InsertOne(ctx, event)
+	return err
+}
+
+func (r *mongoEventRepository) InsertEventOnce(ctx context.Context, event models.Event, since time.Time) (bool, error) {
+	filter := bson.M{
+		"user_id":    event.UserID,
+		"imdb_id":    event.ImdbID,
+		"type":       event.Type,
+		"created_at": bson.M{"$gte": since},
+	}
+	result, err := r.eventCollection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": event}, options.UpdateOne().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// AggregateTrendingScores sums the events of each movie since the given time. Every event counts
+// with the weight of its type, halved for every halfLife that has passed since it happened.
+func (r *mongoEventRepository) AggregateTrendingScores(ctx context.Context, since time.Time, now time.Time, halfLife time.Duration, weights map[string]float64) ([]models.TrendingScore, error) {
+	branches := bson.A{}
+	for eventType, weight := range weights {
+		branches = append(branches, bson.D{
+			{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{"$type", eventType}}}},
+			{Key: "then", Value: weight},
+		})
+	}
+	decayPerMs := -math.Ln2 / float64(halfLife.Milliseconds())
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "created_at", Value: bson.D{{Key: "$gte", Value: since}}}}}},
+		{{Key: "$addFields", Value: bson.D{
+			{Key: "weight", Value: bson.D{{Key: "$switch", Value: bson.D{
+				{Key: "branches", Value: branches},
+				{Key: "default", Value: 0},
+			}}}},
+			{Key: "decay", Value: bson.D{{Key: "$exp", Value: bson.D{{Key: "$multiply", Value: bson.A{
+				decayPerMs,
+				bson.D{{Key: "$subtract", Value: bson.A{now, "$created_at"}}},
+			}}}}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$imdb_id"},
+			{Key: "score", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$multiply", Value: bson.A{"$weight", "$decay"}}}}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "score", Value: bson.D{{Key: "$gt", Value: 0}}}}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "imdb_id", Value: "$_id"},
+			{Key: "score", Value: 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}}}},
+	}
+
+	cursor, err := r.eventCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var scores []models.TrendingScore
+	if err = cursor.All(ctx, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// ReplaceTrendingScores swaps in a freshly computed window: new scores are upserted and scores
+// from earlier runs that were not recomputed are removed.
+func (r *mongoEventRepository) ReplaceTrendingScores(ctx context.Context, window string, scores []models.TrendingScore, computedAt time.Time) error {
+	if len(scores) > 0 {
+		writes := make([]mongo.WriteModel, 0, len(scores))
+		for _, score := range scores {
+			writes = append(writes, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"window": window, "imdb_id": score.ImdbID}).
+				SetReplacement(score).
+				SetUpsert(true))
+		}
+		if _, err := r.trendingCollection.BulkWrite(ctx, writes); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.trendingCollection.DeleteMany(ctx, bson.M{"window": window, "computed_at": bson.M{"$lt": computedAt}})
+	return err
+}
+
+func (r *mongoEventRepository) GetTrendingScores(ctx context.Context, window string, genre string, limit int64) ([]models.TrendingScore, error) {
+	filter := bson.M{"window": window}
+	if genre != "" {
+		filter["genres"] = genre
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "score", Value: -1}}).SetLimit(limit)
+
+	cursor, err := r.trendingCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var scores []models.TrendingScore
+	if err = cursor.All(ctx, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type GenreRepository interface {
+	// GetGenres lists every genre ordered by name.
+	GetGenres(ctx context.Context) ([]models.Genre, error)
+	GetGenre(ctx context.Context, id int) (*models.Genre, error)
+	GetGenresByIDs(ctx context.Context, ids []int) ([]models.Genre, error)
+	// GetGenreByName finds a genre by name, ignoring case.
+	GetGenreByName(ctx context.Context, name string) (*models.Genre, error)
+	// CreateGenre stores a genre under the next free genre_id.
+	CreateGenre(ctx context.Context, name string) (*models.Genre, error)
+	UpdateGenre(ctx context.Context, genre models.Genre) error
+	DeleteGenre(ctx context.Context, id int) error
+}
+
+type mongoGenreRepository struct {
+	collection *mongo.Collection
+}
+
+func NewGenreRepository(db *mongo.Database) GenreRepository {
+	return &mongoGenreRepository{
+		collection: db.Collection("genres"),
+	}
+}
+
+// caseInsensitive compares strings ignoring case.
+var caseInsensitive = &options.Collation{Locale: "en", Strength: 2}
+
+func (r *mongoGenreRepository) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "genre_name", Value: 1}, {Key: "genre_id", Value: 1}}).
+		SetCollation(caseInsensitive)
+	return r.find(ctx, bson.M{}, findOptions)
+}
+
+func (r *mongoGenreRepository) GetGenre(ctx context.Context, id int) (*models.Genre, error) {
+	var genre models.Genre
+	if err := r.collection.FindOne(ctx, bson.M{"genre_id": id}).Decode(&genre); err != nil {
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *mongoGenreRepository) GetGenresByIDs(ctx context.Context, ids []int) ([]models.Genre, error) {
+	return r.find(ctx, bson.M{"genre_id": bson.M{"$in": ids}}, options.Find())
+}
+
+func (r *mongoGenreRepository) GetGenreByName(ctx context.Context, name string) (*models.Genre, error) {
+	var genre models.Genre
+	findOptions := options.FindOne().SetCollation(caseInsensitive)
+	if err := r.collection.FindOne(ctx, bson.M{"genre_name": name}, findOptions).Decode(&genre); err != nil {
+		return nil, err
+	}
+	return &genre, nil
+}
+
+// createGenreAttempts bounds the retries of CreateGenre when concurrent creates pick the same ID.
+const createGenreAttempts = 5
+
+func (r *mongoGenreRepository) CreateGenre(ctx context.Context, name string) (*models.Genre, error) {
+	// genre_id is uniquely indexed, so a concurrent create fails instead of reusing an ID, and
+	// the create is tried again with the next one.
+	var err error
+	for range createGenreAttempts {
+		var last models.Genre
+		findOptions := options.FindOne().SetSort(bson.D{{Key: "genre_id", Value: -1}})
+		err = r.collection.FindOne(ctx, bson.M{}, findOptions).Decode(&last)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		genre := models.Genre{GenreID: last.GenreID + 1, GenreName: name}
+		_, err = r.collection.InsertOne(ctx, genre)
+		if err == nil {
+			return &genre, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func (r *mongoGenreRepository) UpdateGenre(ctx context.Context, genre models.Genre) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"genre_id": genre.GenreID},
+		bson.M{"$set": bson.M{"genre_name": genre.GenreName}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoGenreRepository) DeleteGenre(ctx context.Context, id int) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"genre_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoGenreRepository) find(ctx context.Context, filter bson.M, findOptions *options.FindOptionsBuilder) ([]models.Genre, error) {
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	genres := []models.Genre{}
+	if err = cursor.All(ctx, &genres); err != nil {
+		return nil, err
+	}
+	return genres, nil
+}
+
+// Movies and users embed copies of the genres they reference. The helpers below keep the copies
+// in the array field of coll in step with the genres collection.
+
+// renameGenreCopies updates the name of every embedded copy of genre.
+func renameGenreCopies(ctx context.Context, coll *mongo.Collection, field string, genre models.Genre) error {
+	_, err := coll.UpdateMany(ctx,
+		bson.M{field + ".genre_id": genre.GenreID},
+		bson.M{
+			"$set":         bson.M{field + ".$[g].genre_name": genre.GenreName},
+			"$currentDate": bson.M{"updated_at": true},
+		},
+		options.UpdateMany().SetArrayFilters([]any{bson.M{"g.genre_id": genre.GenreID}}),
+	)
+	return err
+}
+
+// mergeGenreCopies replaces the copies of genre from with target. Documents that already carry
+// target just drop from, so no document ends up listing target twice.
+func mergeGenreCopies(ctx context.Context, coll *mongo.Collection, field string, from int, target models.Genre) error {
+	if _, err := coll.UpdateMany(ctx,
+		bson.M{"$and": bson.A{
+			bson.M{field + ".genre_id": from},
+			bson.M{field + ".genre_id": target.GenreID},
+		}},
+		bson.M{
+			"$pull":        bson.M{field: bson.M{"genre_id": from}},
+			"$currentDate": bson.M{"updated_at": true},
+		},
+	); err != nil {
+		return err
+	}
+	_, err := coll.UpdateMany(ctx,
+		bson.M{field + ".genre_id": from},
+		bson.M{
+			"$set": bson.M{
+				field + ".$[g].genre_id":   target.GenreID,
+				field + ".$[g].genre_name": target.GenreName,
+			},
+			"$currentDate": bson.M{"updated_at": true},
+		},
+		options.UpdateMany().SetArrayFilters([]any{bson.M{"g.genre_id": from}}),
+	)
+	return err
+}
+
+// removeGenreCopies drops every embedded copy of the genre.
+func removeGenreCopies(ctx context.Context, coll *mongo.Collection, field string, id int) error {
+	_, err := coll.UpdateMany(ctx,
+		bson.M{field + ".genre_id": id},
+		bson.M{
+			"$pull":        bson.M{field: bson.M{"genre_id": id}},
+			"$currentDate": bson.M{"updated_at": true},
+		},
+	)
+	return err
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"time"
 
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -12,11 +15,54 @@ import (
 type MovieRepository interface {
 	GetMovies(ctx context.Context) ([]models.Movie, error)
 	GetMovie(ctx context.Context, imdbID string) (*models.Movie, error)
+	GetMoviesByIDs(ctx context.Context, imdbIDs []string) ([]models.Movie, error)
 	CreateMovie(ctx context.Context, movie models.Movie) (*mongo.InsertOneResult, error)
+	// UpsertMovies replaces the movies with the same imdb_id, or inserts them, in one round trip.
+	UpsertMovies(ctx context.Context, movies []models.Movie) error
 	UpdateMovieReview(ctx context.Context, imdbID string, review string, sentiment string, rankVal int) (*mongo.UpdateResult, error)
 	GetRankings(ctx context.Context) ([]models.Ranking, error)
-	GetRecommendedMovies(ctx context.Context, genres []string, limit int64) ([]models.Movie, error)
-	GetAllGenres(ctx context.Context) ([]models.Genre, error)
+	GetRecommendedMovies(ctx context.Context, genres []string, excludeIDs []string, skip int64, limit int64) ([]models.Movie, error)
+	GetNewestMovies(ctx context.Context, skip int64, limit int64) ([]models.Movie, error)
+	FilterMovies(ctx context.Context, imdbIDs []string, filters models.MovieFilters) ([]models.Movie, error)
+	GetFacets(ctx context.Context, imdbIDs []string, filters models.MovieFilters) (*models.Facets, error)
+	// StreamMovies calls fn for each movie matching filters, ordered by title, reading them
+	// from a cursor one at a time. The review figures are only filled in when withRatings is
+	// set. It stops at the first error fn returns.
+	StreamMovies(ctx context.Context, filters models.MovieFilters, withRatings bool, fn func(movie models.MovieExport) error) error
+	// SetMovieMetadata saves the fields filled in from a metadata provider: title, poster,
+	// genres, runtime, synopsis and release date.
+	SetMovieMetadata(ctx context.Context, movie models.Movie) error
+	// SetMoviePoster points poster_path at an uploaded poster and records its copies.
+	SetMoviePoster(ctx context.Context, imdbID string, posterPath string, image models.ImageSet) error
+	// AddMovieBackdrop appends an uploaded backdrop to the movie's backdrops, unless the movie
+	// already has limit backdrops, in which case it returns mongo.ErrNoDocuments.
+	AddMovieBackdrop(ctx context.Context, imdbID string, backdropPath string, image models.ImageSet, limit int) error
+	// SetMovieStream saves the movie's stream source; nil removes it, so the movie plays from
+	// YouTube again.
+	SetMovieStream(ctx context.Context, imdbID string, source *models.StreamSource) error
+	// SaveMovieSubtitleTrack points the movie's track in the track's language at a new file,
+	// keeping its label and default flag, or adds the track when the movie has none in that
+	// language. It returns the track as it was before, or nil when it was added.
+	SaveMovieSubtitleTrack(ctx context.Context, imdbID string, track models.SubtitleTrack) (*models.SubtitleTrack, error)
+	// UpdateMovieSubtitleTrack sets the label and default flag of the movie's track in the
+	// language, leaving nil fields alone. Making it the default clears the flag on the other
+	// tracks. It returns mongo.ErrNoDocuments when there is no such track.
+	UpdateMovieSubtitleTrack(ctx context.Context, imdbID string, lang string, label *string, isDefault *bool) error
+	// DeleteMovieSubtitleTrack removes the movie's track in the language and returns it, or
+	// mongo.ErrNoDocuments when there is no such track.
+	DeleteMovieSubtitleTrack(ctx context.Context, imdbID string, lang string) (*models.SubtitleTrack, error)
+	SetMovieDraft(ctx context.Context, imdbID string, draft models.MovieDraft) error
+	PublishMovieDraft(ctx context.Context, imdbID string, draft models.MovieDraft) error
+	ClearMovieDraft(ctx context.Context, imdbID string) error
+	SetMovieCreditNames(ctx context.Context, imdbID string, names []string) error
+	SetMovieEpisodeTitles(ctx context.Context, imdbID string, titles []string) error
+	SetMovieCollections(ctx context.Context, imdbID string, collections []models.CollectionMembership) error
+	// GetMovieIDsWithGenre returns the imdb_ids of the movies in the genre.
+	GetMovieIDsWithGenre(ctx context.Context, genreID int) ([]string, error)
+	// RenameGenre updates the genre's name on every movie in it.
+	RenameGenre(ctx context.Context, genre models.Genre) error
+	// MergeGenre moves every movie in genre from into target.
+	MergeGenre(ctx context.Context, from int, target models.Genre) error
 }
 
 type mongoMovieRepository struct {
@@ -54,10 +100,61 @@ func (r *mongoMovieRepository) GetMovie(ctx context.Context, imdbID string) (*mo
 	return &movie, nil
 }
 
+func (r *mongoMovieRepository) GetMoviesByIDs(ctx context.Context, imdbIDs []string) ([]models.Movie, error) {
+	if len(imdbIDs) == 0 {
+		return []models.Movie{}, nil
+	}
+
+	cursor, err := r.movieCollection.Find(ctx, bson.M{"imdb_id": bson.M{"$in": imdbIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []models.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
+
+// CreateMovie stamps the movie's created_at and updated_at before inserting it. Titles without a
+// content type are stored as movies.
 func (r *mongoMovieRepository) CreateMovie(ctx context.Context, movie models.Movie) (*mongo.InsertOneResult, error) {
+	if movie.ContentType == "" {
+		movie.ContentType = models.ContentTypeMovie
+	}
+	now := time.Now().UTC()
+	movie.CreatedAt = now
+	movie.UpdatedAt = now
 	return r.movieCollection.InsertOne(ctx, movie)
 }
 
+// UpsertMovies keeps the created_at of replaced movies and stamps updated_at. Titles without a
+// content type are stored as movies.
+func (r *mongoMovieRepository) UpsertMovies(ctx context.Context, movies []models.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	writes := make([]mongo.WriteModel, 0, len(movies))
+	for _, movie := range movies {
+		if movie.ContentType == "" {
+			movie.ContentType = models.ContentTypeMovie
+		}
+		if movie.CreatedAt.IsZero() {
+			movie.CreatedAt = now
+		}
+		movie.UpdatedAt = now
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"imdb_id": movie.ImdbID}).
+			SetReplacement(movie).
+			SetUpsert(true))
+	}
+	_, err := r.movieCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
 func (r *mongoMovieRepository) UpdateMovieReview(ctx context.Context, imdbID string, review string, sentiment string, rankVal int) (*mongo.UpdateResult, error) {
 	filter := bson.M{"imdb_id": imdbID}
 	update := bson.M{
@@ -68,10 +165,196 @@ func (r *mongoMovieRepository) UpdateMovieReview(ctx context.Context, imdbID str
 				"ranking_value": rankVal,
 			},
 		},
+		"$currentDate": bson.M{"updated_at": true},
 	}
 	return r.movieCollection.UpdateOne(ctx, filter, update)
 }
 
+func (r *mongoMovieRepository) SetMovieMetadata(ctx context.Context, movie models.Movie) error {
+	return r.updateMovie(ctx, movie.ImdbID, bson.M{"$set": bson.M{
+		"title":        movie.Title,
+		"poster_path":  movie.PosterPath,
+		"genre":        movie.Genre,
+		"runtime":      movie.Runtime,
+		"synopsis":     movie.Synopsis,
+		"release_date": movie.ReleaseDate,
+	}})
+}
+
+func (r *mongoMovieRepository) SetMoviePoster(ctx context.Context, imdbID string, posterPath string, image models.ImageSet) error {
+	return r.updateMovie(ctx, imdbID, bson.M{"$set": bson.M{
+		"poster_path":   posterPath,
+		"images.poster": image,
+	}})
+}
+
+func (r *mongoMovieRepository) AddMovieBackdrop(ctx context.Context, imdbID string, backdropPath string, image models.ImageSet, limit int) error {
+	// The limit is part of the filter, so concurrent uploads cannot push past it.
+	filter := bson.M{
+		"imdb_id":                            imdbID,
+		"backdrops." + strconv.Itoa(limit-1): bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$push": bson.M{
+			"backdrops":        backdropPath,
+			"images.backdrops": image,
+		},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	result, err := r.movieCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoMovieRepository) SetMovieStream(ctx context.Context, imdbID string, source *models.StreamSource) error {
+	if source == nil {
+		return r.updateMovie(ctx, imdbID, bson.M{"$unset": bson.M{"stream": ""}})
+	}
+	return r.updateMovie(ctx, imdbID, bson.M{"$set": bson.M{"stream": source}})
+}
+
+// Subtitle tracks are changed one element at a time, so concurrent edits of different tracks
+// do not overwrite each other.
+
+func (r *mongoMovieRepository) SaveMovieSubtitleTrack(ctx context.Context, imdbID string, track models.SubtitleTrack) (*models.SubtitleTrack, error) {
+	// A concurrent upload may add the track between the two updates, so the replace is tried
+	// again once.
+	for range 2 {
+		filter := bson.M{"imdb_id": imdbID, "subtitles.language": track.Language}
+		update := bson.M{
+			"$set": bson.M{
+				"subtitles.$.key":        track.Key,
+				"subtitles.$.url":        track.URL,
+				"subtitles.$.updated_at": track.UpdatedAt,
+			},
+			"$currentDate": bson.M{"updated_at": true},
+		}
+		previous, err := r.findSubtitleTrackAndUpdate(ctx, filter, update)
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return previous, err
+		}
+
+		// The filter keeps a concurrent upload from adding a second track in the language.
+		filter = bson.M{"imdb_id": imdbID, "subtitles.language": bson.M{"$ne": track.Language}}
+		update = bson.M{
+			"$push":        bson.M{"subtitles": track},
+			"$currentDate": bson.M{"updated_at": true},
+		}
+		result, err := r.movieCollection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, err
+		}
+		if result.MatchedCount > 0 {
+			return nil, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *mongoMovieRepository) UpdateMovieSubtitleTrack(ctx context.Context, imdbID string, lang string, label *string, isDefault *bool) error {
+	set := bson.M{"subtitles.$[track].updated_at": time.Now().UTC()}
+	arrayFilters := []any{bson.M{"track.language": lang}}
+	if label != nil {
+		set["subtitles.$[track].label"] = *label
+	}
+	if isDefault != nil {
+		set["subtitles.$[track].default"] = *isDefault
+		if *isDefault {
+			set["subtitles.$[other].default"] = false
+			arrayFilters = append(arrayFilters, bson.M{"other.language": bson.M{"$ne": lang}})
+		}
+	}
+	result, err := r.movieCollection.UpdateOne(ctx,
+		bson.M{"imdb_id": imdbID, "subtitles.language": lang},
+		bson.M{"$set": set, "$currentDate": bson.M{"updated_at": true}},
+		options.UpdateOne().SetArrayFilters(arrayFilters),
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoMovieRepository) DeleteMovieSubtitleTrack(ctx context.Context, imdbID string, lang string) (*models.SubtitleTrack, error) {
+	filter := bson.M{"imdb_id": imdbID, "subtitles.language": lang}
+	update := bson.M{
+		"$pull":        bson.M{"subtitles": bson.M{"language": lang}},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.findSubtitleTrackAndUpdate(ctx, filter, update)
+}
+
+// findSubtitleTrackAndUpdate applies update to the movie matching filter, which names a track
+// by language, and returns that track as it was before.
+func (r *mongoMovieRepository) findSubtitleTrackAndUpdate(ctx context.Context, filter bson.M, update bson.M) (*models.SubtitleTrack, error) {
+	var before struct {
+		Subtitles []models.SubtitleTrack `bson:"subtitles"`
+	}
+	opts := options.FindOneAndUpdate().SetProjection(bson.M{"subtitles.$": 1})
+	if err := r.movieCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&before); err != nil {
+		return nil, err
+	}
+	if len(before.Subtitles) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &before.Subtitles[0], nil
+}
+
+// SetMovieDraft stores a generated draft next to the published fields, replacing any earlier draft.
+func (r *mongoMovieRepository) SetMovieDraft(ctx context.Context, imdbID string, draft models.MovieDraft) error {
+	return r.updateMovie(ctx, imdbID, bson.M{"$set": bson.M{"ai_draft": draft}})
+}
+
+// PublishMovieDraft copies the draft into the published fields and removes it.
+func (r *mongoMovieRepository) PublishMovieDraft(ctx context.Context, imdbID string, draft models.MovieDraft) error {
+	return r.updateMovie(ctx, imdbID, bson.M{
+		"$set": bson.M{
+			"synopsis":  draft.Synopsis,
+			"keywords":  draft.Keywords,
+			"mood_tags": draft.MoodTags,
+		},
+		"$unset": bson.M{"ai_draft": ""},
+	})
+}
+
+func (r *mongoMovieRepository) ClearMovieDraft(ctx context.Context, imdbID string) error {
+	return r.updateMovie(ctx, imdbID, bson.M{"$unset": bson.M{"ai_draft": ""}})
+}
+
+func (r *mongoMovieRepository) SetMovieCreditNames(ctx context.Context, imdbID string, names []string) error {
+	return r.updateMovie(ctx, imdbID, bson.M{"$set": bson.M{"credit_names": names}})
+}
+
+func (r *mongoMovieRepository) SetMovieEpisodeTitles(ctx context.Context, imdbID string, titles []string) error {
+	return r.updateMovie(ctx, imdbID, bson.M{"$set": bson.M{"episode_titles": titles}})
+}
+
+func (r *mongoMovieRepository) SetMovieCollections(ctx context.Context, imdbID string, collections []models.CollectionMembership) error {
+	return r.updateMovie(ctx, imdbID, bson.M{"$set": bson.M{"collections": collections}})
+}
+
+// updateMovie applies update to a single movie, bumping its updated_at, and reports
+// mongo.ErrNoDocuments when it does not exist.
+func (r *mongoMovieRepository) updateMovie(ctx context.Context, imdbID string, update bson.M) error {
+	update["$currentDate"] = bson.M{"updated_at": true}
+	result, err := r.movieCollection.UpdateOne(ctx, bson.M{"imdb_id": imdbID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
 func (r *mongoMovieRepository) GetRankings(ctx context.Context) ([]models.Ranking, error) {
 	var rankings []models.Ranking
 	cursor, err := r.rankingCollection.Find(ctx, bson.M{})
@@ -86,12 +369,21 @@ func (r *mongoMovieRepository) GetRankings(ctx context.Context) ([]models.Rankin
 	return rankings, nil
 }
 
-func (r *mongoMovieRepository) GetRecommendedMovies(ctx context.Context, genres []string, limit int64) ([]models.Movie, error) {
+// GetRecommendedMovies returns the best ranked movies in the given genres, skipping excludeIDs.
+// An empty genres list matches every movie, which gives the global top-ranked list.
+func (r *mongoMovieRepository) GetRecommendedMovies(ctx context.Context, genres []string, excludeIDs []string, skip int64, limit int64) ([]models.Movie, error) {
 	findOptions := options.Find()
-	findOptions.SetSort(bson.D{{Key: "ranking.ranking_value", Value: 1}})
+	findOptions.SetSort(bson.D{{Key: "ranking.ranking_value", Value: 1}, {Key: "_id", Value: 1}})
+	findOptions.SetSkip(skip)
 	findOptions.SetLimit(limit)
 
-	filter := bson.M{"genre.genre_name": bson.M{"$in": genres}}
+	filter := bson.M{}
+	if len(genres) > 0 {
+		filter["genre.genre_name"] = bson.M{"$in": genres}
+	}
+	if len(excludeIDs) > 0 {
+		filter["imdb_id"] = bson.M{"$nin": excludeIDs}
+	}
 
 	cursor, err := r.movieCollection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -106,22 +398,67 @@ func (r *mongoMovieRepository) GetRecommendedMovies(ctx context.Context, genres
 	return recommendedMovies, nil
 }
 
-func (r *mongoMovieRepository) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
-	// We use aggregation to unwind the genres array and then group by genre_id to get unique genres
-	pipeline := mongo.Pipeline{
-		{{Key: "$unwind", Value: "$genre"}},
-		{{Key: "$group", Value: bson.D{
-			{Key: "_id", Value: "$genre.genre_id"},
-			{Key: "genre_name", Value: bson.D{{Key: "$first", Value: "$genre.genre_name"}}},
-			{Key: "genre_id", Value: bson.D{{Key: "$first", Value: "$genre.genre_id"}}},
-		}}},
-		{{Key: "$project", Value: bson.D{
-			{Key: "_id", Value: 0},
-			{Key: "genre_id", Value: 1},
-			{Key: "genre_name", Value: 1},
-		}}},
-		{{Key: "$sort", Value: bson.D{{Key: "genre_name", Value: 1}}}},
+// GetNewestMovies orders by _id, whose ObjectID embeds the insertion time.
+func (r *mongoMovieRepository) GetNewestMovies(ctx context.Context, skip int64, limit int64) ([]models.Movie, error) {
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "_id", Value: -1}})
+	findOptions.SetSkip(skip)
+	findOptions.SetLimit(limit)
+
+	cursor, err := r.movieCollection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []models.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
+
+func (r *mongoMovieRepository) GetMovieIDsWithGenre(ctx context.Context, genreID int) ([]string, error) {
+	findOptions := options.Find().SetProjection(bson.M{"imdb_id": 1})
+	cursor, err := r.movieCollection.Find(ctx, bson.M{"genre.genre_id": genreID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []models.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, err
 	}
+	imdbIDs := make([]string, 0, len(movies))
+	for _, movie := range movies {
+		imdbIDs = append(imdbIDs, movie.ImdbID)
+	}
+	return imdbIDs, nil
+}
+
+func (r *mongoMovieRepository) RenameGenre(ctx context.Context, genre models.Genre) error {
+	return renameGenreCopies(ctx, r.movieCollection, "genre", genre)
+}
+
+func (r *mongoMovieRepository) MergeGenre(ctx context.Context, from int, target models.Genre) error {
+	return mergeGenreCopies(ctx, r.movieCollection, "genre", from, target)
+}
+
+// Facet names, used as the output fields of the $facet stage.
+const (
+	facetGenres   = "genres"
+	facetRankings = "rankings"
+	facetDecades  = "decades"
+	facetRatings  = "ratings"
+	facetTypes    = "types"
+)
+
+// FilterMovies returns the movies matching filters. A non-nil imdbIDs restricts the result to
+// those movies, which is how search hits are narrowed down.
+func (r *mongoMovieRepository) FilterMovies(ctx context.Context, imdbIDs []string, filters models.MovieFilters) ([]models.Movie, error) {
+	pipeline := facetBaseStages(imdbIDs, len(filters.Ratings) > 0)
+	pipeline = append(pipeline, bson.D{{Key: "$match", Value: facetMatch(filters, "")}})
 
 	cursor, err := r.movieCollection.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -129,9 +466,150 @@ func (r *mongoMovieRepository) GetAllGenres(ctx context.Context) ([]models.Genre
 	}
 	defer cursor.Close(ctx)
 
-	var genres []models.Genre
-	if err = cursor.All(ctx, &genres); err != nil {
+	var movies []models.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
 		return nil, err
 	}
-	return genres, nil
+	return movies, nil
+}
+
+func (r *mongoMovieRepository) StreamMovies(ctx context.Context, filters models.MovieFilters, withRatings bool, fn func(movie models.MovieExport) error) error {
+	pipeline := facetBaseStages(nil, withRatings || len(filters.Ratings) > 0)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: facetMatch(filters, "")}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "title", Value: 1}, {Key: "imdb_id", Value: 1}}}},
+	)
+
+	cursor, err := r.movieCollection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var movie models.MovieExport
+		if err := cursor.Decode(&movie); err != nil {
+			return err
+		}
+		if err := fn(movie); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// GetFacets counts the movies per genre, ranking name, release decade, rating bucket and content type in a
+// single $facet aggregation. Each facet is counted with the selections of the other facets
+// applied, but not its own.
+func (r *mongoMovieRepository) GetFacets(ctx context.Context, imdbIDs []string, filters models.MovieFilters) (*models.Facets, error) {
+	byCount := bson.D{{Key: "count", Value: -1}, {Key: "value", Value: 1}}
+	byValue := bson.D{{Key: "value", Value: 1}}
+
+	facetStage := bson.D{}
+	for _, facet := range []struct {
+		name  string
+		field string
+		sort  bson.D
+	}{
+		{facetGenres, "$genre.genre_name", byCount},
+		{facetRankings, "$ranking.ranking_name", byCount},
+		{facetDecades, "$decade", byValue},
+		{facetRatings, "$rating_bucket", byValue},
+		{facetTypes, "$content_type", byValue},
+	} {
+		stages := bson.A{bson.D{{Key: "$match", Value: facetMatch(filters, facet.name)}}}
+		if facet.name == facetGenres {
+			stages = append(stages, bson.D{{Key: "$unwind", Value: "$genre"}})
+		}
+		stages = append(stages,
+			bson.D{{Key: "$match", Value: bson.D{{Key: facet.field[1:], Value: bson.D{{Key: "$ne", Value: nil}}}}}},
+			bson.D{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: facet.field},
+				{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			}}},
+			bson.D{{Key: "$project", Value: bson.D{
+				{Key: "_id", Value: 0},
+				{Key: "value", Value: bson.D{{Key: "$toString", Value: "$_id"}}},
+				{Key: "count", Value: 1},
+			}}},
+			bson.D{{Key: "$sort", Value: facet.sort}},
+		)
+		facetStage = append(facetStage, bson.E{Key: facet.name, Value: stages})
+	}
+
+	pipeline := facetBaseStages(imdbIDs, true)
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: facetStage}})
+
+	cursor, err := r.movieCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []models.Facets
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	facets := &models.Facets{}
+	if len(results) > 0 {
+		facets = &results[0]
+	}
+	return facets, nil
+}
+
+// facetBaseStages restricts the movies to imdbIDs, when given, and derives the decade field
+// from the release date. The average_rating, review_count and rating_bucket fields, the last
+// being the floor of the average user rating, need a lookup of every review and are only added
+// when withRatings is set.
+func facetBaseStages(imdbIDs []string, withRatings bool) mongo.Pipeline {
+	pipeline := mongo.Pipeline{}
+	if imdbIDs != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "imdb_id", Value: bson.D{{Key: "$in", Value: imdbIDs}}}}}})
+	}
+
+	year := bson.D{{Key: "$year", Value: "$release_date"}}
+	pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.D{
+		{Key: "decade", Value: bson.D{{Key: "$subtract", Value: bson.A{year, bson.D{{Key: "$mod", Value: bson.A{year, 10}}}}}}},
+	}}})
+
+	if withRatings {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.D{
+				{Key: "from", Value: "reviews"},
+				{Key: "localField", Value: "imdb_id"},
+				{Key: "foreignField", Value: "imdb_id"},
+				{Key: "as", Value: "user_reviews"},
+			}}},
+			bson.D{{Key: "$addFields", Value: bson.D{
+				{Key: "average_rating", Value: bson.D{{Key: "$avg", Value: "$user_reviews.rating"}}},
+				{Key: "review_count", Value: bson.D{{Key: "$size", Value: "$user_reviews"}}},
+			}}},
+			bson.D{{Key: "$addFields", Value: bson.D{
+				{Key: "rating_bucket", Value: bson.D{{Key: "$floor", Value: "$average_rating"}}},
+			}}},
+			bson.D{{Key: "$project", Value: bson.D{{Key: "user_reviews", Value: 0}}}},
+		)
+	}
+	return pipeline
+}
+
+// facetMatch builds the filter for every facet selection except the one named by skip.
+func facetMatch(filters models.MovieFilters, skip string) bson.D {
+	match := bson.D{}
+	if len(filters.Genres) > 0 && skip != facetGenres {
+		match = append(match, bson.E{Key: "genre.genre_name", Value: bson.D{{Key: "$in", Value: filters.Genres}}})
+	}
+	if len(filters.Rankings) > 0 && skip != facetRankings {
+		match = append(match, bson.E{Key: "ranking.ranking_name", Value: bson.D{{Key: "$in", Value: filters.Rankings}}})
+	}
+	if len(filters.Decades) > 0 && skip != facetDecades {
+		match = append(match, bson.E{Key: "decade", Value: bson.D{{Key: "$in", Value: filters.Decades}}})
+	}
+	if len(filters.Ratings) > 0 && skip != facetRatings {
+		match = append(match, bson.E{Key: "rating_bucket", Value: bson.D{{Key: "$in", Value: filters.Ratings}}})
+	}
+	if len(filters.Types) > 0 && skip != facetTypes {
+		match = append(match, bson.E{Key: "content_type", Value: bson.D{{Key: "$in", Value: filters.Types}}})
+	}
+	return match
 }
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type PersonRepository interface {
+	CreatePerson(ctx context.Context, person models.Person) (*models.Person, error)
+	GetPerson(ctx context.Context, id bson.ObjectID) (*models.Person, error)
+	UpdatePerson(ctx context.Context, person models.Person) error
+	DeletePerson(ctx context.Context, id bson.ObjectID) error
+	// SearchPeople lists people ordered by name, optionally only those whose name contains query.
+	SearchPeople(ctx context.Context, query string, skip int64, limit int64) ([]models.Person, error)
+
+	CreateCredit(ctx context.Context, credit models.Credit) (*models.Credit, error)
+	GetCredit(ctx context.Context, id bson.ObjectID) (*models.Credit, error)
+	DeleteCredit(ctx context.Context, id bson.ObjectID) error
+	// DeleteCreditsForPerson removes every credit of the person and returns the affected movies.
+	DeleteCreditsForPerson(ctx context.Context, personID bson.ObjectID) ([]string, error)
+	// GetMovieCredits returns a movie's credits with their people, in billing order.
+	GetMovieCredits(ctx context.Context, imdbID string) ([]models.MovieCredit, error)
+	// GetFilmography returns a page of the person's credits with their movies, newest first.
+	GetFilmography(ctx context.Context, personID bson.ObjectID, skip int64, limit int64) ([]models.FilmographyEntry, error)
+	GetCreditedMovieIDs(ctx context.Context, personID bson.ObjectID) ([]string, error)
+}
+
+type mongoPersonRepository struct {
+	personCollection *mongo.Collection
+	creditCollection *mongo.Collection
+}
+
+func NewPersonRepository(db *mongo.Database) PersonRepository {
+	return &mongoPersonRepository{
+		personCollection: db.Collection("people"),
+		creditCollection: db.Collection("credits"),
+	}
+}
+
+func (r *mongoPersonRepository) CreatePerson(ctx context.Context, person models.Person) (*models.Person, error) {
+	now := time.Now().UTC()
+	person.ID = bson.NewObjectID()
+	person.CreatedAt = now
+	person.UpdatedAt = now
+	if _, err := r.personCollection.InsertOne(ctx, person); err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+func (r *mongoPersonRepository) GetPerson(ctx context.Context, id bson.ObjectID) (*models.Person, error) {
+	var person models.Person
+	if err := r.personCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&person); err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+func (r *mongoPersonRepository) UpdatePerson(ctx context.Context, person models.Person) error {
+	update := bson.M{
+		"$set": bson.M{
+			"name":       person.Name,
+			"bio":        person.Bio,
+			"photo_url":  person.PhotoURL,
+			"birth_date": person.BirthDate,
+		},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	result, err := r.personCollection.UpdateOne(ctx, bson.M{"_id": person.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoPersonRepository) DeletePerson(ctx context.Context, id bson.ObjectID) error {
+	result, err := r.personCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoPersonRepository) SearchPeople(ctx context.Context, query string, skip int64, limit int64) ([]models.Person, error) {
+	filter := bson.M{}
+	if query != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"}
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := r.personCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	people := []models.Person{}
+	if err = cursor.All(ctx, &people); err != nil {
+		return nil, err
+	}
+	return people, nil
+}
+
+func (r *mongoPersonRepository) CreateCredit(ctx context.Context, credit models.Credit) (*models.Credit, error) {
+	credit.ID = bson.NewObjectID()
+	if _, err := r.creditCollection.InsertOne(ctx, credit); err != nil {
+		return nil, err
+	}
+	return &credit, nil
+}
+
+func (r *mongoPersonRepository) GetCredit(ctx context.Context, id bson.ObjectID) (*models.Credit, error) {
+	var credit models.Credit
+	if err := r.creditCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&credit); err != nil {
+		return nil, err
+	}
+	return &credit, nil
+}
+
+func (r *mongoPersonRepository) DeleteCredit(ctx context.Context, id bson.ObjectID) error {
+	result, err := r.creditCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoPersonRepository) DeleteCreditsForPerson(ctx context.Context, personID bson.ObjectID) ([]string, error) {
+	imdbIDs, err := r.GetCreditedMovieIDs(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.creditCollection.DeleteMany(ctx, bson.M{"person_id": personID}); err != nil {
+		return nil, err
+	}
+	return imdbIDs, nil
+}
+
+func (r *mongoPersonRepository) GetMovieCredits(ctx context.Context, imdbID string) ([]models.MovieCredit, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"imdb_id": imdbID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "people",
+			"localField":   "person_id",
+			"foreignField": "_id",
+			"as":           "person",
+		}}},
+		{{Key: "$unwind", Value: "$person"}},
+		{{Key: "$sort", Value: bson.D{{Key: "order", Value: 1}, {Key: "_id", Value: 1}}}},
+	}
+	cursor, err := r.creditCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	credits := []models.MovieCredit{}
+	if err = cursor.All(ctx, &credits); err != nil {
+		return nil, err
+	}
+	return credits, nil
+}
+
+func (r *mongoPersonRepository) GetFilmography(ctx context.Context, personID bson.ObjectID, skip int64, limit int64) ([]models.FilmographyEntry, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"person_id": personID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "movies",
+			"localField":   "imdb_id",
+			"foreignField": "imdb_id",
+			"as":           "movie",
+		}}},
+		{{Key: "$unwind", Value: "$movie"}},
+		{{Key: "$sort", Value: bson.D{{Key: "movie.release_date", Value: -1}, {Key: "movie.title", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$skip", Value: skip}},
+		{{Key: "$limit", Value: limit}},
+	}
+	cursor, err := r.creditCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.FilmographyEntry{}
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *mongoPersonRepository) GetCreditedMovieIDs(ctx context.Context, personID bson.ObjectID) ([]string, error) {
+	var imdbIDs []string
+	if err := r.creditCollection.Distinct(ctx, "imdb_id", bson.M{"person_id": personID}).Decode(&imdbIDs); err != nil {
+		return nil, err
+	}
+	return imdbIDs, nil
+}
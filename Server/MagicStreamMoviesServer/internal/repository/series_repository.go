@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type SeriesRepository interface {
+	// UpsertSeason creates or replaces the season identified by its series and number.
+	UpsertSeason(ctx context.Context, season models.Season) (*models.Season, error)
+	GetSeason(ctx context.Context, seriesID string, seasonNumber int) (*models.Season, error)
+	// GetSeasons returns the seasons of a series by number.
+	GetSeasons(ctx context.Context, seriesID string) ([]models.Season, error)
+	// DeleteSeason removes the season together with its episodes.
+	DeleteSeason(ctx context.Context, seriesID string, seasonNumber int) error
+
+	// UpsertEpisode creates or replaces the episode identified by its series, season and number.
+	UpsertEpisode(ctx context.Context, episode models.Episode) (*models.Episode, error)
+	GetEpisode(ctx context.Context, seriesID string, seasonNumber int, episodeNumber int) (*models.Episode, error)
+	// GetEpisodeByImdbID finds an episode by its own IMDb ID.
+	GetEpisodeByImdbID(ctx context.Context, imdbID string) (*models.Episode, error)
+	// GetEpisodes returns the episodes of a season by number.
+	GetEpisodes(ctx context.Context, seriesID string, seasonNumber int) ([]models.Episode, error)
+	// GetSeriesEpisodes returns every episode of a series by season and number.
+	GetSeriesEpisodes(ctx context.Context, seriesID string) ([]models.Episode, error)
+	DeleteEpisode(ctx context.Context, seriesID string, seasonNumber int, episodeNumber int) error
+
+	// UpsertEpisodeProgress keeps a single entry per user and episode, holding the latest progress.
+	UpsertEpisodeProgress(ctx context.Context, progress models.EpisodeProgress) error
+	// GetLatestEpisodeProgress returns the episode of the series the user watched most recently.
+	GetLatestEpisodeProgress(ctx context.Context, userId string, seriesID string) (*models.EpisodeProgress, error)
+}
+
+type mongoSeriesRepository struct {
+	seasonCollection   *mongo.Collection
+	episodeCollection  *mongo.Collection
+	progressCollection *mongo.Collection
+}
+
+func NewSeriesRepository(db *mongo.Database) SeriesRepository {
+	return &mongoSeriesRepository{
+		seasonCollection:   db.Collection("seasons"),
+		episodeCollection:  db.Collection("episodes"),
+		progressCollection: db.Collection("episode_progress"),
+	}
+}
+
+func (r *mongoSeriesRepository) UpsertSeason(ctx context.Context, season models.Season) (*models.Season, error) {
+	filter := bson.M{"series_id": season.SeriesID, "season_number": season.SeasonNumber}
+	update := bson.M{
+		"$set": bson.M{
+			"title":       season.Title,
+			"overview":    season.Overview,
+			"poster_path": season.PosterPath,
+			"air_date":    season.AirDate,
+		},
+		"$setOnInsert": bson.M{"created_at": time.Now().UTC()},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	findOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved models.Season
+	if err := r.seasonCollection.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *mongoSeriesRepository) GetSeason(ctx context.Context, seriesID string, seasonNumber int) (*models.Season, error) {
+	var season models.Season
+	filter := bson.M{"series_id": seriesID, "season_number": seasonNumber}
+	if err := r.seasonCollection.FindOne(ctx, filter).Decode(&season); err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (r *mongoSeriesRepository) GetSeasons(ctx context.Context, seriesID string) ([]models.Season, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "season_number", Value: 1}})
+	cursor, err := r.seasonCollection.Find(ctx, bson.M{"series_id": seriesID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	seasons := []models.Season{}
+	if err = cursor.All(ctx, &seasons); err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+func (r *mongoSeriesRepository) DeleteSeason(ctx context.Context, seriesID string, seasonNumber int) error {
+	filter := bson.M{"series_id": seriesID, "season_number": seasonNumber}
+	result, err := r.seasonCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	_, err = r.episodeCollection.DeleteMany(ctx, filter)
+	return err
+}
+
+func (r *mongoSeriesRepository) UpsertEpisode(ctx context.Context, episode models.Episode) (*models.Episode, error) {
+	filter := bson.M{
+		"series_id":      episode.SeriesID,
+		"season_number":  episode.SeasonNumber,
+		"episode_number": episode.EpisodeNumber,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"imdb_id":    episode.ImdbID,
+			"title":      episode.Title,
+			"synopsis":   episode.Synopsis,
+			"runtime":    episode.Runtime,
+			"youtube_id": episode.YouTubeID,
+			"air_date":   episode.AirDate,
+			"stream":     episode.Stream,
+		},
+		"$setOnInsert": bson.M{"created_at": time.Now().UTC()},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	findOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved models.Episode
+	if err := r.episodeCollection.FindOneAndUpdate(ctx, filter, update, findOptions).Decode(&saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *mongoSeriesRepository) GetEpisode(ctx context.Context, seriesID string, seasonNumber int, episodeNumber int) (*models.Episode, error) {
+	var episode models.Episode
+	filter := bson.M{"series_id": seriesID, "season_number": seasonNumber, "episode_number": episodeNumber}
+	if err := r.episodeCollection.FindOne(ctx, filter).Decode(&episode); err != nil {
+		return nil, err
+	}
+	return &episode, nil
+}
+
+func (r *mongoSeriesRepository) GetEpisodeByImdbID(ctx context.Context, imdbID string) (*models.Episode, error) {
+	var episode models.Episode
+	if err := r.episodeCollection.FindOne(ctx, bson.M{"imdb_id": imdbID}).Decode(&episode); err != nil {
+		return nil, err
+	}
+	return &episode, nil
+}
+
+func (r *mongoSeriesRepository) GetEpisodes(ctx context.Context, seriesID string, seasonNumber int) ([]models.Episode, error) {
+	return r.findEpisodes(ctx, bson.M{"series_id": seriesID, "season_number": seasonNumber})
+}
+
+func (r *mongoSeriesRepository) GetSeriesEpisodes(ctx context.Context, seriesID string) ([]models.Episode, error) {
+	return r.findEpisodes(ctx, bson.M{"series_id": seriesID})
+}
+
+func (r *mongoSeriesRepository) findEpisodes(ctx context.Context, filter bson.M) ([]models.Episode, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "season_number", Value: 1}, {Key: "episode_number", Value: 1}})
+	cursor, err := r.episodeCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	episodes := []models.Episode{}
+	if err = cursor.All(ctx, &episodes); err != nil {
+		return nil, err
+	}
+	return episodes, nil
+}
+
+func (r *mongoSeriesRepository) DeleteEpisode(ctx context.Context, seriesID string, seasonNumber int, episodeNumber int) error {
+	filter := bson.M{"series_id": seriesID, "season_number": seasonNumber, "episode_number": episodeNumber}
+	result, err := r.episodeCollection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoSeriesRepository) UpsertEpisodeProgress(ctx context.Context, progress models.EpisodeProgress) error {
+	filter := bson.M{
+		"user_id":        progress.UserID,
+		"series_id":      progress.SeriesID,
+		"season_number":  progress.SeasonNumber,
+		"episode_number": progress.EpisodeNumber,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"progress_seconds": progress.ProgressSeconds,
+			"completed":        progress.Completed,
+			"watched_at":       progress.WatchedAt,
+		},
+	}
+	_, err := r.progressCollection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (r *mongoSeriesRepository) GetLatestEpisodeProgress(ctx context.Context, userId string, seriesID string) (*models.EpisodeProgress, error) {
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "watched_at", Value: -1}})
+	var progress models.EpisodeProgress
+	if err := r.progressCollection.FindOne(ctx, bson.M{"user_id": userId, "series_id": seriesID}, findOptions).Decode(&progress); err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
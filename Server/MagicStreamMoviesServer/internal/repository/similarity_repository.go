@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type SimilarityRepository interface {
+	ReplaceSimilarities(ctx context.Context, similarities []models.MovieSimilarity, computedAt time.Time) error
+	GetSimilarities(ctx context.Context, imdbIDs []string) ([]models.MovieSimilarity, error)
+}
+
+type mongoSimilarityRepository struct {
+	similarityCollection *mongo.Collection
+}
+
+func NewSimilarityRepository(db *mongo.Database) SimilarityRepository {
+	return &mongoSimilarityRepository{
+		similarityCollection: db.Collection("movie_similarities"),
+	}
+}
+
+// ReplaceSimilarities upserts one document per movie and then removes documents left over from
+// earlier runs, so movies that lost all their neighbours do not keep stale ones.
+func (r *mongoSimilarityRepository) ReplaceSimilarities(ctx context.Context, similarities []models.MovieSimilarity, computedAt time.Time) error {
+	if len(similarities) > 0 {
+		writes := make([]mongo.WriteModel, 0, len(similarities))
+		for _, sim := range similarities {
+			writes = append(writes, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"imdb_id": sim.ImdbID}).
+				SetReplacement(sim).
+				SetUpsert(true))
+		}
+		if _, err := r.similarityCollection.BulkWrite(ctx, writes); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.similarityCollection.DeleteMany(ctx, bson.M{"computed_at": bson.M{"$lt": computedAt}})
+	return err
+}
+
+func (r *mongoSimilarityRepository) GetSimilarities(ctx context.Context, imdbIDs []string) ([]models.MovieSimilarity, error) {
+	cursor, err := r.similarityCollection.Find(ctx, bson.M{"imdb_id": bson.M{"$in": imdbIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var similarities []models.MovieSimilarity
+	if err = cursor.All(ctx, &similarities); err != nil {
+		return nil, err
+	}
+	return similarities, nil
+}
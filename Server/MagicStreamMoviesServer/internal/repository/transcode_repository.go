@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type TranscodeRepository interface {
+	// CreateTranscodeJob saves a new job as queued.
+	CreateTranscodeJob(ctx context.Context, job models.TranscodeJob) (*models.TranscodeJob, error)
+	GetTranscodeJob(ctx context.Context, id bson.ObjectID) (*models.TranscodeJob, error)
+	// GetTranscodeJobs returns a title's jobs, newest first.
+	GetTranscodeJobs(ctx context.Context, imdbID string) ([]models.TranscodeJob, error)
+	// ClaimTranscodeJob marks the oldest queued job as running with a lease until leaseUntil
+	// and returns it, or mongo.ErrNoDocuments when none is queued.
+	ClaimTranscodeJob(ctx context.Context, leaseUntil time.Time) (*models.TranscodeJob, error)
+	// UpdateTranscodeProgress records a running job's progress and renews its lease. It returns
+	// mongo.ErrNoDocuments once the job is no longer running, such as after it was canceled.
+	UpdateTranscodeProgress(ctx context.Context, id bson.ObjectID, progress float64, leaseUntil time.Time) error
+	// FinishTranscodeJob saves the status, progress, output and error of a running job. It
+	// returns mongo.ErrNoDocuments when the job is no longer running.
+	FinishTranscodeJob(ctx context.Context, job models.TranscodeJob) error
+	// CancelTranscodeJob cancels a queued or running job. It returns mongo.ErrNoDocuments when
+	// there is no such job still to finish.
+	CancelTranscodeJob(ctx context.Context, id bson.ObjectID) error
+	// RetryTranscodeJob queues a failed or canceled job again. It returns mongo.ErrNoDocuments
+	// when there is no such job.
+	RetryTranscodeJob(ctx context.Context, id bson.ObjectID) error
+	// RequeueExpiredTranscodeJobs queues running jobs whose lease ran out again, for jobs
+	// whose worker stopped.
+	RequeueExpiredTranscodeJobs(ctx context.Context) (int64, error)
+}
+
+type mongoTranscodeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTranscodeRepository(db *mongo.Database) TranscodeRepository {
+	return &mongoTranscodeRepository{
+		collection: db.Collection("transcode_jobs"),
+	}
+}
+
+func (r *mongoTranscodeRepository) CreateTranscodeJob(ctx context.Context, job models.TranscodeJob) (*models.TranscodeJob, error) {
+	now := time.Now().UTC()
+	job.ID = bson.NewObjectID()
+	job.Status = models.TranscodeQueued
+	job.Progress = 0
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	if _, err := r.collection.InsertOne(ctx, job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *mongoTranscodeRepository) GetTranscodeJob(ctx context.Context, id bson.ObjectID) (*models.TranscodeJob, error) {
+	var job models.TranscodeJob
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *mongoTranscodeRepository) GetTranscodeJobs(ctx context.Context, imdbID string) ([]models.TranscodeJob, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"imdb_id": imdbID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []models.TranscodeJob{}
+	if err = cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *mongoTranscodeRepository) ClaimTranscodeJob(ctx context.Context, leaseUntil time.Time) (*models.TranscodeJob, error) {
+	update := bson.M{
+		"$set":         bson.M{"status": models.TranscodeRunning, "started_at": time.Now().UTC(), "progress": 0, "lease_until": leaseUntil.UTC()},
+		"$inc":         bson.M{"attempts": 1},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	findOptions := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job models.TranscodeJob
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"status": models.TranscodeQueued}, update, findOptions).Decode(&job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *mongoTranscodeRepository) UpdateTranscodeProgress(ctx context.Context, id bson.ObjectID, progress float64, leaseUntil time.Time) error {
+	update := bson.M{
+		"$set":         bson.M{"progress": progress, "lease_until": leaseUntil.UTC()},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.update(ctx, bson.M{"_id": id, "status": models.TranscodeRunning}, update)
+}
+
+func (r *mongoTranscodeRepository) FinishTranscodeJob(ctx context.Context, job models.TranscodeJob) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":       job.Status,
+			"progress":     job.Progress,
+			"playlist_key": job.PlaylistKey,
+			"renditions":   job.Renditions,
+			"thumbnails":   job.Thumbnails,
+			"error":        job.Error,
+			"finished_at":  time.Now().UTC(),
+		},
+		"$unset":       bson.M{"lease_until": ""},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.update(ctx, bson.M{"_id": job.ID, "status": models.TranscodeRunning}, update)
+}
+
+func (r *mongoTranscodeRepository) CancelTranscodeJob(ctx context.Context, id bson.ObjectID) error {
+	filter := bson.M{"_id": id, "status": bson.M{"$in": []string{models.TranscodeQueued, models.TranscodeRunning}}}
+	update := bson.M{
+		"$set":         bson.M{"status": models.TranscodeCanceled, "finished_at": time.Now().UTC()},
+		"$unset":       bson.M{"lease_until": ""},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.update(ctx, filter, update)
+}
+
+func (r *mongoTranscodeRepository) RetryTranscodeJob(ctx context.Context, id bson.ObjectID) error {
+	filter := bson.M{"_id": id, "status": bson.M{"$in": []string{models.TranscodeFailed, models.TranscodeCanceled}}}
+	update := bson.M{
+		"$set":         bson.M{"status": models.TranscodeQueued, "progress": 0},
+		"$unset":       bson.M{"error": "", "started_at": "", "finished_at": "", "playlist_key": "", "renditions": "", "thumbnails": "", "lease_until": ""},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.update(ctx, filter, update)
+}
+
+func (r *mongoTranscodeRepository) RequeueExpiredTranscodeJobs(ctx context.Context) (int64, error) {
+	// Jobs claimed before leases were kept have none, and count as expired.
+	filter := bson.M{
+		"status": models.TranscodeRunning,
+		"$or": bson.A{
+			bson.M{"lease_until": bson.M{"$exists": false}},
+			bson.M{"lease_until": bson.M{"$lt": time.Now().UTC()}},
+		},
+	}
+	update := bson.M{
+		"$set":         bson.M{"status": models.TranscodeQueued, "progress": 0},
+		"$unset":       bson.M{"started_at": "", "lease_until": ""},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *mongoTranscodeRepository) update(ctx context.Context, filter bson.M, update bson.M) error {
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type UploadRepository interface {
+	CreateUpload(ctx context.Context, upload models.Upload) (*models.Upload, error)
+	GetUpload(ctx context.Context, id bson.ObjectID) (*models.Upload, error)
+	// AppendUploadPart records a chunk written at offset. It returns mongo.ErrNoDocuments when
+	// the upload is gone or has moved past offset, so concurrent writers cannot both succeed.
+	AppendUploadPart(ctx context.Context, id bson.ObjectID, offset int64, part models.UploadPart) error
+	// ClaimUploadJoin marks a fully received upload as being joined until the given time. It
+	// returns mongo.ErrNoDocuments when the upload is complete, is still missing data, or has an
+	// unexpired claim from another join.
+	ClaimUploadJoin(ctx context.Context, id bson.ObjectID, until time.Time) error
+	// ReleaseUploadJoin drops the claim of a failed join, so the next request can retry it.
+	ReleaseUploadJoin(ctx context.Context, id bson.ObjectID) error
+	// CompleteUpload records the key of the joined media and drops the parts.
+	CompleteUpload(ctx context.Context, id bson.ObjectID, key string) error
+	DeleteUpload(ctx context.Context, id bson.ObjectID) error
+}
+
+type mongoUploadRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUploadRepository(db *mongo.Database) UploadRepository {
+	return &mongoUploadRepository{
+		collection: db.Collection("uploads"),
+	}
+}
+
+func (r *mongoUploadRepository) CreateUpload(ctx context.Context, upload models.Upload) (*models.Upload, error) {
+	now := time.Now().UTC()
+	upload.ID = bson.NewObjectID()
+	upload.Offset = 0
+	upload.Parts = []models.UploadPart{}
+	upload.CreatedAt = now
+	upload.UpdatedAt = now
+	if _, err := r.collection.InsertOne(ctx, upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (r *mongoUploadRepository) GetUpload(ctx context.Context, id bson.ObjectID) (*models.Upload, error) {
+	var upload models.Upload
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (r *mongoUploadRepository) AppendUploadPart(ctx context.Context, id bson.ObjectID, offset int64, part models.UploadPart) error {
+	filter := bson.M{"_id": id, "offset": offset, "completed_at": bson.M{"$exists": false}}
+	update := bson.M{
+		"$push":        bson.M{"parts": part},
+		"$inc":         bson.M{"offset": part.Size},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.update(ctx, filter, update)
+}
+
+func (r *mongoUploadRepository) ClaimUploadJoin(ctx context.Context, id bson.ObjectID, until time.Time) error {
+	filter := bson.M{
+		"_id":          id,
+		"completed_at": bson.M{"$exists": false},
+		"$expr":        bson.M{"$eq": bson.A{"$offset", "$length"}},
+		"$or": bson.A{
+			bson.M{"joining_until": bson.M{"$exists": false}},
+			bson.M{"joining_until": bson.M{"$lt": time.Now().UTC()}},
+		},
+	}
+	update := bson.M{
+		"$set":         bson.M{"joining_until": until.UTC()},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.update(ctx, filter, update)
+}
+
+func (r *mongoUploadRepository) ReleaseUploadJoin(ctx context.Context, id bson.ObjectID) error {
+	update := bson.M{
+		"$unset":       bson.M{"joining_until": ""},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.update(ctx, bson.M{"_id": id}, update)
+}
+
+func (r *mongoUploadRepository) CompleteUpload(ctx context.Context, id bson.ObjectID, key string) error {
+	update := bson.M{
+		"$set":         bson.M{"key": key, "completed_at": time.Now().UTC(), "parts": []models.UploadPart{}},
+		"$unset":       bson.M{"joining_until": ""},
+		"$currentDate": bson.M{"updated_at": true},
+	}
+	return r.update(ctx, bson.M{"_id": id}, update)
+}
+
+func (r *mongoUploadRepository) DeleteUpload(ctx context.Context, id bson.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoUploadRepository) update(ctx context.Context, filter bson.M, update bson.M) error {
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
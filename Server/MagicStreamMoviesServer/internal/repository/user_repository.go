@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
@@ -18,6 +17,9 @@ type UserRepository interface {
 	CountUsersByEmail(ctx context.Context, email string) (int64, error)
 	UpdateTokens(ctx context.Context, userId string, token string, refreshToken string, updatedAt time.Time) error
 	GetUserFavouriteGenres(ctx context.Context, userId string) ([]string, error)
+	RenameFavouriteGenre(ctx context.Context, genre models.Genre) error
+	MergeFavouriteGenre(ctx context.Context, from int, target models.Genre) error
+	RemoveFavouriteGenre(ctx context.Context, genreID int) error
 }
 
 type mongoUserRepository struct {
@@ -81,13 +83,11 @@ func (r *mongoUserRepository) GetUserFavouriteGenres(ctx context.Context, userId
 
 	favGenresArray, ok := result["favourite_genres"].(bson.A)
 	if !ok {
-		// If it's nil or not an array, maybe return empty? 
-		// Or check if user exists first? 
-		// Keeping logic similar to original.
-		return []string{}, errors.New("unable to retrieve favourite genres for user")
+		// Users who never picked favourites simply have none; callers fall back to global rankings.
+		return []string{}, nil
 	}
 
-	var genreNames []string
+	genreNames := []string{}
 	for _, item := range favGenresArray {
 		if genreMap, ok := item.(bson.D); ok {
 			for _, elem := range genreMap {
@@ -101,3 +101,15 @@ func (r *mongoUserRepository) GetUserFavouriteGenres(ctx context.Context, userId
 	}
 	return genreNames, nil
 }
+
+func (r *mongoUserRepository) RenameFavouriteGenre(ctx context.Context, genre models.Genre) error {
+	return renameGenreCopies(ctx, r.userCollection, "favourite_genres", genre)
+}
+
+func (r *mongoUserRepository) MergeFavouriteGenre(ctx context.Context, from int, target models.Genre) error {
+	return mergeGenreCopies(ctx, r.userCollection, "favourite_genres", from, target)
+}
+
+func (r *mongoUserRepository) RemoveFavouriteGenre(ctx context.Context, genreID int) error {
+	return removeGenreCopies(ctx, r.userCollection, "favourite_genres", genreID)
+}
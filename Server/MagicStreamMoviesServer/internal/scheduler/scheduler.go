@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work run periodically by the Scheduler.
+type Job func(ctx context.Context) error
+
+type task struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// Scheduler runs registered jobs in-process on fixed intervals. Each job runs once as soon as
+// the scheduler starts and then on every tick; a job never overlaps with itself.
+type Scheduler struct {
+	mu     sync.Mutex
+	tasks  []task
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers a job to run at the given interval. Jobs registered after Start are ignored.
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks = append(s.tasks, task{name: name, interval: interval, job: job})
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, t := range s.tasks {
+		s.wg.Add(1)
+		go s.run(ctx, t)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, t task) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		if err := t.job(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("scheduler: job %s failed: %v", t.name, err)
+		} else if err == nil {
+			log.Printf("scheduler: job %s finished in %s", t.name, time.Since(start))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package search
+
+import "sort"
+
+// termMatch is an indexed term that matches a query term, weighted by how close the match is.
+type termMatch struct {
+	term   string
+	weight float64
+}
+
+// maxEdits is the number of typos tolerated in a query term. Short terms must match exactly,
+// since a single edit already turns them into many unrelated words.
+func maxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// fuzzyMatches returns the vocabulary terms that term may have been meant as. A term that is in
+// the vocabulary only matches itself; otherwise every term within the edit budget matches, with
+// a weight that drops with each edit.
+func fuzzyMatches[V any](term string, vocabulary map[string]V) []termMatch {
+	if _, ok := vocabulary[term]; ok {
+		return []termMatch{{term: term, weight: 1}}
+	}
+
+	budget := maxEdits(term)
+	length := len([]rune(term))
+	matches := []termMatch{}
+	for candidate := range vocabulary {
+		diff := len([]rune(candidate)) - length
+		if diff > budget || -diff > budget {
+			continue
+		}
+		if d := editDistance(term, candidate); d <= budget {
+			matches = append(matches, termMatch{term: candidate, weight: 1 / float64(1+d)})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].weight != matches[j].weight {
+			return matches[i].weight > matches[j].weight
+		}
+		return matches[i].term < matches[j].term
+	})
+	return matches
+}
+
+// editDistance is the optimal string alignment distance between a and b: the number of
+// insertions, deletions, substitutions and adjacent transpositions turning a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
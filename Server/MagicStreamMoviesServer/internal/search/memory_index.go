@@ -0,0 +1,196 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/pkg/utils"
+)
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type document struct {
+	title  string
+	terms  map[string]float64
+	length float64
+}
+
+// memoryIndex is an in-process inverted index scored with BM25 over field-weighted term
+// frequencies. It must be rebuilt from the database on startup.
+type memoryIndex struct {
+	mu          sync.RWMutex
+	docs        map[string]*document
+	postings    map[string]map[string]float64
+	totalLength float64
+}
+
+func NewMemoryIndex() SearchIndex {
+	return &memoryIndex{
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]float64),
+	}
+}
+
+func (idx *memoryIndex) Rebuild(ctx context.Context, movies []models.Movie) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.docs = make(map[string]*document, len(movies))
+	idx.postings = make(map[string]map[string]float64)
+	idx.totalLength = 0
+	for _, movie := range movies {
+		idx.add(movie)
+	}
+	return nil
+}
+
+func (idx *memoryIndex) Index(ctx context.Context, movie models.Movie) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(movie.ImdbID)
+	idx.add(movie)
+	return nil
+}
+
+func (idx *memoryIndex) add(movie models.Movie) {
+	doc := &document{title: movie.Title, terms: make(map[string]float64)}
+	for _, f := range movieFields(movie) {
+		for _, token := range utils.Tokenize(f.text) {
+			doc.terms[token] += f.weight
+			doc.length += f.weight
+		}
+	}
+
+	idx.docs[movie.ImdbID] = doc
+	idx.totalLength += doc.length
+	for term, tf := range doc.terms {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[string]float64)
+		}
+		idx.postings[term][movie.ImdbID] = tf
+	}
+}
+
+func (idx *memoryIndex) remove(imdbID string) {
+	doc, ok := idx.docs[imdbID]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		delete(idx.postings[term], imdbID)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLength -= doc.length
+	delete(idx.docs, imdbID)
+}
+
+// Search scores every document containing a query term, or a term the query term is a likely
+// typo of, and sums the best match per query term.
+func (idx *memoryIndex) Search(ctx context.Context, query string, limit int) ([]models.SearchHit, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if len(idx.docs) == 0 {
+		return []models.SearchHit{}, nil
+	}
+	n := float64(len(idx.docs))
+	avgLength := idx.totalLength / n
+
+	scores := make(map[string]float64)
+	for _, queryTerm := range utils.Tokenize(query) {
+		best := make(map[string]float64)
+		for _, match := range fuzzyMatches(queryTerm, idx.postings) {
+			postings := idx.postings[match.term]
+			idf := math.Log(1 + (n-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+			for imdbID, tf := range postings {
+				norm := 1 - bm25B + bm25B*idx.docs[imdbID].length/avgLength
+				score := match.weight * idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+				if score > best[imdbID] {
+					best[imdbID] = score
+				}
+			}
+		}
+		for imdbID, score := range best {
+			scores[imdbID] += score
+		}
+	}
+
+	hits := make([]models.SearchHit, 0, len(scores))
+	for imdbID, score := range scores {
+		hits = append(hits, models.SearchHit{ImdbID: imdbID, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ImdbID < hits[j].ImdbID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// Suggest returns titles starting with prefix first, then titles with a later word starting
+// with it, each group in alphabetical order.
+func (idx *memoryIndex) Suggest(ctx context.Context, prefix string, limit int) ([]models.Suggestion, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	type candidate struct {
+		suggestion models.Suggestion
+		rank       int
+	}
+	candidates := []candidate{}
+	for imdbID, doc := range idx.docs {
+		if rank, ok := prefixRank(doc.title, prefix); ok {
+			candidates = append(candidates, candidate{models.Suggestion{ImdbID: imdbID, Title: doc.title}, rank})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].rank != candidates[j].rank {
+			return candidates[i].rank < candidates[j].rank
+		}
+		if candidates[i].suggestion.Title != candidates[j].suggestion.Title {
+			return candidates[i].suggestion.Title < candidates[j].suggestion.Title
+		}
+		return candidates[i].suggestion.ImdbID < candidates[j].suggestion.ImdbID
+	})
+
+	suggestions := []models.Suggestion{}
+	for i, c := range candidates {
+		if limit > 0 && i == limit {
+			break
+		}
+		suggestions = append(suggestions, c.suggestion)
+	}
+	return suggestions, nil
+}
+
+// prefixRank reports whether title matches the lowercase prefix: rank 0 when the title starts
+// with it, rank 1 when a later word does.
+func prefixRank(title string, prefix string) (int, bool) {
+	lower := strings.ToLower(title)
+	if strings.HasPrefix(lower, prefix) {
+		return 0, true
+	}
+	words := strings.Fields(lower)
+	for i := 1; i < len(words); i++ {
+		if strings.HasPrefix(words[i], prefix) {
+			return 1, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,133 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func catalog() []models.Movie {
+	return []models.Movie{
+		{ImdbID: "tt1", Title: "The Dark Knight", Genre: []models.Genre{{GenreID: 1, GenreName: "Action"}}, AdminReview: "Gripping crime drama"},
+		{ImdbID: "tt2", Title: "Knight and Day", Genre: []models.Genre{{GenreID: 2, GenreName: "Comedy"}}},
+		{ImdbID: "tt3", Title: "Dark Waters", Genre: []models.Genre{{GenreID: 3, GenreName: "Drama"}}, AdminReview: "A slow legal thriller"},
+		{ImdbID: "tt4", Title: "Crime Story", Genre: []models.Genre{{GenreID: 3, GenreName: "Drama"}}},
+	}
+}
+
+func ids(hits []models.SearchHit) []string {
+	result := []string{}
+	for _, hit := range hits {
+		result = append(result, hit.ImdbID)
+	}
+	return result
+}
+
+func TestMemoryIndex_Search(t *testing.T) {
+	idx := NewMemoryIndex()
+	assert.NoError(t, idx.Rebuild(context.Background(), catalog()))
+
+	t.Run("RanksDocumentsMatchingMoreTermsFirst", func(t *testing.T) {
+		hits, err := idx.Search(context.Background(), "dark knight", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "tt1", hits[0].ImdbID)
+		assert.ElementsMatch(t, []string{"tt1", "tt2", "tt3"}, ids(hits))
+	})
+
+	t.Run("TitleOutweighsReview", func(t *testing.T) {
+		hits, err := idx.Search(context.Background(), "crime", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tt4", "tt1"}, ids(hits))
+	})
+
+	t.Run("ToleratesTypos", func(t *testing.T) {
+		hits, err := idx.Search(context.Background(), "knigth", 10)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"tt1", "tt2"}, ids(hits))
+	})
+
+	t.Run("MatchesGenres", func(t *testing.T) {
+		hits, err := idx.Search(context.Background(), "comedy", 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"tt2"}, ids(hits))
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		hits, err := idx.Search(context.Background(), "dark knight", 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, hits, 1)
+	})
+}
+
+func TestMemoryIndex_IndexReplacesDocument(t *testing.T) {
+	idx := NewMemoryIndex()
+	assert.NoError(t, idx.Rebuild(context.Background(), catalog()))
+
+	assert.NoError(t, idx.Index(context.Background(), models.Movie{ImdbID: "tt3", Title: "Deep Waters"}))
+
+	hits, err := idx.Search(context.Background(), "dark", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tt1"}, ids(hits))
+
+	hits, err = idx.Search(context.Background(), "deep", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tt3"}, ids(hits))
+}
+
+func TestMemoryIndex_Suggest(t *testing.T) {
+	idx := NewMemoryIndex()
+	assert.NoError(t, idx.Rebuild(context.Background(), catalog()))
+
+	suggestions, err := idx.Suggest(context.Background(), "Kni", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Suggestion{
+		{ImdbID: "tt2", Title: "Knight and Day"},
+		{ImdbID: "tt1", Title: "The Dark Knight"},
+	}, suggestions)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("knight", "knight"))
+	assert.Equal(t, 1, editDistance("knigth", "knight"))
+	assert.Equal(t, 1, editDistance("nigh", "night"))
+	assert.Equal(t, 2, editDistance("kitten", "sittin"))
+	assert.Equal(t, 3, editDistance("", "abc"))
+}
+
+func TestMemoryIndex_MatchesCreditNames(t *testing.T) {
+	idx := NewMemoryIndex()
+	assert.NoError(t, idx.Rebuild(context.Background(), catalog()))
+
+	movie := catalog()[0]
+	movie.CreditNames = []string{"Christopher Nolan", "Heath Ledger"}
+	assert.NoError(t, idx.Index(context.Background(), movie))
+
+	hits, err := idx.Search(context.Background(), "ledger", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tt1"}, ids(hits))
+}
+
+func TestMemoryIndex_MatchesEpisodeTitles(t *testing.T) {
+	idx := NewMemoryIndex()
+	series := models.Movie{
+		ImdbID:        "tt0903747",
+		Title:         "Breaking Bad",
+		ContentType:   models.ContentTypeSeries,
+		EpisodeTitles: []string{"Pilot", "Ozymandias"},
+	}
+	assert.NoError(t, idx.Rebuild(context.Background(), append(catalog(), series)))
+
+	hits, err := idx.Search(context.Background(), "ozymandias", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tt0903747"}, ids(hits))
+}
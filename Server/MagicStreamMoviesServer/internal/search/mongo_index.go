@@ -0,0 +1,197 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/pkg/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const textIndexName = "movie_search"
+
+// Server error codes for an index that already exists with a different definition.
+const (
+	indexOptionsConflict  = 85
+	indexKeySpecsConflict = 86
+)
+
+// mongoIndex searches the movies collection through a MongoDB text index, which MongoDB keeps
+// up to date on every write. Text indexes only match whole (stemmed) words, so query terms are
+// first corrected against a vocabulary of the indexed words kept in memory.
+type mongoIndex struct {
+	collection *mongo.Collection
+	mu         sync.RWMutex
+	vocabulary map[string]struct{}
+}
+
+func NewMongoIndex(db *mongo.Database) SearchIndex {
+	return &mongoIndex{
+		collection: db.Collection("movies"),
+		vocabulary: make(map[string]struct{}),
+	}
+}
+
+// Rebuild makes sure the text index exists and reloads the vocabulary.
+func (idx *mongoIndex) Rebuild(ctx context.Context, movies []models.Movie) error {
+	model := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "genre.genre_name", Value: "text"},
+			{Key: "admin_review", Value: "text"},
+			{Key: "synopsis", Value: "text"},
+			{Key: "keywords", Value: "text"},
+			{Key: "mood_tags", Value: "text"},
+			{Key: "credit_names", Value: "text"},
+			{Key: "episode_titles", Value: "text"},
+		},
+		Options: options.Index().SetName(textIndexName).SetWeights(bson.D{
+			{Key: "title", Value: 3},
+			{Key: "genre.genre_name", Value: 2},
+			{Key: "keywords", Value: 2},
+			{Key: "credit_names", Value: 2},
+			{Key: "admin_review", Value: 1},
+			{Key: "synopsis", Value: 1},
+			{Key: "mood_tags", Value: 1},
+			{Key: "episode_titles", Value: 1},
+		}),
+	}
+	if _, err := idx.collection.Indexes().CreateOne(ctx, model); err != nil {
+		// A collection has at most one text index, so an index built for other fields is
+		// replaced.
+		var cmdErr mongo.CommandError
+		if !errors.As(err, &cmdErr) || (cmdErr.Code != indexOptionsConflict && cmdErr.Code != indexKeySpecsConflict) {
+			return err
+		}
+		if err := idx.collection.Indexes().DropOne(ctx, textIndexName); err != nil {
+			return err
+		}
+		if _, err := idx.collection.Indexes().CreateOne(ctx, model); err != nil {
+			return err
+		}
+	}
+
+	vocabulary := make(map[string]struct{})
+	for _, movie := range movies {
+		addTerms(vocabulary, movie)
+	}
+	idx.mu.Lock()
+	idx.vocabulary = vocabulary
+	idx.mu.Unlock()
+	return nil
+}
+
+func (idx *mongoIndex) Index(ctx context.Context, movie models.Movie) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	addTerms(idx.vocabulary, movie)
+	return nil
+}
+
+func (idx *mongoIndex) Search(ctx context.Context, query string, limit int) ([]models.SearchHit, error) {
+	terms := idx.correct(utils.Tokenize(query))
+	if len(terms) == 0 {
+		return []models.SearchHit{}, nil
+	}
+
+	filter := bson.M{"$text": bson.M{"$search": strings.Join(terms, " ")}}
+	findOptions := options.Find().
+		SetProjection(bson.M{"_id": 0, "imdb_id": 1, "score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.M{"score": bson.M{"$meta": "textScore"}})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+	}
+
+	cursor, err := idx.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	hits := []models.SearchHit{}
+	if err = cursor.All(ctx, &hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// correct swaps every term missing from the vocabulary for the words it is most likely a typo
+// of. Terms without any close match are kept and left to the text index to stem.
+func (idx *mongoIndex) correct(terms []string) []string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	corrected := make([]string, 0, len(terms))
+	for _, term := range terms {
+		matches := fuzzyMatches(term, idx.vocabulary)
+		if len(matches) == 0 {
+			corrected = append(corrected, term)
+			continue
+		}
+		for _, match := range matches {
+			if match.weight == matches[0].weight {
+				corrected = append(corrected, match.term)
+			}
+		}
+	}
+	return corrected
+}
+
+// Suggest returns titles starting with prefix first, then titles with a later word starting
+// with it.
+func (idx *mongoIndex) Suggest(ctx context.Context, prefix string, limit int) ([]models.Suggestion, error) {
+	quoted := regexp.QuoteMeta(strings.TrimSpace(prefix))
+	suggestions := []models.Suggestion{}
+	seen := make(map[string]struct{})
+
+	for _, pattern := range []string{"^" + quoted, `\s` + quoted} {
+		remaining := limit - len(suggestions)
+		if limit > 0 && remaining <= 0 {
+			break
+		}
+		findOptions := options.Find().
+			SetProjection(bson.M{"_id": 0, "imdb_id": 1, "title": 1}).
+			SetSort(bson.D{{Key: "title", Value: 1}, {Key: "imdb_id", Value: 1}})
+		if limit > 0 {
+			findOptions.SetLimit(int64(remaining + len(seen)))
+		}
+
+		cursor, err := idx.collection.Find(ctx, bson.M{"title": bson.M{"$regex": pattern, "$options": "i"}}, findOptions)
+		if err != nil {
+			return nil, err
+		}
+		var matches []models.Suggestion
+		err = cursor.All(ctx, &matches)
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, match := range matches {
+			if _, dup := seen[match.ImdbID]; dup {
+				continue
+			}
+			if limit > 0 && len(suggestions) == limit {
+				break
+			}
+			seen[match.ImdbID] = struct{}{}
+			suggestions = append(suggestions, match)
+		}
+	}
+	return suggestions, nil
+}
+
+func addTerms(vocabulary map[string]struct{}, movie models.Movie) {
+	for _, f := range movieFields(movie) {
+		for _, token := range utils.Tokenize(f.text) {
+			vocabulary[token] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendMongo  = "mongo"
+)
+
+// SearchIndex is a full-text index over the movie catalog. Implementations rank matches by
+// relevance and tolerate small typos in the query.
+type SearchIndex interface {
+	// Rebuild replaces the contents of the index with the given catalog.
+	Rebuild(ctx context.Context, movies []models.Movie) error
+	// Index adds a movie to the index, or refreshes it if it is already there.
+	Index(ctx context.Context, movie models.Movie) error
+	Search(ctx context.Context, query string, limit int) ([]models.SearchHit, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]models.Suggestion, error)
+}
+
+// New builds the index selected by backend.
+func New(backend string, db *mongo.Database) (SearchIndex, error) {
+	switch backend {
+	case BackendMemory:
+		return NewMemoryIndex(), nil
+	case BackendMongo:
+		return NewMongoIndex(db), nil
+	default:
+		return nil, fmt.Errorf("unknown search backend %q", backend)
+	}
+}
+
+// field is a piece of searchable movie text and how much a match in it counts.
+type field struct {
+	text   string
+	weight float64
+}
+
+// movieFields lists the text of a movie that is searchable. Title matches count the most, then
+// genres, keywords and the names of the cast and crew, then the descriptive text and the titles
+// of a series' episodes.
+func movieFields(movie models.Movie) []field {
+	fields := []field{
+		{text: movie.Title, weight: 3},
+		{text: movie.AdminReview, weight: 1},
+		{text: movie.Synopsis, weight: 1},
+	}
+	for _, genre := range movie.Genre {
+		fields = append(fields, field{text: genre.GenreName, weight: 2})
+	}
+	for _, keyword := range movie.Keywords {
+		fields = append(fields, field{text: keyword, weight: 2})
+	}
+	for _, tag := range movie.MoodTags {
+		fields = append(fields, field{text: tag, weight: 1})
+	}
+	for _, name := range movie.CreditNames {
+		fields = append(fields, field{text: name, weight: 2})
+	}
+	for _, title := range movie.EpisodeTitles {
+		fields = append(fields, field{text: title, weight: 1})
+	}
+	return fields
+}
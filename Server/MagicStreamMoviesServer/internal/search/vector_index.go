@@ -0,0 +1,118 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+)
+
+// VectorIndex is an in-process nearest-neighbour index over movie embeddings. It compares the
+// query with every vector, which is exact and fast enough for catalogs of tens of thousands of
+// movies.
+type VectorIndex struct {
+	mu      sync.RWMutex
+	vectors map[string][]float32
+}
+
+func NewVectorIndex() *VectorIndex {
+	return &VectorIndex{vectors: make(map[string][]float32)}
+}
+
+// Replace swaps the whole contents of the index.
+func (idx *VectorIndex) Replace(vectors map[string][]float32) {
+	normalized := make(map[string][]float32, len(vectors))
+	for imdbID, vector := range vectors {
+		normalized[imdbID] = Normalize(vector)
+	}
+	idx.mu.Lock()
+	idx.vectors = normalized
+	idx.mu.Unlock()
+}
+
+func (idx *VectorIndex) Upsert(imdbID string, vector []float32) {
+	normalized := Normalize(vector)
+	idx.mu.Lock()
+	idx.vectors[imdbID] = normalized
+	idx.mu.Unlock()
+}
+
+// Get returns the unit length vector of a movie.
+func (idx *VectorIndex) Get(imdbID string) ([]float32, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	vector, ok := idx.vectors[imdbID]
+	return vector, ok
+}
+
+func (idx *VectorIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.vectors)
+}
+
+// Search returns the movies whose vectors have the highest cosine similarity with query,
+// skipping exclude. Only positive similarities are returned.
+func (idx *VectorIndex) Search(query []float32, limit int, exclude []string) []models.SearchHit {
+	query = Normalize(query)
+	skip := make(map[string]struct{}, len(exclude))
+	for _, imdbID := range exclude {
+		skip[imdbID] = struct{}{}
+	}
+
+	idx.mu.RLock()
+	hits := []models.SearchHit{}
+	for imdbID, vector := range idx.vectors {
+		if _, ok := skip[imdbID]; ok {
+			continue
+		}
+		if score := Dot(query, vector); score > 0 {
+			hits = append(hits, models.SearchHit{ImdbID: imdbID, Score: score})
+		}
+	}
+	idx.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ImdbID < hits[j].ImdbID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// Dot is the cosine similarity of two unit length vectors. Vectors of different lengths come
+// from different models and are not similar at all.
+func Dot(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	dot := 0.0
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+// Normalize returns a copy of vector scaled to unit length.
+func Normalize(vector []float32) []float32 {
+	norm := 0.0
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	unit := make([]float32, len(vector))
+	if norm == 0 {
+		return unit
+	}
+	scale := 1 / math.Sqrt(norm)
+	for i, v := range vector {
+		unit[i] = float32(float64(v) * scale)
+	}
+	return unit
+}
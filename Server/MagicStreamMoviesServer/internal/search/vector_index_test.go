@@ -0,0 +1,29 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorIndex_Search(t *testing.T) {
+	idx := NewVectorIndex()
+	idx.Replace(map[string][]float32{
+		"tt1": {1, 0, 0},
+		"tt2": {2, 2, 0},
+		"tt3": {0, 0, 5},
+		"tt4": {-1, 0, 0},
+	})
+
+	hits := idx.Search([]float32{3, 1, 0}, 10, []string{"tt2"})
+
+	// tt3 is orthogonal and tt4 opposite to the query, so neither is similar at all.
+	assert.Len(t, hits, 1)
+	assert.Equal(t, "tt1", hits[0].ImdbID)
+	assert.InDelta(t, 3/3.1622776, hits[0].Score, 1e-6)
+
+	idx.Upsert("tt5", []float32{3, 1, 0})
+	hits = idx.Search([]float32{3, 1, 0}, 1, nil)
+	assert.Equal(t, "tt5", hits[0].ImdbID)
+	assert.Equal(t, 5, idx.Len())
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/cache"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/tmc/langchaingo/llms"
+)
+
+var ErrAssistantUnavailable = errors.New("assistant is not configured")
+
+// maxMarkerLength bounds how much text is held back while waiting for a pick marker to close.
+const maxMarkerLength = 32
+
+const assistantInstructions = `You are the movie assistant of MagicStreamMovies. Help the user find something to watch.
+Only recommend movies from the catalog below and cite every recommended movie by its imdb_id in double brackets, e.g. [[tt0111161]].
+Never recommend a movie that is not in the catalog. Prefer the user's favourite genres unless they ask for something else.
+Keep answers short and friendly.`
+
+type AssistantService interface {
+	// Chat answers message in the context of the user's conversation so far. When onChunk is not
+	// nil the answer is also streamed through it as it is generated.
+	Chat(ctx context.Context, userId string, message string, onChunk func(string) error) (*models.AssistantReply, error)
+	// ResetConversation forgets the user's conversation.
+	ResetConversation(userId string)
+}
+
+type assistantService struct {
+	movieRepo     repository.MovieRepository
+	userRepo      repository.UserRepository
+	semantic      SemanticService
+	model         llms.Model
+	conversations *cache.TTLCache[string, []models.ChatMessage]
+	config        *config.Config
+}
+
+// NewAssistantService builds the assistant. semantic may be nil, in which case candidates come
+// from the user's favourite genres only; a nil model makes every chat fail with
+// ErrAssistantUnavailable.
+func NewAssistantService(movieRepo repository.MovieRepository, userRepo repository.UserRepository, semantic SemanticService, model llms.Model, cfg *config.Config) AssistantService {
+	return &assistantService{
+		movieRepo:     movieRepo,
+		userRepo:      userRepo,
+		semantic:      semantic,
+		model:         model,
+		conversations: cache.NewTTLCache[string, []models.ChatMessage](cfg.AssistantConversationTTL),
+		config:        cfg,
+	}
+}
+
+func (s *assistantService) Chat(ctx context.Context, userId string, message string, onChunk func(string) error) (*models.AssistantReply, error) {
+	if s.model == nil {
+		return nil, ErrAssistantUnavailable
+	}
+
+	favourites, err := s.userRepo.GetUserFavouriteGenres(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.candidates(ctx, message, favourites)
+	if err != nil {
+		return nil, err
+	}
+	history, _ := s.conversations.Get(userId)
+
+	messages := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeSystem, assistantPrompt(favourites, candidates))}
+	for _, turn := range history {
+		role := llms.ChatMessageTypeHuman
+		if turn.Role == models.ChatRoleAssistant {
+			role = llms.ChatMessageTypeAI
+		}
+		messages = append(messages, llms.TextParts(role, turn.Content))
+	}
+	messages = append(messages, llms.TextParts(llms.ChatMessageTypeHuman, message))
+
+	filter := newPickFilter(s.resolver(ctx, candidates), onChunk)
+	var options []llms.CallOption
+	if onChunk != nil {
+		options = append(options, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			return filter.Write(string(chunk))
+		}))
+	}
+	response, err := s.model.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Choices) == 0 {
+		return nil, errors.New("assistant returned no answer")
+	}
+	answer := response.Choices[0].Content
+	if onChunk == nil {
+		if err := filter.Write(answer); err != nil {
+			return nil, err
+		}
+	}
+	if err := filter.Flush(); err != nil {
+		return nil, err
+	}
+
+	// The raw answer is kept so the model sees its own citations on the next turn.
+	history = append(history,
+		models.ChatMessage{Role: models.ChatRoleUser, Content: message},
+		models.ChatMessage{Role: models.ChatRoleAssistant, Content: answer},
+	)
+	if maxMessages := 2 * s.config.AssistantMaxTurns; len(history) > maxMessages {
+		history = history[len(history)-maxMessages:]
+	}
+	s.conversations.Set(userId, history)
+
+	return &models.AssistantReply{Message: filter.text.String(), Picks: filter.picks}, nil
+}
+
+func (s *assistantService) ResetConversation(userId string) {
+	s.conversations.Delete(userId)
+}
+
+// candidates is the slice of the catalog shown to the model: the best ranked movies in the
+// user's favourite genres, topped up with the movies semantically closest to the message.
+func (s *assistantService) candidates(ctx context.Context, message string, favourites []string) ([]models.Movie, error) {
+	limit := s.config.AssistantCandidates
+	favouriteLimit := limit
+	if s.semantic != nil {
+		favouriteLimit = limit / 2
+	}
+	movies, err := s.movieRepo.GetRecommendedMovies(ctx, favourites, nil, 0, int64(favouriteLimit))
+	if err != nil {
+		return nil, err
+	}
+	if s.semantic == nil {
+		return movies, nil
+	}
+
+	related, err := s.semantic.Search(ctx, message, limit)
+	if err != nil {
+		// The favourite genres alone still give the model something to work with.
+		log.Printf("failed to find movies related to assistant message: %v", err)
+		return movies, nil
+	}
+	seen := make(map[string]bool, len(movies))
+	for _, movie := range movies {
+		seen[movie.ImdbID] = true
+	}
+	for _, result := range related {
+		if len(movies) >= limit {
+			break
+		}
+		if !seen[result.ImdbID] {
+			seen[result.ImdbID] = true
+			movies = append(movies, result.Movie)
+		}
+	}
+	return movies, nil
+}
+
+// resolver looks a cited imdb_id up among the candidates and then in the catalog, so the model
+// can also pick a movie mentioned earlier in the conversation.
+func (s *assistantService) resolver(ctx context.Context, candidates []models.Movie) func(string) (models.Movie, bool) {
+	known := make(map[string]models.Movie, len(candidates))
+	for _, movie := range candidates {
+		known[movie.ImdbID] = movie
+	}
+	return func(imdbID string) (models.Movie, bool) {
+		if movie, ok := known[imdbID]; ok {
+			return movie, true
+		}
+		movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+		if err != nil {
+			return models.Movie{}, false
+		}
+		known[imdbID] = *movie
+		return *movie, true
+	}
+}
+
+func assistantPrompt(favourites []string, candidates []models.Movie) string {
+	var prompt strings.Builder
+	prompt.WriteString(assistantInstructions)
+	prompt.WriteString("\n\n")
+	if len(favourites) > 0 {
+		fmt.Fprintf(&prompt, "The user's favourite genres: %s.\n\n", strings.Join(favourites, ", "))
+	}
+	prompt.WriteString("Catalog (imdb_id | title | genres | ranking):\n")
+	for _, movie := range candidates {
+		genres := make([]string, 0, len(movie.Genre))
+		for _, genre := range movie.Genre {
+			genres = append(genres, genre.GenreName)
+		}
+		fmt.Fprintf(&prompt, "%s | %s | %s | %s\n", movie.ImdbID, movie.Title, strings.Join(genres, ", "), movie.Ranking.RankingName)
+	}
+	return prompt.String()
+}
+
+// pickFilter rewrites the pick markers in the model's answer as it streams in. A marker naming a
+// catalog movie becomes the movie's title and the movie is recorded as a pick; any other marker
+// is dropped. Text that may still turn out to be a marker is held back until it is complete.
+type pickFilter struct {
+	resolve func(string) (models.Movie, bool)
+	emit    func(string) error
+	pending string
+	text    strings.Builder
+	picks   []models.Movie
+	picked  map[string]bool
+}
+
+func newPickFilter(resolve func(string) (models.Movie, bool), emit func(string) error) *pickFilter {
+	return &pickFilter{
+		resolve: resolve,
+		emit:    emit,
+		picks:   []models.Movie{},
+		picked:  make(map[string]bool),
+	}
+}
+
+func (f *pickFilter) Write(chunk string) error {
+	f.pending += chunk
+	var out strings.Builder
+	for {
+		start := strings.Index(f.pending, "[[")
+		if start < 0 {
+			cut := len(f.pending)
+			if strings.HasSuffix(f.pending, "[") {
+				cut--
+			}
+			out.WriteString(f.pending[:cut])
+			f.pending = f.pending[cut:]
+			break
+		}
+		end := strings.Index(f.pending[start:], "]]")
+		if end < 0 {
+			if len(f.pending)-start > maxMarkerLength {
+				// Too long to be a marker, so it is ordinary text.
+				out.WriteString(f.pending[:start+2])
+				f.pending = f.pending[start+2:]
+				continue
+			}
+			out.WriteString(f.pending[:start])
+			f.pending = f.pending[start:]
+			break
+		}
+		out.WriteString(f.pending[:start])
+		out.WriteString(f.pick(strings.TrimSpace(f.pending[start+2 : start+end])))
+		f.pending = f.pending[start+end+2:]
+	}
+	return f.send(out.String())
+}
+
+// Flush releases any text still held back at the end of the answer.
+func (f *pickFilter) Flush() error {
+	rest := f.pending
+	f.pending = ""
+	return f.send(rest)
+}
+
+func (f *pickFilter) pick(imdbID string) string {
+	movie, ok := f.resolve(imdbID)
+	if !ok {
+		return ""
+	}
+	if !f.picked[imdbID] {
+		f.picked[imdbID] = true
+		f.picks = append(f.picks, movie)
+	}
+	return movie.Title
+}
+
+func (f *pickFilter) send(text string) error {
+	if text == "" {
+		return nil
+	}
+	f.text.WriteString(text)
+	if f.emit == nil {
+		return nil
+	}
+	return f.emit(text)
+}
@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/llm"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tmc/langchaingo/llms"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func assistantConfig() *config.Config {
+	return &config.Config{AssistantConversationTTL: time.Minute, AssistantMaxTurns: 10, AssistantCandidates: 10}
+}
+
+func TestAssistantService_ChatKeepsOnlyCatalogPicks(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	model := llm.NewScripted("Try [[tt1]] or [[tt404]] tonight.")
+	svc := service.NewAssistantService(movieRepo, userRepo, nil, model, assistantConfig())
+
+	primer := models.Movie{ImdbID: "tt1", Title: "Primer", Genre: []models.Genre{{GenreName: "Sci-Fi"}}}
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "u1").Return([]string{"Sci-Fi"}, nil)
+	movieRepo.On("GetRecommendedMovies", mock.Anything, []string{"Sci-Fi"}, []string(nil), int64(0), int64(10)).
+		Return([]models.Movie{primer}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt404").Return(nil, mongo.ErrNoDocuments)
+
+	reply, err := svc.Chat(context.Background(), "u1", "something mind bending", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Try Primer or  tonight.", reply.Message)
+	assert.Equal(t, []models.Movie{primer}, reply.Picks)
+
+	prompt := model.Calls()[0][0].Parts[0].(llms.TextContent).Text
+	assert.Contains(t, prompt, "favourite genres: Sci-Fi")
+	assert.Contains(t, prompt, "tt1 | Primer | Sci-Fi")
+}
+
+func TestAssistantService_ChatStreamsAndRemembersConversation(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	semantic := new(mocks.MockSemanticService)
+	model := llm.NewScripted("How about [[tt2]]?", "Then [[tt1]] it is.", "Hello again.")
+	svc := service.NewAssistantService(movieRepo, userRepo, semantic, model, assistantConfig())
+
+	primer := models.Movie{ImdbID: "tt1", Title: "Primer"}
+	looper := models.Movie{ImdbID: "tt2", Title: "Looper"}
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "u1").Return([]string{}, nil)
+	movieRepo.On("GetRecommendedMovies", mock.Anything, []string{}, []string(nil), int64(0), int64(5)).
+		Return([]models.Movie{primer}, nil)
+	semantic.On("Search", mock.Anything, mock.Anything, 10).
+		Return([]models.SearchResult{{Movie: looper, Score: 0.9}}, nil)
+
+	var chunks []string
+	reply, err := svc.Chat(context.Background(), "u1", "time travel", func(text string) error {
+		chunks = append(chunks, text)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "How about Looper?", strings.Join(chunks, ""))
+	assert.Equal(t, []models.Movie{looper}, reply.Picks)
+
+	reply, err = svc.Chat(context.Background(), "u1", "something older", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Then Primer it is.", reply.Message)
+	second := model.Calls()[1]
+	assert.Len(t, second, 4)
+	assert.Equal(t, llms.ChatMessageTypeAI, second[2].Role)
+	assert.Equal(t, "How about [[tt2]]?", second[2].Parts[0].(llms.TextContent).Text)
+
+	svc.ResetConversation("u1")
+	_, err = svc.Chat(context.Background(), "u1", "hi", nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, model.Calls()[2], 2)
+}
+
+func TestAssistantService_ChatWithoutModel(t *testing.T) {
+	svc := service.NewAssistantService(nil, nil, nil, nil, assistantConfig())
+
+	_, err := svc.Chat(context.Background(), "u1", "anything", nil)
+
+	assert.True(t, errors.Is(err, service.ErrAssistantUnavailable))
+}
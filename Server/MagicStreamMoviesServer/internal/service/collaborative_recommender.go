@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+)
+
+// unrankedValue is the ranking_value given to movies the admin has not reviewed yet.
+const unrankedValue = 999
+
+// recommendationPool is how many candidates each source supplies. The pool is the same for every
+// page, so pages of one ranking never overlap or skip titles; recommendations end after it.
+const recommendationPool = 500
+
+// maxWatchedReasons caps how many "because you watched" reasons a recommendation carries.
+const maxWatchedReasons = 3
+
+// collaborativeRecommender blends item-item collaborative filtering over the precomputed
+// movie_similarities with genre affinity, the admin ranking and, when movie embeddings are
+// available, how close a movie's embedding is to the user's taste.
+type collaborativeRecommender struct {
+	movieRepo      repository.MovieRepository
+	userRepo       repository.UserRepository
+	activityRepo   repository.ActivityRepository
+	similarityRepo repository.SimilarityRepository
+	vectors        *search.VectorIndex
+	config         *config.Config
+}
+
+// NewCollaborativeRecommender builds the blended recommender. vectors may be nil, in which case
+// the semantic signal is always zero.
+func NewCollaborativeRecommender(
+	movieRepo repository.MovieRepository,
+	userRepo repository.UserRepository,
+	activityRepo repository.ActivityRepository,
+	similarityRepo repository.SimilarityRepository,
+	vectors *search.VectorIndex,
+	cfg *config.Config,
+) Recommender {
+	return &collaborativeRecommender{
+		movieRepo:      movieRepo,
+		userRepo:       userRepo,
+		activityRepo:   activityRepo,
+		similarityRepo: similarityRepo,
+		vectors:        vectors,
+		config:         cfg,
+	}
+}
+
+// userProfile is everything the blended scorer needs to know about one user.
+type userProfile struct {
+	favourites []string
+	prefs      map[string]float64
+	seen       []string
+	seenTitles map[string]string
+	affinity   map[string]float64
+	cf         map[string]float64
+	// cfSources records, per candidate, how much each seen movie contributed to its CF score.
+	cfSources map[string]map[string]float64
+	// taste is the preference-weighted mean embedding of the seen movies, or nil without any.
+	taste []float32
+}
+
+func (r *collaborativeRecommender) Recommend(ctx context.Context, userId string, page models.Pagination) ([]models.RecommendedMovie, error) {
+	page = normalizePage(page, r.config.RecommendedMovieLimit)
+
+	profile, err := r.buildProfile(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The genre query supplies candidates for users with little or no history; the strongest CF
+	// and embedding neighbours supply the rest.
+	genreCandidates, err := r.movieRepo.GetRecommendedMovies(ctx, profile.favourites, profile.seen, 0, recommendationPool)
+	if err != nil {
+		return nil, err
+	}
+	neighbourIDs := make([]string, 0, len(profile.cf))
+	for imdbID := range profile.cf {
+		neighbourIDs = append(neighbourIDs, imdbID)
+	}
+	sort.Slice(neighbourIDs, func(i, j int) bool {
+		a, b := profile.cf[neighbourIDs[i]], profile.cf[neighbourIDs[j]]
+		if a != b {
+			return a > b
+		}
+		return neighbourIDs[i] < neighbourIDs[j]
+	})
+	if len(neighbourIDs) > recommendationPool {
+		neighbourIDs = neighbourIDs[:recommendationPool]
+	}
+	if profile.taste != nil {
+		for _, hit := range r.vectors.Search(profile.taste, recommendationPool, profile.seen) {
+			if _, ok := profile.cf[hit.ImdbID]; !ok {
+				neighbourIDs = append(neighbourIDs, hit.ImdbID)
+			}
+		}
+	}
+	sort.Strings(neighbourIDs)
+	neighbourCandidates, err := r.movieRepo.GetMoviesByIDs(ctx, neighbourIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]models.RecommendedMovie)
+	for _, movie := range append(genreCandidates, neighbourCandidates...) {
+		if _, ok := candidates[movie.ImdbID]; ok {
+			continue
+		}
+		score, _ := r.score(movie, profile)
+		candidates[movie.ImdbID] = models.RecommendedMovie{
+			Movie:   movie,
+			Score:   score,
+			Reasons: r.reasons(movie, profile),
+		}
+	}
+
+	ranked := make([]models.RecommendedMovie, 0, len(candidates))
+	for _, c := range candidates {
+		ranked = append(ranked, c)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].ImdbID < ranked[j].ImdbID
+	})
+
+	recommended := []models.RecommendedMovie{}
+	for i := page.Skip(); i < int64(len(ranked)) && i < page.Skip()+page.PageSize; i++ {
+		recommended = append(recommended, ranked[i])
+	}
+	return recommended, nil
+}
+
+func (r *collaborativeRecommender) Explain(ctx context.Context, userId string, imdbID string) (*models.RecommendationExplanation, error) {
+	movie, err := r.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := r.buildProfile(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	score, components := r.score(*movie, profile)
+	return &models.RecommendationExplanation{
+		ImdbID:      movie.ImdbID,
+		Title:       movie.Title,
+		Strategy:    StrategyCollaborative,
+		Score:       score,
+		AlreadySeen: slices.Contains(profile.seen, imdbID),
+		Components:  components,
+		Reasons:     r.reasons(*movie, profile),
+	}, nil
+}
+
+func (r *collaborativeRecommender) buildProfile(ctx context.Context, userId string) (*userProfile, error) {
+	favourites, err := r.userRepo.GetUserFavouriteGenres(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	history, err := r.activityRepo.GetWatchHistory(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := r.activityRepo.GetUserReviews(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &userProfile{
+		favourites: favourites,
+		prefs:      interactionWeights(history, reviews),
+		seenTitles: make(map[string]string),
+		cf:         make(map[string]float64),
+		cfSources:  make(map[string]map[string]float64),
+	}
+	for imdbID := range profile.prefs {
+		profile.seen = append(profile.seen, imdbID)
+	}
+	sort.Strings(profile.seen)
+
+	if len(profile.seen) > 0 {
+		if err := r.addCFScores(ctx, profile); err != nil {
+			return nil, err
+		}
+	}
+
+	seenMovies, err := r.movieRepo.GetMoviesByIDs(ctx, profile.seen)
+	if err != nil {
+		return nil, err
+	}
+	for _, movie := range seenMovies {
+		profile.seenTitles[movie.ImdbID] = movie.Title
+	}
+	profile.affinity = genreAffinity(favourites, seenMovies, profile.prefs)
+	profile.taste = r.taste(profile)
+	return profile, nil
+}
+
+// taste averages the embeddings of the seen movies, weighted by how much the user liked them.
+func (r *collaborativeRecommender) taste(profile *userProfile) []float32 {
+	if r.vectors == nil {
+		return nil
+	}
+	var taste []float32
+	for _, imdbID := range profile.seen {
+		vector, ok := r.vectors.Get(imdbID)
+		if !ok {
+			continue
+		}
+		if taste == nil {
+			taste = make([]float32, len(vector))
+		}
+		if len(vector) != len(taste) {
+			continue
+		}
+		for i, v := range vector {
+			taste[i] += float32(profile.prefs[imdbID]) * v
+		}
+	}
+	return taste
+}
+
+// semanticScore is the cosine similarity of a movie's embedding with the user's taste, clamped
+// at zero.
+func (r *collaborativeRecommender) semanticScore(movie models.Movie, profile *userProfile) float64 {
+	if profile.taste == nil {
+		return 0
+	}
+	vector, ok := r.vectors.Get(movie.ImdbID)
+	if !ok {
+		return 0
+	}
+	return max(0, search.Dot(search.Normalize(profile.taste), vector))
+}
+
+// addCFScores predicts a preference for every unseen neighbour of the user's movies as the
+// similarity-weighted average of the user's preferences for the movies it is similar to.
+func (r *collaborativeRecommender) addCFScores(ctx context.Context, profile *userProfile) error {
+	similarities, err := r.similarityRepo.GetSimilarities(ctx, profile.seen)
+	if err != nil {
+		return err
+	}
+
+	norms := make(map[string]float64)
+	for _, sim := range similarities {
+		for _, neighbor := range sim.Neighbors {
+			if _, ok := profile.prefs[neighbor.ImdbID]; ok {
+				continue
+			}
+			contribution := neighbor.Score * profile.prefs[sim.ImdbID]
+			profile.cf[neighbor.ImdbID] += contribution
+			norms[neighbor.ImdbID] += neighbor.Score
+			if profile.cfSources[neighbor.ImdbID] == nil {
+				profile.cfSources[neighbor.ImdbID] = make(map[string]float64)
+			}
+			profile.cfSources[neighbor.ImdbID][sim.ImdbID] += contribution
+		}
+	}
+	for imdbID, norm := range norms {
+		if norm > 0 {
+			profile.cf[imdbID] /= norm
+		}
+	}
+	return nil
+}
+
+func (r *collaborativeRecommender) score(movie models.Movie, profile *userProfile) (float64, []models.ScoreComponent) {
+	weights := r.config.RecommendationWeights
+	components := []models.ScoreComponent{
+		{Signal: "collaborative_filtering", Value: profile.cf[movie.ImdbID], Weight: weights.CF},
+		{Signal: "genre_affinity", Value: movieGenreAffinity(movie, profile.affinity), Weight: weights.Genre},
+		{Signal: "ranking", Value: rankingScore(movie.Ranking), Weight: weights.Ranking},
+		{Signal: "semantic", Value: r.semanticScore(movie, profile), Weight: weights.Semantic},
+	}
+
+	score := 0.0
+	for i := range components {
+		components[i].Contribution = components[i].Value * components[i].Weight
+		score += components[i].Contribution
+	}
+	return score, components
+}
+
+func (r *collaborativeRecommender) reasons(movie models.Movie, profile *userProfile) []models.RecommendationReason {
+	reasons := []models.RecommendationReason{}
+
+	sources := make([]string, 0, len(profile.cfSources[movie.ImdbID]))
+	for imdbID := range profile.cfSources[movie.ImdbID] {
+		sources = append(sources, imdbID)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		a, b := profile.cfSources[movie.ImdbID][sources[i]], profile.cfSources[movie.ImdbID][sources[j]]
+		if a != b {
+			return a > b
+		}
+		return sources[i] < sources[j]
+	})
+	for i, imdbID := range sources {
+		if i == maxWatchedReasons {
+			break
+		}
+		reasons = append(reasons, models.RecommendationReason{
+			Type:   models.ReasonBecauseYouWatched,
+			ImdbID: imdbID,
+			Title:  profile.seenTitles[imdbID],
+		})
+	}
+
+	for _, genre := range movie.Genre {
+		if profile.affinity[genre.GenreName] > 0 {
+			reasons = append(reasons, models.RecommendationReason{Type: models.ReasonGenreMatch, Genre: genre.GenreName})
+		}
+	}
+
+	if isTopRanked(movie.Ranking) || len(reasons) == 0 {
+		reasons = append(reasons, models.RecommendationReason{Type: models.ReasonTopRanked, RankingName: movie.Ranking.RankingName})
+	}
+	return reasons
+}
+
+// interactionWeights turns a user's activity into a preference in [0, 1] per movie. An explicit
+// rating wins over watch history; otherwise a completed watch counts fully and a partial one half.
+func interactionWeights(history []models.WatchHistory, reviews []models.Review) map[string]float64 {
+	prefs := make(map[string]float64, len(history)+len(reviews))
+	for _, entry := range history {
+		if entry.Completed {
+			prefs[entry.ImdbID] = 1
+		} else {
+			prefs[entry.ImdbID] = 0.5
+		}
+	}
+	for _, review := range reviews {
+		prefs[review.ImdbID] = float64(review.Rating) / 5
+	}
+	return prefs
+}
+
+// genreAffinity weighs genres by the user's favourites and the genres of movies they liked,
+// normalised so the strongest genre scores 1.
+func genreAffinity(favourites []string, seenMovies []models.Movie, prefs map[string]float64) map[string]float64 {
+	affinity := make(map[string]float64)
+	for _, genre := range favourites {
+		affinity[genre] += 1
+	}
+	for _, movie := range seenMovies {
+		for _, genre := range movie.Genre {
+			affinity[genre.GenreName] += prefs[movie.ImdbID]
+		}
+	}
+
+	max := 0.0
+	for _, v := range affinity {
+		if v > max {
+			max = v
+		}
+	}
+	if max > 0 {
+		for genre := range affinity {
+			affinity[genre] /= max
+		}
+	}
+	return affinity
+}
+
+func movieGenreAffinity(movie models.Movie, affinity map[string]float64) float64 {
+	if len(movie.Genre) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, genre := range movie.Genre {
+		total += affinity[genre.GenreName]
+	}
+	return total / float64(len(movie.Genre))
+}
+
+// rankingScore maps the admin ranking (1 is best) into (0, 1]; unranked movies score 0.
+func rankingScore(ranking models.Ranking) float64 {
+	if ranking.RankingValue <= 0 || ranking.RankingValue == unrankedValue {
+		return 0
+	}
+	return 1 / float64(ranking.RankingValue)
+}
+
+// isTopRanked reports whether the admin ranked the movie in one of the two best rankings.
+func isTopRanked(ranking models.Ranking) bool {
+	return rankingScore(ranking) >= 0.5
+}
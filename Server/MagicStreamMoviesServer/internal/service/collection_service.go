@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrUnknownTitles      = errors.New("unknown titles")
+)
+
+const defaultCollectionPageSize = 20
+
+type CollectionService interface {
+	GetCollections(ctx context.Context, page models.Pagination) ([]models.Collection, error)
+	// GetCollection returns the collection with its titles in viewing order.
+	GetCollection(ctx context.Context, id string) (*models.CollectionDetail, error)
+	CreateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error)
+	UpdateCollection(ctx context.Context, id string, collection models.Collection) (*models.Collection, error)
+	DeleteCollection(ctx context.Context, id string) error
+}
+
+type collectionService struct {
+	collectionRepo repository.CollectionRepository
+	movieRepo      repository.MovieRepository
+}
+
+func NewCollectionService(collectionRepo repository.CollectionRepository, movieRepo repository.MovieRepository) CollectionService {
+	return &collectionService{
+		collectionRepo: collectionRepo,
+		movieRepo:      movieRepo,
+	}
+}
+
+func (s *collectionService) GetCollections(ctx context.Context, page models.Pagination) ([]models.Collection, error) {
+	page = normalizePage(page, defaultCollectionPageSize)
+	return s.collectionRepo.GetCollections(ctx, page.Skip(), page.PageSize)
+}
+
+func (s *collectionService) GetCollection(ctx context.Context, id string) (*models.CollectionDetail, error) {
+	collectionID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.getCollection(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, collection.ImdbIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CollectionDetail{Collection: *collection, Movies: orderByIDs(movies, collection.ImdbIDs)}, nil
+}
+
+func (s *collectionService) CreateCollection(ctx context.Context, collection models.Collection) (*models.Collection, error) {
+	if err := s.checkTitles(ctx, collection.ImdbIDs); err != nil {
+		return nil, err
+	}
+	created, err := s.collectionRepo.CreateCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	s.refreshMemberships(ctx, created.ImdbIDs...)
+	return created, nil
+}
+
+func (s *collectionService) UpdateCollection(ctx context.Context, id string, collection models.Collection) (*models.Collection, error) {
+	collectionID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.getCollection(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTitles(ctx, collection.ImdbIDs); err != nil {
+		return nil, err
+	}
+
+	collection.ID = collectionID
+	if err := s.collectionRepo.UpdateCollection(ctx, collection); err != nil {
+		return nil, err
+	}
+	// Titles that left the collection lose their membership, the others get the new name and position.
+	s.refreshMemberships(ctx, append(existing.ImdbIDs, collection.ImdbIDs...)...)
+	return s.collectionRepo.GetCollection(ctx, collectionID)
+}
+
+func (s *collectionService) DeleteCollection(ctx context.Context, id string) error {
+	collectionID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	existing, err := s.getCollection(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	if err := s.collectionRepo.DeleteCollection(ctx, collectionID); err != nil {
+		return err
+	}
+	s.refreshMemberships(ctx, existing.ImdbIDs...)
+	return nil
+}
+
+// checkTitles reports the imdb_ids that are not in the catalog.
+func (s *collectionService) checkTitles(ctx context.Context, imdbIDs []string) error {
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, imdbIDs)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(movies))
+	for _, movie := range movies {
+		known[movie.ImdbID] = true
+	}
+	var missing []string
+	for _, id := range imdbIDs {
+		if !known[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownTitles, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// refreshMemberships copies the collections listing each movie onto the movie. The collection
+// itself is already saved, so failures are only logged.
+func (s *collectionService) refreshMemberships(ctx context.Context, imdbIDs ...string) {
+	done := make(map[string]bool, len(imdbIDs))
+	for _, imdbID := range imdbIDs {
+		if done[imdbID] {
+			continue
+		}
+		done[imdbID] = true
+
+		collections, err := s.collectionRepo.GetCollectionsContaining(ctx, imdbID)
+		if err != nil {
+			log.Printf("failed to load collections of %s: %v", imdbID, err)
+			continue
+		}
+		memberships := []models.CollectionMembership{}
+		for _, collection := range collections {
+			memberships = append(memberships, models.CollectionMembership{
+				ID:       collection.ID,
+				Name:     collection.Name,
+				Position: slices.Index(collection.ImdbIDs, imdbID) + 1,
+			})
+		}
+		if err := s.movieRepo.SetMovieCollections(ctx, imdbID, memberships); err != nil {
+			log.Printf("failed to update collections of %s: %v", imdbID, err)
+		}
+	}
+}
+
+func (s *collectionService) getCollection(ctx context.Context, id bson.ObjectID) (*models.Collection, error) {
+	collection, err := s.collectionRepo.GetCollection(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCollectionNotFound
+	}
+	return collection, err
+}
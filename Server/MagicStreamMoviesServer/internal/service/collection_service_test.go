@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestCollectionService_CreateRejectsUnknownTitles(t *testing.T) {
+	collectionRepo := new(mocks.MockCollectionRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewCollectionService(collectionRepo, movieRepo)
+
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt0133093", "tt9999999"}).
+		Return([]models.Movie{{ImdbID: "tt0133093"}}, nil)
+
+	_, err := svc.CreateCollection(context.Background(), models.Collection{Name: "The Matrix Collection", ImdbIDs: []string{"tt0133093", "tt9999999"}})
+
+	assert.True(t, errors.Is(err, service.ErrUnknownTitles))
+	assert.Contains(t, err.Error(), "tt9999999")
+	collectionRepo.AssertNotCalled(t, "CreateCollection", mock.Anything, mock.Anything)
+}
+
+func TestCollectionService_UpdateRefreshesMemberships(t *testing.T) {
+	collectionRepo := new(mocks.MockCollectionRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewCollectionService(collectionRepo, movieRepo)
+
+	id := bson.NewObjectID()
+	existing := models.Collection{ID: id, Name: "Matrix", ImdbIDs: []string{"tt0133093", "tt10838180"}}
+	updated := models.Collection{ID: id, Name: "The Matrix Collection", ImdbIDs: []string{"tt0133093", "tt0234215"}}
+	collectionRepo.On("GetCollection", mock.Anything, id).Return(&existing, nil).Once()
+	movieRepo.On("GetMoviesByIDs", mock.Anything, updated.ImdbIDs).
+		Return([]models.Movie{{ImdbID: "tt0133093"}, {ImdbID: "tt0234215"}}, nil)
+	collectionRepo.On("UpdateCollection", mock.Anything, updated).Return(nil)
+	collectionRepo.On("GetCollectionsContaining", mock.Anything, "tt0133093").Return([]models.Collection{updated}, nil)
+	collectionRepo.On("GetCollectionsContaining", mock.Anything, "tt0234215").Return([]models.Collection{updated}, nil)
+	// Resurrections left the collection and loses its membership.
+	collectionRepo.On("GetCollectionsContaining", mock.Anything, "tt10838180").Return([]models.Collection{}, nil)
+	movieRepo.On("SetMovieCollections", mock.Anything, "tt0133093",
+		[]models.CollectionMembership{{ID: id, Name: "The Matrix Collection", Position: 1}}).Return(nil)
+	movieRepo.On("SetMovieCollections", mock.Anything, "tt0234215",
+		[]models.CollectionMembership{{ID: id, Name: "The Matrix Collection", Position: 2}}).Return(nil)
+	movieRepo.On("SetMovieCollections", mock.Anything, "tt10838180", []models.CollectionMembership{}).Return(nil)
+	collectionRepo.On("GetCollection", mock.Anything, id).Return(&updated, nil).Once()
+
+	result, err := svc.UpdateCollection(context.Background(), id.Hex(), models.Collection{Name: "The Matrix Collection", ImdbIDs: updated.ImdbIDs})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "The Matrix Collection", result.Name)
+	movieRepo.AssertExpectations(t)
+}
+
+func TestCollectionService_GetCollectionInViewingOrder(t *testing.T) {
+	collectionRepo := new(mocks.MockCollectionRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewCollectionService(collectionRepo, movieRepo)
+
+	id := bson.NewObjectID()
+	collectionRepo.On("GetCollection", mock.Anything, id).
+		Return(&models.Collection{ID: id, ImdbIDs: []string{"tt0133093", "tt0234215"}}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt0133093", "tt0234215"}).
+		Return([]models.Movie{{ImdbID: "tt0234215"}, {ImdbID: "tt0133093"}}, nil)
+
+	detail, err := svc.GetCollection(context.Background(), id.Hex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tt0133093", detail.Movies[0].ImdbID)
+	assert.Equal(t, "tt0234215", detail.Movies[1].ImdbID)
+}
+
+func TestCollectionService_GetCollectionErrors(t *testing.T) {
+	collectionRepo := new(mocks.MockCollectionRepository)
+	svc := service.NewCollectionService(collectionRepo, nil)
+
+	_, err := svc.GetCollection(context.Background(), "matrix")
+	assert.True(t, errors.Is(err, service.ErrInvalidID))
+
+	id := bson.NewObjectID()
+	collectionRepo.On("GetCollection", mock.Anything, id).Return(nil, mongo.ErrNoDocuments)
+	_, err = svc.GetCollection(context.Background(), id.Hex())
+	assert.True(t, errors.Is(err, service.ErrCollectionNotFound))
+}
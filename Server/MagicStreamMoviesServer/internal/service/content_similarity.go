@@ -0,0 +1,151 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/pkg/utils"
+)
+
+// Weights of the content-based "more like this" signals.
+const (
+	contentGenreWeight   = 0.5
+	contentRankingWeight = 0.2
+	contentTextWeight    = 0.3
+)
+
+// maxSimilarMovies is how many neighbours are computed and cached per source movie.
+const maxSimilarMovies = 50
+
+// RankSimilarMovies scores every other movie in the catalog against source by genre overlap
+// (Jaccard over genre IDs), ranking proximity and TF-IDF cosine similarity of the movies' text,
+// and returns the best matches in descending order.
+func RankSimilarMovies(source models.Movie, catalog []models.Movie, limit int) []models.SimilarMovie {
+	vectors := tfidfVectors(catalog)
+	sourceVector := vectors[source.ImdbID]
+	if sourceVector == nil {
+		// The source may not be part of the catalog slice; weigh it against the catalog's IDF.
+		sourceVector = tfidfVectors(append([]models.Movie{source}, catalog...))[source.ImdbID]
+	}
+
+	results := make([]models.SimilarMovie, 0, len(catalog))
+	for _, movie := range catalog {
+		if movie.ImdbID == source.ImdbID {
+			continue
+		}
+		score := contentGenreWeight*genreJaccard(source, movie) +
+			contentRankingWeight*rankingProximity(source.Ranking, movie.Ranking) +
+			contentTextWeight*cosine(sourceVector, vectors[movie.ImdbID])
+		if score <= 0 {
+			continue
+		}
+		results = append(results, models.SimilarMovie{ImdbID: movie.ImdbID, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ImdbID < results[j].ImdbID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func genreJaccard(a, b models.Movie) float64 {
+	set := make(map[int]struct{}, len(a.Genre))
+	for _, g := range a.Genre {
+		set[g.GenreID] = struct{}{}
+	}
+
+	union := len(set)
+	intersection := 0
+	seen := make(map[int]struct{}, len(b.Genre))
+	for _, g := range b.Genre {
+		if _, dup := seen[g.GenreID]; dup {
+			continue
+		}
+		seen[g.GenreID] = struct{}{}
+		if _, ok := set[g.GenreID]; ok {
+			intersection++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+// rankingProximity is 1 for identical admin rankings and decays as they move apart. Unranked
+// movies carry no ranking signal.
+func rankingProximity(a, b models.Ranking) float64 {
+	if rankingScore(a) == 0 || rankingScore(b) == 0 {
+		return 0
+	}
+	return 1 / (1 + math.Abs(float64(a.RankingValue-b.RankingValue)))
+}
+
+// movieText is the free text describing a movie that feeds TF-IDF, cast and crew included.
+func movieText(movie models.Movie) string {
+	parts := []string{movie.Title, movie.AdminReview, movie.Synopsis}
+	parts = append(parts, movie.Keywords...)
+	parts = append(parts, movie.MoodTags...)
+	parts = append(parts, movie.CreditNames...)
+	return strings.Join(parts, " ")
+}
+
+func tfidfVectors(movies []models.Movie) map[string]map[string]float64 {
+	termFreqs := make(map[string]map[string]float64, len(movies))
+	docFreq := make(map[string]int)
+	for _, movie := range movies {
+		if _, dup := termFreqs[movie.ImdbID]; dup {
+			continue
+		}
+		tf := make(map[string]float64)
+		for _, token := range utils.Tokenize(movieText(movie)) {
+			tf[token]++
+		}
+		for term := range tf {
+			docFreq[term]++
+		}
+		termFreqs[movie.ImdbID] = tf
+	}
+
+	n := float64(len(termFreqs))
+	vectors := make(map[string]map[string]float64, len(termFreqs))
+	for imdbID, tf := range termFreqs {
+		vector := make(map[string]float64, len(tf))
+		for term, freq := range tf {
+			idf := math.Log((n+1)/(float64(docFreq[term])+1)) + 1
+			vector[term] = freq * idf
+		}
+		vectors[imdbID] = vector
+	}
+	return vectors
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for term, wa := range a {
+		normA += wa * wa
+		if wb, ok := b[term]; ok {
+			dot += wa * wb
+		}
+	}
+	for _, wb := range b {
+		normB += wb * wb
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
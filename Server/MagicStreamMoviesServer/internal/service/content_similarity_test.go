@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankSimilarMovies(t *testing.T) {
+	action := models.Genre{GenreID: 1, GenreName: "Action"}
+	scifi := models.Genre{GenreID: 2, GenreName: "Sci-Fi"}
+	romance := models.Genre{GenreID: 3, GenreName: "Romance"}
+
+	source := models.Movie{
+		ImdbID:      "tt1",
+		Title:       "Robot Uprising",
+		Genre:       []models.Genre{action, scifi},
+		AdminReview: "Robots rebel against their makers in a dazzling space battle.",
+		Ranking:     models.Ranking{RankingValue: 1},
+	}
+	catalog := []models.Movie{
+		source,
+		{
+			ImdbID:      "tt2",
+			Title:       "Robot Revenge",
+			Genre:       []models.Genre{action, scifi},
+			AdminReview: "The robots return for another space battle.",
+			Ranking:     models.Ranking{RankingValue: 2},
+		},
+		{
+			ImdbID:  "tt3",
+			Title:   "Explosions",
+			Genre:   []models.Genre{action},
+			Ranking: models.Ranking{RankingValue: 999},
+		},
+		{
+			ImdbID:      "tt4",
+			Title:       "Love in Paris",
+			Genre:       []models.Genre{romance},
+			AdminReview: "A gentle love story.",
+			Ranking:     models.Ranking{RankingValue: 5},
+		},
+	}
+
+	results := service.RankSimilarMovies(source, catalog, 10)
+
+	assert.Len(t, results, 3)
+	assert.Equal(t, "tt2", results[0].ImdbID)
+	assert.Equal(t, "tt3", results[1].ImdbID)
+	assert.Equal(t, "tt4", results[2].ImdbID)
+	for _, r := range results {
+		assert.NotEqual(t, "tt1", r.ImdbID, "source movie must not be similar to itself")
+	}
+
+	assert.Len(t, service.RankSimilarMovies(source, catalog, 1), 1)
+}
+
+func TestRankSimilarMovies_SharedCast(t *testing.T) {
+	drama := models.Genre{GenreID: 1, GenreName: "Drama"}
+	source := models.Movie{ImdbID: "tt1", Title: "First", Genre: []models.Genre{drama}, CreditNames: []string{"Ada Lovelace"}}
+	catalog := []models.Movie{
+		source,
+		{ImdbID: "tt2", Title: "Second", Genre: []models.Genre{drama}},
+		{ImdbID: "tt3", Title: "Third", Genre: []models.Genre{drama}, CreditNames: []string{"Ada Lovelace"}},
+	}
+
+	results := service.RankSimilarMovies(source, catalog, 10)
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, "tt3", results[0].ImdbID)
+}
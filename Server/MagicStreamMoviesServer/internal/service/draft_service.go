@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/tmc/langchaingo/llms"
+)
+
+var (
+	ErrDraftsUnavailable = errors.New("draft generation is not configured")
+	ErrNoDraft           = errors.New("movie has no draft")
+	ErrInvalidDraft      = errors.New("model returned an invalid draft")
+)
+
+// Limits applied to generated drafts so a verbose model cannot bloat the movie documents.
+const (
+	maxDraftSynopsis = 600
+	maxDraftKeywords = 10
+	maxDraftMoodTags = 5
+)
+
+const draftPrompt = `Write catalog metadata for the movie below. Answer with JSON only, in the form
+{"synopsis": "...", "keywords": ["..."], "mood_tags": ["..."]}
+The synopsis is at most three sentences and free of spoilers. Keywords are up to %d short content keywords
+(themes, settings, subjects). Mood tags are up to %d single words or short phrases describing how the movie
+feels, such as "uplifting" or "tense".
+
+Title: %s
+Genres: %s
+%s`
+
+type DraftService interface {
+	// GenerateDraft asks the model for a synopsis, keywords and mood tags and stores them as a
+	// draft on the movie, replacing any earlier draft.
+	GenerateDraft(ctx context.Context, imdbID string) (*models.MovieDraft, error)
+	GetDraft(ctx context.Context, imdbID string) (*models.MovieDraft, error)
+	// ApproveDraft publishes the draft to the movie's synopsis, keywords and mood tags. Fields
+	// set in edits replace the generated ones.
+	ApproveDraft(ctx context.Context, imdbID string, edits *models.MovieDraft) (*models.Movie, error)
+	DiscardDraft(ctx context.Context, imdbID string) error
+	// GenerateMissingDrafts drafts every movie that has neither a synopsis nor a pending draft,
+	// or every movie when overwrite is set. It returns how many drafts were generated.
+	GenerateMissingDrafts(ctx context.Context, overwrite bool) (int, error)
+}
+
+type draftService struct {
+	movieRepo repository.MovieRepository
+	model     llms.Model
+	modelName string
+	indexers  []MovieIndexer
+	now       func() time.Time
+}
+
+// NewDraftService builds the draft service. A nil model makes generation fail with
+// ErrDraftsUnavailable while existing drafts can still be reviewed.
+func NewDraftService(movieRepo repository.MovieRepository, model llms.Model, modelName string, indexers []MovieIndexer) DraftService {
+	return &draftService{
+		movieRepo: movieRepo,
+		model:     model,
+		modelName: modelName,
+		indexers:  indexers,
+		now:       time.Now,
+	}
+}
+
+func (s *draftService) GenerateDraft(ctx context.Context, imdbID string) (*models.MovieDraft, error) {
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, *movie)
+}
+
+func (s *draftService) generate(ctx context.Context, movie models.Movie) (*models.MovieDraft, error) {
+	if s.model == nil {
+		return nil, ErrDraftsUnavailable
+	}
+
+	genres := make([]string, 0, len(movie.Genre))
+	for _, genre := range movie.Genre {
+		genres = append(genres, genre.GenreName)
+	}
+	review := ""
+	if movie.AdminReview != "" {
+		review = "Review: " + movie.AdminReview
+	}
+	prompt := fmt.Sprintf(draftPrompt, maxDraftKeywords, maxDraftMoodTags, movie.Title, strings.Join(genres, ", "), review)
+
+	response, err := llms.GenerateFromSinglePrompt(ctx, s.model, prompt, llms.WithJSONMode())
+	if err != nil {
+		return nil, err
+	}
+	draft, err := parseDraft(response)
+	if err != nil {
+		return nil, err
+	}
+	draft.Model = s.modelName
+	draft.GeneratedAt = s.now()
+
+	if err := s.movieRepo.SetMovieDraft(ctx, movie.ImdbID, *draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (s *draftService) GetDraft(ctx context.Context, imdbID string) (*models.MovieDraft, error) {
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.AIDraft == nil {
+		return nil, ErrNoDraft
+	}
+	return movie.AIDraft, nil
+}
+
+func (s *draftService) ApproveDraft(ctx context.Context, imdbID string, edits *models.MovieDraft) (*models.Movie, error) {
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.AIDraft == nil {
+		return nil, ErrNoDraft
+	}
+	draft := *movie.AIDraft
+	if edits != nil {
+		if synopsis := strings.TrimSpace(edits.Synopsis); synopsis != "" {
+			draft.Synopsis = synopsis
+		}
+		if edits.Keywords != nil {
+			draft.Keywords = normalizeTags(edits.Keywords, maxDraftKeywords)
+		}
+		if edits.MoodTags != nil {
+			draft.MoodTags = normalizeTags(edits.MoodTags, maxDraftMoodTags)
+		}
+	}
+	if err := s.movieRepo.PublishMovieDraft(ctx, imdbID, draft); err != nil {
+		return nil, err
+	}
+
+	movie.Synopsis = draft.Synopsis
+	movie.Keywords = draft.Keywords
+	movie.MoodTags = draft.MoodTags
+	movie.AIDraft = nil
+	indexMovies(ctx, s.indexers, *movie)
+	return movie, nil
+}
+
+func (s *draftService) DiscardDraft(ctx context.Context, imdbID string) error {
+	return s.movieRepo.ClearMovieDraft(ctx, imdbID)
+}
+
+func (s *draftService) GenerateMissingDrafts(ctx context.Context, overwrite bool) (int, error) {
+	movies, err := s.movieRepo.GetMovies(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, movie := range movies {
+		if !overwrite && (movie.Synopsis != "" || movie.AIDraft != nil) {
+			continue
+		}
+		if _, err := s.generate(ctx, movie); err != nil {
+			// One bad answer should not stop the batch; the movie is retried on the next run.
+			if errors.Is(err, ErrDraftsUnavailable) || ctx.Err() != nil {
+				return generated, err
+			}
+			log.Printf("failed to generate draft for %s: %v", movie.ImdbID, err)
+			continue
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+// parseDraft reads the model's JSON answer, tolerating surrounding prose or code fences, and
+// normalises it to the draft limits.
+func parseDraft(response string) (*models.MovieDraft, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, ErrInvalidDraft
+	}
+
+	var draft models.MovieDraft
+	if err := json.Unmarshal([]byte(response[start:end+1]), &draft); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDraft, err)
+	}
+	draft.Synopsis = strings.TrimSpace(draft.Synopsis)
+	if draft.Synopsis == "" {
+		return nil, ErrInvalidDraft
+	}
+	if runes := []rune(draft.Synopsis); len(runes) > maxDraftSynopsis {
+		draft.Synopsis = strings.TrimSpace(string(runes[:maxDraftSynopsis]))
+	}
+	draft.Keywords = normalizeTags(draft.Keywords, maxDraftKeywords)
+	draft.MoodTags = normalizeTags(draft.MoodTags, maxDraftMoodTags)
+	return &draft, nil
+}
+
+// normalizeTags lower-cases and trims tags, dropping empty and repeated ones, and keeps at most
+// limit of them.
+func normalizeTags(tags []string, limit int) []string {
+	normalized := []string{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+		if len(normalized) == limit {
+			break
+		}
+	}
+	return normalized
+}
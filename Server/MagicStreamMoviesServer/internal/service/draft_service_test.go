@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/llm"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDraftService_GenerateDraft(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	model := llm.NewScripted("```json\n" + `{"synopsis": " A engineer builds a time machine. ", "keywords": ["Time Travel", "garage", "time travel", ""], "mood_tags": ["Cerebral"]}` + "\n```")
+	svc := service.NewDraftService(movieRepo, model, "gpt-test", nil)
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", Title: "Primer"}, nil)
+	movieRepo.On("SetMovieDraft", mock.Anything, "tt1", mock.Anything).Return(nil)
+
+	draft, err := svc.GenerateDraft(context.Background(), "tt1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "A engineer builds a time machine.", draft.Synopsis)
+	assert.Equal(t, []string{"time travel", "garage"}, draft.Keywords)
+	assert.Equal(t, []string{"cerebral"}, draft.MoodTags)
+	assert.Equal(t, "gpt-test", draft.Model)
+	movieRepo.AssertExpectations(t)
+}
+
+func TestDraftService_GenerateDraftRejectsInvalidAnswer(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewDraftService(movieRepo, llm.NewScripted("I cannot help with that."), "gpt-test", nil)
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", Title: "Primer"}, nil)
+
+	_, err := svc.GenerateDraft(context.Background(), "tt1")
+
+	assert.True(t, errors.Is(err, service.ErrInvalidDraft))
+	movieRepo.AssertNotCalled(t, "SetMovieDraft")
+}
+
+func TestDraftService_ApproveDraftPublishesAndIndexes(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	indexer := new(mocks.MockSearchService)
+	svc := service.NewDraftService(movieRepo, nil, "", []service.MovieIndexer{indexer})
+
+	draft := &models.MovieDraft{Synopsis: "Generated.", Keywords: []string{"garage"}, MoodTags: []string{"tense"}}
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", AIDraft: draft}, nil)
+	movieRepo.On("PublishMovieDraft", mock.Anything, "tt1", models.MovieDraft{
+		Synopsis: "Edited.", Keywords: []string{"garage"}, MoodTags: []string{"tense"},
+	}).Return(nil)
+	indexer.On("IndexMovie", mock.Anything, mock.MatchedBy(func(m models.Movie) bool {
+		return m.Synopsis == "Edited." && m.AIDraft == nil
+	})).Return(nil)
+
+	movie, err := svc.ApproveDraft(context.Background(), "tt1", &models.MovieDraft{Synopsis: "Edited."})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Edited.", movie.Synopsis)
+	movieRepo.AssertExpectations(t)
+	indexer.AssertExpectations(t)
+}
+
+func TestDraftService_GenerateMissingDrafts(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	model := llm.NewScripted(`{"synopsis": "Two loops.", "keywords": [], "mood_tags": []}`)
+	svc := service.NewDraftService(movieRepo, model, "gpt-test", nil)
+
+	movieRepo.On("GetMovies", mock.Anything).Return([]models.Movie{
+		{ImdbID: "tt1", Synopsis: "Published."},
+		{ImdbID: "tt2", AIDraft: &models.MovieDraft{Synopsis: "Pending."}},
+		{ImdbID: "tt3", Title: "Looper"},
+	}, nil)
+	movieRepo.On("SetMovieDraft", mock.Anything, "tt3", mock.Anything).Return(nil)
+
+	generated, err := svc.GenerateMissingDrafts(context.Background(), false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, generated)
+	movieRepo.AssertExpectations(t)
+}
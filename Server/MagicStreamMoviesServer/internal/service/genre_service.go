@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrGenreNotFound = errors.New("genre not found")
+	ErrGenreExists   = errors.New("a genre with this name already exists")
+	ErrGenreInUse    = errors.New("genre still has movies, merge it into another genre instead")
+	ErrMergeIntoSelf = errors.New("a genre cannot be merged into itself")
+	ErrUnknownGenres = errors.New("unknown genres")
+)
+
+type GenreService interface {
+	GetGenres(ctx context.Context) ([]models.Genre, error)
+	GetGenre(ctx context.Context, id int) (*models.Genre, error)
+	CreateGenre(ctx context.Context, req models.GenreRequest) (*models.Genre, error)
+	// RenameGenre renames the genre and every copy embedded in movies and users' favourites.
+	RenameGenre(ctx context.Context, id int, req models.GenreRequest) (*models.Genre, error)
+	// DeleteGenre removes a genre no movie is in, dropping it from users' favourites.
+	DeleteGenre(ctx context.Context, id int) error
+	// MergeGenre moves the movies and favourites of the genre over to the target genre and
+	// deletes it. It returns the target genre.
+	MergeGenre(ctx context.Context, id int, merge models.GenreMerge) (*models.Genre, error)
+}
+
+type genreService struct {
+	genreRepo repository.GenreRepository
+	movieRepo repository.MovieRepository
+	userRepo  repository.UserRepository
+	indexers  []MovieIndexer
+}
+
+func NewGenreService(genreRepo repository.GenreRepository, movieRepo repository.MovieRepository, userRepo repository.UserRepository, indexers []MovieIndexer) GenreService {
+	return &genreService{
+		genreRepo: genreRepo,
+		movieRepo: movieRepo,
+		userRepo:  userRepo,
+		indexers:  indexers,
+	}
+}
+
+func (s *genreService) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	return s.genreRepo.GetGenres(ctx)
+}
+
+func (s *genreService) GetGenre(ctx context.Context, id int) (*models.Genre, error) {
+	return s.getGenre(ctx, id)
+}
+
+func (s *genreService) CreateGenre(ctx context.Context, req models.GenreRequest) (*models.Genre, error) {
+	name := strings.TrimSpace(req.GenreName)
+	if err := s.checkNameFree(ctx, name, 0); err != nil {
+		return nil, err
+	}
+	return s.genreRepo.CreateGenre(ctx, name)
+}
+
+func (s *genreService) RenameGenre(ctx context.Context, id int, req models.GenreRequest) (*models.Genre, error) {
+	if _, err := s.getGenre(ctx, id); err != nil {
+		return nil, err
+	}
+	genre := models.Genre{GenreID: id, GenreName: strings.TrimSpace(req.GenreName)}
+	if err := s.checkNameFree(ctx, genre.GenreName, id); err != nil {
+		return nil, err
+	}
+
+	// The copies are updated even when the name is unchanged, so repeating a rename that failed
+	// halfway finishes it.
+	if err := s.genreRepo.UpdateGenre(ctx, genre); err != nil {
+		return nil, err
+	}
+	if err := s.movieRepo.RenameGenre(ctx, genre); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.RenameFavouriteGenre(ctx, genre); err != nil {
+		return nil, err
+	}
+	s.reindexGenre(ctx, id)
+	return &genre, nil
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, id int) error {
+	if _, err := s.getGenre(ctx, id); err != nil {
+		return err
+	}
+	imdbIDs, err := s.movieRepo.GetMovieIDsWithGenre(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(imdbIDs) > 0 {
+		return ErrGenreInUse
+	}
+	if err := s.userRepo.RemoveFavouriteGenre(ctx, id); err != nil {
+		return err
+	}
+	return s.genreRepo.DeleteGenre(ctx, id)
+}
+
+func (s *genreService) MergeGenre(ctx context.Context, id int, merge models.GenreMerge) (*models.Genre, error) {
+	if id == merge.TargetID {
+		return nil, ErrMergeIntoSelf
+	}
+	if _, err := s.getGenre(ctx, id); err != nil {
+		return nil, err
+	}
+	target, err := s.getGenre(ctx, merge.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The genre is deleted last, so a merge that failed halfway can simply be repeated.
+	if err := s.movieRepo.MergeGenre(ctx, id, *target); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.MergeFavouriteGenre(ctx, id, *target); err != nil {
+		return nil, err
+	}
+	if err := s.genreRepo.DeleteGenre(ctx, id); err != nil {
+		return nil, err
+	}
+	s.reindexGenre(ctx, target.GenreID)
+	return target, nil
+}
+
+// checkNameFree reports ErrGenreExists when a genre other than id already has the name.
+func (s *genreService) checkNameFree(ctx context.Context, name string, id int) error {
+	existing, err := s.genreRepo.GetGenreByName(ctx, name)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.GenreID != id {
+		return ErrGenreExists
+	}
+	return nil
+}
+
+// reindexGenre reindexes the movies in the genre, since genre names are searchable. The genre
+// itself is already saved, so failures are only logged.
+func (s *genreService) reindexGenre(ctx context.Context, id int) {
+	imdbIDs, err := s.movieRepo.GetMovieIDsWithGenre(ctx, id)
+	if err != nil {
+		log.Printf("failed to load movies of genre %d: %v", id, err)
+		return
+	}
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, imdbIDs)
+	if err != nil {
+		log.Printf("failed to reload movies of genre %d: %v", id, err)
+		return
+	}
+	indexMovies(ctx, s.indexers, movies...)
+}
+
+func (s *genreService) getGenre(ctx context.Context, id int) (*models.Genre, error) {
+	genre, err := s.genreRepo.GetGenre(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrGenreNotFound
+	}
+	return genre, err
+}
+
+// canonicalGenres checks that every genre exists and replaces the names sent by the client with
+// the stored ones, so a typo cannot introduce a genre. Repeated genres are dropped.
+func canonicalGenres(ctx context.Context, genreRepo repository.GenreRepository, genres []models.Genre) ([]models.Genre, error) {
+	if len(genres) == 0 {
+		return genres, nil
+	}
+	ids := make([]int, 0, len(genres))
+	for _, genre := range genres {
+		ids = append(ids, genre.GenreID)
+	}
+	stored, err := genreRepo.GetGenresByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]models.Genre, len(stored))
+	for _, genre := range stored {
+		byID[genre.GenreID] = genre
+	}
+
+	canonical := make([]models.Genre, 0, len(genres))
+	seen := make(map[int]bool, len(genres))
+	var missing []string
+	for _, id := range ids {
+		genre, ok := byID[id]
+		switch {
+		case !ok:
+			missing = append(missing, strconv.Itoa(id))
+		case !seen[id]:
+			seen[id] = true
+			canonical = append(canonical, genre)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownGenres, strings.Join(missing, ", "))
+	}
+	return canonical, nil
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestGenreService_CreateRejectsDuplicateName(t *testing.T) {
+	genreRepo := new(mocks.MockGenreRepository)
+	svc := service.NewGenreService(genreRepo, nil, nil, nil)
+
+	genreRepo.On("GetGenreByName", mock.Anything, "drama").Return(&models.Genre{GenreID: 1, GenreName: "Drama"}, nil)
+
+	_, err := svc.CreateGenre(context.Background(), models.GenreRequest{GenreName: " drama "})
+
+	assert.ErrorIs(t, err, service.ErrGenreExists)
+	genreRepo.AssertNotCalled(t, "CreateGenre", mock.Anything, mock.Anything)
+}
+
+func TestGenreService_RenamePropagatesAndReindexes(t *testing.T) {
+	genreRepo := new(mocks.MockGenreRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	index := search.NewMemoryIndex()
+	svc := service.NewGenreService(genreRepo, movieRepo, userRepo, []service.MovieIndexer{service.NewSearchService(index, movieRepo)})
+
+	renamed := models.Genre{GenreID: 3, GenreName: "Science Fiction"}
+	genreRepo.On("GetGenre", mock.Anything, 3).Return(&models.Genre{GenreID: 3, GenreName: "Sci-Fi"}, nil)
+	// Changing the case of the name is allowed.
+	genreRepo.On("GetGenreByName", mock.Anything, "Science Fiction").Return(nil, mongo.ErrNoDocuments)
+	genreRepo.On("UpdateGenre", mock.Anything, renamed).Return(nil)
+	movieRepo.On("RenameGenre", mock.Anything, renamed).Return(nil)
+	userRepo.On("RenameFavouriteGenre", mock.Anything, renamed).Return(nil)
+	movieRepo.On("GetMovieIDsWithGenre", mock.Anything, 3).Return([]string{"tt2543164"}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt2543164"}).
+		Return([]models.Movie{{ImdbID: "tt2543164", Title: "Arrival", Genre: []models.Genre{renamed}}}, nil)
+
+	genre, err := svc.RenameGenre(context.Background(), 3, models.GenreRequest{GenreName: "Science Fiction"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, renamed, *genre)
+	movieRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	hits, err := index.Search(context.Background(), "science fiction", 10)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+}
+
+func TestGenreService_DeleteRefusesGenreWithMovies(t *testing.T) {
+	genreRepo := new(mocks.MockGenreRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	svc := service.NewGenreService(genreRepo, movieRepo, userRepo, nil)
+
+	genreRepo.On("GetGenre", mock.Anything, 3).Return(&models.Genre{GenreID: 3, GenreName: "Sci-Fi"}, nil)
+	movieRepo.On("GetMovieIDsWithGenre", mock.Anything, 3).Return([]string{"tt2543164"}, nil)
+
+	err := svc.DeleteGenre(context.Background(), 3)
+
+	assert.ErrorIs(t, err, service.ErrGenreInUse)
+	genreRepo.AssertNotCalled(t, "DeleteGenre", mock.Anything, mock.Anything)
+}
+
+func TestGenreService_Merge(t *testing.T) {
+	genreRepo := new(mocks.MockGenreRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	svc := service.NewGenreService(genreRepo, movieRepo, userRepo, nil)
+
+	target := models.Genre{GenreID: 3, GenreName: "Sci-Fi"}
+	genreRepo.On("GetGenre", mock.Anything, 12).Return(&models.Genre{GenreID: 12, GenreName: "SciFi"}, nil)
+	genreRepo.On("GetGenre", mock.Anything, 3).Return(&target, nil)
+	movieRepo.On("MergeGenre", mock.Anything, 12, target).Return(nil)
+	userRepo.On("MergeFavouriteGenre", mock.Anything, 12, target).Return(nil)
+	genreRepo.On("DeleteGenre", mock.Anything, 12).Return(nil)
+	movieRepo.On("GetMovieIDsWithGenre", mock.Anything, 3).Return([]string{}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{}).Return([]models.Movie{}, nil)
+
+	merged, err := svc.MergeGenre(context.Background(), 12, models.GenreMerge{TargetID: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, target, *merged)
+	genreRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+
+	_, err = svc.MergeGenre(context.Background(), 3, models.GenreMerge{TargetID: 3})
+	assert.ErrorIs(t, err, service.ErrMergeIntoSelf)
+}
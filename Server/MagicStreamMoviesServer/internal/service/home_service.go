@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrUnknownRail       = errors.New("unknown rail type")
+	ErrRailRequiresLogin = errors.New("rail requires an authenticated user")
+)
+
+var railTitles = map[string]string{
+	models.RailContinueWatching: "Continue Watching",
+	models.RailRecommended:      "Recommended For You",
+	models.RailTopRanked:        "Top Ranked",
+	models.RailNewArrivals:      "New Arrivals",
+	models.RailTrending:         "Trending",
+}
+
+type HomeService interface {
+	// GetHome builds the configured rails. An empty userId gets the non-personalized home page.
+	GetHome(ctx context.Context, userId string) (*models.HomePage, error)
+	// GetRail builds a page of one rail. key is the genre name of genre rails and the
+	// collection ID of collection rails, and is ignored by the other rails.
+	GetRail(ctx context.Context, userId string, railType string, key string, page models.Pagination) (*models.Rail, error)
+}
+
+type homeService struct {
+	movieRepo      repository.MovieRepository
+	userRepo       repository.UserRepository
+	activityRepo   repository.ActivityRepository
+	eventRepo      repository.EventRepository
+	collectionRepo repository.CollectionRepository
+	recommender    Recommender
+	config         *config.Config
+}
+
+// homeRails are the rails HOME_RAILS may list. Genre and collection rails need a key, so the
+// home page gets them through favourite_genres and featured_collections.
+var homeRails = map[string]bool{
+	models.RailContinueWatching:    true,
+	models.RailRecommended:         true,
+	models.RailTopRanked:           true,
+	models.RailNewArrivals:         true,
+	models.RailTrending:            true,
+	models.RailFavouriteGenres:     true,
+	models.RailFeaturedCollections: true,
+}
+
+// NewHomeService fails on rails in cfg.HomeRails it does not know, so a typo in the
+// configuration stops the server instead of failing every home page.
+func NewHomeService(movieRepo repository.MovieRepository, userRepo repository.UserRepository, activityRepo repository.ActivityRepository, eventRepo repository.EventRepository, collectionRepo repository.CollectionRepository, recommender Recommender, cfg *config.Config) (HomeService, error) {
+	for _, railType := range cfg.HomeRails {
+		if !homeRails[railType] {
+			return nil, fmt.Errorf("%w %q in HOME_RAILS", ErrUnknownRail, railType)
+		}
+	}
+	return &homeService{
+		movieRepo:      movieRepo,
+		userRepo:       userRepo,
+		activityRepo:   activityRepo,
+		eventRepo:      eventRepo,
+		collectionRepo: collectionRepo,
+		recommender:    recommender,
+		config:         cfg,
+	}, nil
+}
+
+func (s *homeService) GetHome(ctx context.Context, userId string) (*models.HomePage, error) {
+	home := &models.HomePage{Personalized: userId != "", Rails: []models.Rail{}}
+	firstPage := models.Pagination{Page: 1, PageSize: s.config.HomeRailSize}
+
+	for _, railType := range s.config.HomeRails {
+		if railType == models.RailFavouriteGenres {
+			if userId == "" {
+				continue
+			}
+			genres, err := s.userRepo.GetUserFavouriteGenres(ctx, userId)
+			if err != nil {
+				return nil, err
+			}
+			for i, genre := range genres {
+				if i == s.config.HomeMaxGenreRails {
+					break
+				}
+				if err := s.appendRail(ctx, home, userId, models.RailGenre, genre, firstPage); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if railType == models.RailFeaturedCollections {
+			collections, err := s.collectionRepo.GetFeaturedCollections(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, collection := range collections {
+				if err := s.appendRail(ctx, home, userId, models.RailCollection, collection.ID.Hex(), firstPage); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		if userId == "" && isPersonalRail(railType) {
+			continue
+		}
+		if err := s.appendRail(ctx, home, userId, railType, "", firstPage); err != nil {
+			return nil, err
+		}
+	}
+	return home, nil
+}
+
+// appendRail adds the rail to the page unless it has nothing to show.
+func (s *homeService) appendRail(ctx context.Context, home *models.HomePage, userId string, railType string, key string, page models.Pagination) error {
+	rail, err := s.GetRail(ctx, userId, railType, key, page)
+	if err != nil {
+		return err
+	}
+	if len(rail.Items) > 0 {
+		home.Rails = append(home.Rails, *rail)
+	}
+	return nil
+}
+
+func (s *homeService) GetRail(ctx context.Context, userId string, railType string, key string, page models.Pagination) (*models.Rail, error) {
+	if userId == "" && isPersonalRail(railType) {
+		return nil, ErrRailRequiresLogin
+	}
+	page = normalizePage(page, s.config.HomeRailSize)
+
+	// Rails fetch one item more than the page size to learn whether another page exists.
+	var (
+		items []models.RailItem
+		title = railTitles[railType]
+		err   error
+	)
+	switch railType {
+	case models.RailContinueWatching:
+		items, err = s.continueWatching(ctx, userId, page)
+	case models.RailRecommended:
+		items, err = s.recommended(ctx, userId, page)
+	case models.RailTopRanked:
+		items, err = s.moviesRail(s.movieRepo.GetRecommendedMovies(ctx, nil, nil, page.Skip(), page.PageSize+1))
+	case models.RailNewArrivals:
+		items, err = s.moviesRail(s.movieRepo.GetNewestMovies(ctx, page.Skip(), page.PageSize+1))
+	case models.RailGenre:
+		title = key
+		items, err = s.genreRail(ctx, userId, key, page)
+	case models.RailTrending:
+		items, err = s.trending(ctx, page)
+	case models.RailCollection:
+		title, items, err = s.collectionRail(ctx, key, page)
+	default:
+		return nil, ErrUnknownRail
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rail := &models.Rail{
+		Type:     railType,
+		Title:    title,
+		Items:    items,
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}
+	switch railType {
+	case models.RailGenre:
+		rail.Genre = key
+	case models.RailCollection:
+		rail.Collection = key
+	}
+	if int64(len(rail.Items)) > page.PageSize {
+		rail.Items = rail.Items[:page.PageSize]
+		rail.HasMore = true
+	}
+	return rail, nil
+}
+
+func (s *homeService) continueWatching(ctx context.Context, userId string, page models.Pagination) ([]models.RailItem, error) {
+	history, err := s.activityRepo.GetWatchHistory(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// A series stays in progress between episodes, when it points at the next one to start.
+	var inProgress []models.WatchHistory
+	for _, entry := range history {
+		if !entry.Completed && (entry.ProgressSeconds > 0 || entry.EpisodeNumber > 0) {
+			inProgress = append(inProgress, entry)
+		}
+	}
+	inProgress = pageOf(inProgress, page.Skip(), page.PageSize+1)
+
+	ids := make([]string, 0, len(inProgress))
+	for _, entry := range inProgress {
+		ids = append(ids, entry.ImdbID)
+	}
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ImdbID] = movie
+	}
+
+	items := []models.RailItem{}
+	for _, entry := range inProgress {
+		if movie, ok := byID[entry.ImdbID]; ok {
+			items = append(items, models.RailItem{
+				Movie:           movie,
+				ProgressSeconds: entry.ProgressSeconds,
+				SeasonNumber:    entry.SeasonNumber,
+				EpisodeNumber:   entry.EpisodeNumber,
+			})
+		}
+	}
+	return items, nil
+}
+
+// recommended asks the recommender for everything up to the end of the requested page plus one,
+// since recommendation scores are only comparable within a single ranking.
+func (s *homeService) recommended(ctx context.Context, userId string, page models.Pagination) ([]models.RailItem, error) {
+	recommendations, err := s.recommender.Recommend(ctx, userId, models.Pagination{Page: 1, PageSize: page.Skip() + page.PageSize + 1})
+	if err != nil {
+		return nil, err
+	}
+
+	items := []models.RailItem{}
+	for _, rec := range pageOf(recommendations, page.Skip(), page.PageSize+1) {
+		items = append(items, models.RailItem{Movie: rec.Movie, Reasons: rec.Reasons})
+	}
+	return items, nil
+}
+
+func (s *homeService) genreRail(ctx context.Context, userId string, genre string, page models.Pagination) ([]models.RailItem, error) {
+	var seen []string
+	if userId != "" {
+		var err error
+		if seen, err = s.activityRepo.GetSeenMovieIDs(ctx, userId); err != nil {
+			return nil, err
+		}
+	}
+	return s.moviesRail(s.movieRepo.GetRecommendedMovies(ctx, []string{genre}, seen, page.Skip(), page.PageSize+1))
+}
+
+// collectionRail lists a page of the collection's titles in viewing order, titled after the
+// collection.
+func (s *homeService) collectionRail(ctx context.Context, id string, page models.Pagination) (string, []models.RailItem, error) {
+	collectionID, err := parseID(id)
+	if err != nil {
+		return "", nil, err
+	}
+	collection, err := s.collectionRepo.GetCollection(ctx, collectionID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	ids := pageOf(collection.ImdbIDs, page.Skip(), page.PageSize+1)
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, ids)
+	if err != nil {
+		return "", nil, err
+	}
+	items, err := s.moviesRail(orderByIDs(movies, ids), nil)
+	return collection.Name, items, err
+}
+
+func (s *homeService) trending(ctx context.Context, page models.Pagination) ([]models.RailItem, error) {
+	scores, err := s.eventRepo.GetTrendingScores(ctx, DefaultTrendingWindow, "", page.Skip()+page.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, score := range pageOf(scores, page.Skip(), page.PageSize+1) {
+		ids = append(ids, score.ImdbID)
+	}
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return s.moviesRail(orderByIDs(movies, ids), nil)
+}
+
+func (s *homeService) moviesRail(movies []models.Movie, err error) ([]models.RailItem, error) {
+	if err != nil {
+		return nil, err
+	}
+	items := make([]models.RailItem, 0, len(movies))
+	for _, movie := range movies {
+		items = append(items, models.RailItem{Movie: movie})
+	}
+	return items, nil
+}
+
+func isPersonalRail(railType string) bool {
+	switch railType {
+	case models.RailContinueWatching, models.RailRecommended, models.RailFavouriteGenres:
+		return true
+	}
+	return false
+}
+
+func pageOf[T any](items []T, skip int64, limit int64) []T {
+	if skip >= int64(len(items)) {
+		return nil
+	}
+	end := skip + limit
+	if end > int64(len(items)) {
+		end = int64(len(items))
+	}
+	return items[skip:end]
+}
+
+// orderByIDs returns movies in the order of ids, dropping ids that no longer exist.
+func orderByIDs(movies []models.Movie, ids []string) []models.Movie {
+	byID := make(map[string]models.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ImdbID] = movie
+	}
+	ordered := make([]models.Movie, 0, len(ids))
+	for _, id := range ids {
+		if movie, ok := byID[id]; ok {
+			ordered = append(ordered, movie)
+		}
+	}
+	return ordered
+}
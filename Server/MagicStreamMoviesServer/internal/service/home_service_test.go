@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestGetHome_AnonymousSkipsPersonalRails(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	cfg := &config.Config{
+		HomeRails:    []string{"continue_watching", "recommended", "top_ranked", "favourite_genres", "new_arrivals"},
+		HomeRailSize: 2,
+	}
+	svc, err := service.NewHomeService(movieRepo, nil, nil, nil, nil, nil, cfg)
+	assert.NoError(t, err)
+
+	movieRepo.On("GetRecommendedMovies", mock.Anything, []string(nil), []string(nil), int64(0), int64(3)).
+		Return([]models.Movie{{ImdbID: "tt1"}, {ImdbID: "tt2"}, {ImdbID: "tt3"}}, nil)
+	movieRepo.On("GetNewestMovies", mock.Anything, int64(0), int64(3)).Return([]models.Movie{}, nil)
+
+	home, err := svc.GetHome(context.Background(), "")
+
+	assert.NoError(t, err)
+	assert.False(t, home.Personalized)
+	// New arrivals is empty and therefore left out.
+	assert.Len(t, home.Rails, 1)
+	assert.Equal(t, models.RailTopRanked, home.Rails[0].Type)
+	assert.Equal(t, "Top Ranked", home.Rails[0].Title)
+	assert.Len(t, home.Rails[0].Items, 2)
+	assert.True(t, home.Rails[0].HasMore)
+}
+
+func TestGetHome_PersonalizedRails(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	cfg := &config.Config{
+		HomeRails:         []string{"continue_watching", "favourite_genres"},
+		HomeRailSize:      10,
+		HomeMaxGenreRails: 1,
+	}
+	svc, err := service.NewHomeService(movieRepo, userRepo, activityRepo, nil, nil, nil, cfg)
+	assert.NoError(t, err)
+
+	activityRepo.On("GetWatchHistory", mock.Anything, "user123").Return([]models.WatchHistory{
+		{ImdbID: "tt1", ProgressSeconds: 600},
+		{ImdbID: "tt2", Completed: true, ProgressSeconds: 5400},
+	}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt1"}).Return([]models.Movie{{ImdbID: "tt1"}}, nil)
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "user123").Return([]string{"Comedy", "Drama"}, nil)
+	activityRepo.On("GetSeenMovieIDs", mock.Anything, "user123").Return([]string{"tt1", "tt2"}, nil)
+	movieRepo.On("GetRecommendedMovies", mock.Anything, []string{"Comedy"}, []string{"tt1", "tt2"}, int64(0), int64(11)).
+		Return([]models.Movie{{ImdbID: "tt5"}}, nil)
+
+	home, err := svc.GetHome(context.Background(), "user123")
+
+	assert.NoError(t, err)
+	assert.True(t, home.Personalized)
+	assert.Len(t, home.Rails, 2)
+	assert.Equal(t, models.RailContinueWatching, home.Rails[0].Type)
+	assert.Equal(t, 600, home.Rails[0].Items[0].ProgressSeconds)
+	assert.Equal(t, models.RailGenre, home.Rails[1].Type)
+	assert.Equal(t, "Comedy", home.Rails[1].Title)
+	assert.False(t, home.Rails[1].HasMore)
+	movieRepo.AssertExpectations(t)
+}
+
+func TestGetRail_ContinueWatchingSeriesBetweenEpisodes(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	svc, err := service.NewHomeService(movieRepo, nil, activityRepo, nil, nil, nil, &config.Config{HomeRailSize: 10})
+	assert.NoError(t, err)
+
+	activityRepo.On("GetWatchHistory", mock.Anything, "user123").Return([]models.WatchHistory{
+		{ImdbID: "tt0903747", SeasonNumber: 1, EpisodeNumber: 2},
+		{ImdbID: "tt0944947", Completed: true},
+	}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt0903747"}).
+		Return([]models.Movie{{ImdbID: "tt0903747", ContentType: models.ContentTypeSeries}}, nil)
+
+	rail, err := svc.GetRail(context.Background(), "user123", models.RailContinueWatching, "", models.Pagination{})
+
+	assert.NoError(t, err)
+	assert.Len(t, rail.Items, 1)
+	assert.Equal(t, 1, rail.Items[0].SeasonNumber)
+	assert.Equal(t, 2, rail.Items[0].EpisodeNumber)
+}
+
+func TestGetHome_FeaturedCollections(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	collectionRepo := new(mocks.MockCollectionRepository)
+	cfg := &config.Config{HomeRails: []string{"featured_collections"}, HomeRailSize: 2}
+	svc, err := service.NewHomeService(movieRepo, nil, nil, nil, collectionRepo, nil, cfg)
+	assert.NoError(t, err)
+
+	id := bson.NewObjectID()
+	matrix := models.Collection{ID: id, Name: "The Matrix Collection", ImdbIDs: []string{"tt0133093", "tt0234215", "tt0242653"}}
+	collectionRepo.On("GetFeaturedCollections", mock.Anything).Return([]models.Collection{matrix}, nil)
+	collectionRepo.On("GetCollection", mock.Anything, id).Return(&matrix, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt0133093", "tt0234215", "tt0242653"}).
+		Return([]models.Movie{{ImdbID: "tt0242653"}, {ImdbID: "tt0133093"}, {ImdbID: "tt0234215"}}, nil)
+
+	home, err := svc.GetHome(context.Background(), "")
+
+	assert.NoError(t, err)
+	assert.Len(t, home.Rails, 1)
+	rail := home.Rails[0]
+	assert.Equal(t, models.RailCollection, rail.Type)
+	assert.Equal(t, "The Matrix Collection", rail.Title)
+	assert.Equal(t, id.Hex(), rail.Collection)
+	assert.Equal(t, "tt0133093", rail.Items[0].ImdbID)
+	assert.Equal(t, "tt0234215", rail.Items[1].ImdbID)
+	assert.True(t, rail.HasMore)
+}
+
+func TestGetRail_PersonalRailRequiresLogin(t *testing.T) {
+	svc, err := service.NewHomeService(nil, nil, nil, nil, nil, nil, &config.Config{HomeRailSize: 10})
+	assert.NoError(t, err)
+
+	_, err = svc.GetRail(context.Background(), "", models.RailRecommended, "", models.Pagination{})
+
+	assert.ErrorIs(t, err, service.ErrRailRequiresLogin)
+}
+
+func TestNewHomeService_RejectsUnknownRails(t *testing.T) {
+	_, err := service.NewHomeService(nil, nil, nil, nil, nil, nil, &config.Config{HomeRails: []string{"top_ranked", "top_rankd"}})
+
+	assert.ErrorIs(t, err, service.ErrUnknownRail)
+	assert.ErrorContains(t, err, `"top_rankd"`)
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/imaging"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image")
+	ErrImageTooLarge    = errors.New("image is too large")
+	ErrTooManyBackdrops = errors.New("movie already has the maximum number of backdrops")
+	ErrImageNotFound    = errors.New("image not found")
+)
+
+// maxBackdrops matches the limit on a movie's backdrops.
+const maxBackdrops = 20
+
+// imagesPrefix is the storage prefix of every image, served under /images/.
+const imagesPrefix = "images"
+
+type ImageService interface {
+	// UploadPoster stores the image with its resized copies and makes it the movie's poster.
+	// The previous uploaded poster is removed.
+	UploadPoster(ctx context.Context, imdbID string, r io.Reader) (*models.ImageSet, error)
+	// UploadBackdrop stores the image with its resized copies and adds it to the movie's
+	// backdrops.
+	UploadBackdrop(ctx context.Context, imdbID string, r io.Reader) (*models.ImageSet, error)
+	// OpenImage opens a stored image by its path below /images/. The caller closes it.
+	OpenImage(ctx context.Context, key string) (*storage.Blob, error)
+}
+
+type imageService struct {
+	movieRepo repository.MovieRepository
+	store     storage.BlobStore
+	publicURL string
+	maxBytes  int64
+}
+
+func NewImageService(movieRepo repository.MovieRepository, store storage.BlobStore, cfg *config.Config) ImageService {
+	return &imageService{
+		movieRepo: movieRepo,
+		store:     store,
+		publicURL: cfg.PublicURL,
+		maxBytes:  cfg.ImageMaxBytes,
+	}
+}
+
+func (s *imageService) UploadPoster(ctx context.Context, imdbID string, r io.Reader) (*models.ImageSet, error) {
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	image, err := s.save(ctx, r, "posters", imdbID, imaging.PosterVariants)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.movieRepo.SetMoviePoster(ctx, imdbID, image.Variants["large"], *image); err != nil {
+		s.remove(ctx, image.Key)
+		return nil, err
+	}
+	if movie.Images != nil && movie.Images.Poster != nil && movie.Images.Poster.Key != image.Key {
+		s.remove(ctx, movie.Images.Poster.Key)
+	}
+	return image, nil
+}
+
+func (s *imageService) UploadBackdrop(ctx context.Context, imdbID string, r io.Reader) (*models.ImageSet, error) {
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if len(movie.Backdrops) >= maxBackdrops {
+		return nil, ErrTooManyBackdrops
+	}
+	image, err := s.save(ctx, r, "backdrops", imdbID, imaging.BackdropVariants)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.movieRepo.AddMovieBackdrop(ctx, imdbID, image.Variants["large"], *image, maxBackdrops); err != nil {
+		s.remove(ctx, image.Key)
+		// The movie was found above, so a miss means other uploads filled it up meanwhile.
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrTooManyBackdrops
+		}
+		return nil, err
+	}
+	return image, nil
+}
+
+func (s *imageService) OpenImage(ctx context.Context, key string) (*storage.Blob, error) {
+	blob, err := s.store.Open(ctx, imagesPrefix+"/"+key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return nil, ErrImageNotFound
+	}
+	return blob, err
+}
+
+// save renders the upload's copies and saves them under a prefix named after the content,
+// so each upload gets URLs of its own that can be cached for good.
+func (s *imageService) save(ctx context.Context, r io.Reader, kind string, imdbID string, variants []imaging.Variant) (*models.ImageSet, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, ErrImageTooLarge
+	}
+	result, err := imaging.Process(data, variants)
+	if errors.Is(err, imaging.ErrTooManyPixels) {
+		return nil, fmt.Errorf("%w: %v", ErrImageTooLarge, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	sum := sha256.Sum256(data)
+	key := path.Join(imagesPrefix, kind, imdbID, hex.EncodeToString(sum[:8]))
+	image := &models.ImageSet{
+		Width:    result.Width,
+		Height:   result.Height,
+		Variants: make(map[string]string, len(result.Files)),
+		Key:      key,
+	}
+	for _, file := range result.Files {
+		fileKey := key + "/" + file.Filename
+		if err := s.store.Put(ctx, fileKey, bytes.NewReader(file.Data), file.ContentType); err != nil {
+			s.remove(ctx, key)
+			return nil, err
+		}
+		image.Variants[file.Name] = s.publicURL + "/" + fileKey
+	}
+	return image, nil
+}
+
+// remove deletes an image's files. The movie no longer refers to them, so failures are only
+// logged.
+func (s *imageService) remove(ctx context.Context, key string) {
+	if err := s.store.DeletePrefix(ctx, key); err != nil {
+		log.Printf("failed to remove image %s: %v", key, err)
+	}
+}
@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func testPNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func newImageService(t *testing.T, movieRepo *mocks.MockMovieRepository) (service.ImageService, storage.BlobStore) {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	cfg := &config.Config{PublicURL: "https://api.example.com", ImageMaxBytes: 1 << 20}
+	return service.NewImageService(movieRepo, store, cfg), store
+}
+
+func TestImageService_UploadPosterReplacesPrevious(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	svc, store := newImageService(t, movieRepo)
+
+	old := "images/posters/tt2543164/000000000000"
+	assert.NoError(t, store.Put(ctx, old+"/large.jpg", strings.NewReader("old"), "image/jpeg"))
+	movie := &models.Movie{ImdbID: "tt2543164", Images: &models.MovieImages{Poster: &models.ImageSet{Key: old}}}
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(movie, nil)
+	movieRepo.On("SetMoviePoster", mock.Anything, "tt2543164", mock.Anything, mock.Anything).Return(nil)
+
+	poster, err := svc.UploadPoster(ctx, "tt2543164", bytes.NewReader(testPNG(t, 400, 600)))
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, poster) {
+		assert.Equal(t, 400, poster.Width)
+		assert.Equal(t, 600, poster.Height)
+		assert.True(t, strings.HasPrefix(poster.Variants["large"], "https://api.example.com/images/posters/tt2543164/"))
+		assert.True(t, strings.HasSuffix(poster.Variants["large"], "/large.jpg"))
+		assert.Contains(t, poster.Variants, "thumb_webp")
+		movieRepo.AssertCalled(t, "SetMoviePoster", mock.Anything, "tt2543164", poster.Variants["large"], *poster)
+
+		blob, err := svc.OpenImage(ctx, strings.TrimPrefix(poster.Variants["thumb"], "https://api.example.com/images/"))
+		if assert.NoError(t, err) {
+			blob.Close()
+			assert.Equal(t, "image/jpeg", blob.ContentType)
+		}
+	}
+	_, err = store.Stat(ctx, old+"/large.jpg")
+	assert.True(t, errors.Is(err, storage.ErrNotFound))
+}
+
+func TestImageService_UploadBackdrop(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	svc, _ := newImageService(t, movieRepo)
+
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(&models.Movie{ImdbID: "tt2543164"}, nil)
+	movieRepo.On("AddMovieBackdrop", mock.Anything, "tt2543164", mock.Anything, mock.Anything, 20).Return(nil)
+
+	backdrop, err := svc.UploadBackdrop(context.Background(), "tt2543164", bytes.NewReader(testPNG(t, 640, 360)))
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, backdrop) {
+		assert.Contains(t, backdrop.Variants["large"], "/images/backdrops/tt2543164/")
+	}
+	movieRepo.AssertExpectations(t)
+}
+
+func TestImageService_UploadErrors(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	svc, _ := newImageService(t, movieRepo)
+
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(&models.Movie{ImdbID: "tt2543164"}, nil)
+	full := &models.Movie{ImdbID: "tt0000001", Backdrops: make([]string, 20)}
+	movieRepo.On("GetMovie", mock.Anything, "tt0000001").Return(full, nil)
+	// Other uploads filled the movie up between the check and the update.
+	movieRepo.On("GetMovie", mock.Anything, "tt0000002").Return(&models.Movie{ImdbID: "tt0000002"}, nil)
+	movieRepo.On("AddMovieBackdrop", mock.Anything, "tt0000002", mock.Anything, mock.Anything, 20).Return(mongo.ErrNoDocuments)
+
+	_, err := svc.UploadPoster(ctx, "tt2543164", strings.NewReader("GIF89a not an image we take"))
+	assert.True(t, errors.Is(err, service.ErrUnsupportedImage))
+
+	_, err = svc.UploadPoster(ctx, "tt2543164", bytes.NewReader(make([]byte, 1<<20+1)))
+	assert.True(t, errors.Is(err, service.ErrImageTooLarge))
+
+	_, err = svc.UploadBackdrop(ctx, "tt0000001", bytes.NewReader(testPNG(t, 16, 9)))
+	assert.True(t, errors.Is(err, service.ErrTooManyBackdrops))
+	_, err = svc.UploadBackdrop(ctx, "tt0000002", bytes.NewReader(testPNG(t, 16, 9)))
+	assert.True(t, errors.Is(err, service.ErrTooManyBackdrops))
+
+	_, err = svc.OpenImage(ctx, "../config.json")
+	assert.True(t, errors.Is(err, service.ErrImageNotFound))
+	_, err = svc.OpenImage(ctx, "posters/tt2543164/missing/large.jpg")
+	assert.True(t, errors.Is(err, service.ErrImageNotFound))
+
+	movieRepo.AssertNotCalled(t, "SetMoviePoster", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/importer"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+)
+
+// ErrImportFile is returned when the import file cannot be read past some point, for example a
+// truncated gzip stream. The rows before it have been imported.
+var ErrImportFile = errors.New("unreadable import file")
+
+const (
+	// importBatchSize is the number of rows looked up and written per round trip.
+	importBatchSize = 500
+	// maxImportErrors caps the row errors kept in a report.
+	maxImportErrors = 1000
+)
+
+type ImportService interface {
+	// ImportMovies upserts the movies read from reader by imdb_id. Each row is applied over the
+	// stored movie and validated like a movie added through the API; rows that fail are reported
+	// and the rest are still imported. A dry run validates without writing anything.
+	ImportMovies(ctx context.Context, reader importer.Reader, dryRun bool) (*models.ImportReport, error)
+}
+
+type importService struct {
+	movieRepo repository.MovieRepository
+	genreRepo repository.GenreRepository
+	indexers  []MovieIndexer
+	validate  *validator.Validate
+}
+
+func NewImportService(movieRepo repository.MovieRepository, genreRepo repository.GenreRepository, indexers []MovieIndexer) ImportService {
+	return &importService{
+		movieRepo: movieRepo,
+		genreRepo: genreRepo,
+		indexers:  indexers,
+		validate:  validator.New(),
+	}
+}
+
+func (s *importService) ImportMovies(ctx context.Context, reader importer.Reader, dryRun bool) (*models.ImportReport, error) {
+	genres, err := s.genreRepo.GetGenres(ctx)
+	if err != nil {
+		return nil, err
+	}
+	run := &importRun{
+		service: s,
+		report:  &models.ImportReport{DryRun: dryRun, Errors: []models.ImportRowError{}},
+		genres:  newGenreLookup(genres),
+	}
+
+	batch := make([]*importer.Record, 0, importBatchSize)
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *importer.RowError
+		if errors.As(err, &rowErr) {
+			run.report.Rows++
+			run.fail(rowErr.Line, "", rowErr.Err)
+			continue
+		}
+		if err != nil {
+			return run.report, fmt.Errorf("%w: %v", ErrImportFile, err)
+		}
+
+		run.report.Rows++
+		if record.Skip {
+			run.report.Skipped++
+			continue
+		}
+		batch = append(batch, record)
+		if len(batch) == importBatchSize {
+			if err := run.importBatch(ctx, batch); err != nil {
+				return run.report, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := run.importBatch(ctx, batch); err != nil {
+		return run.report, err
+	}
+	return run.report, nil
+}
+
+// importRun holds the state of one import.
+type importRun struct {
+	service *importService
+	report  *models.ImportReport
+	genres  genreLookup
+}
+
+func (r *importRun) importBatch(ctx context.Context, batch []*importer.Record) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	s := r.service
+
+	ids := make([]string, 0, len(batch))
+	for _, record := range batch {
+		ids = append(ids, record.ImdbID)
+	}
+	stored, err := s.movieRepo.GetMoviesByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]models.Movie, len(stored))
+	for _, movie := range stored {
+		current[movie.ImdbID] = movie
+	}
+
+	// A title repeated in the batch is written once, with every row applied in file order.
+	var order []string
+	for _, record := range batch {
+		base, exists := current[record.ImdbID]
+		movie, err := r.apply(record, base)
+		if err != nil {
+			r.fail(record.Line, record.ImdbID, err)
+			continue
+		}
+		if exists {
+			r.report.Updated++
+		} else {
+			r.report.Created++
+		}
+		if !slices.Contains(order, record.ImdbID) {
+			order = append(order, record.ImdbID)
+		}
+		current[record.ImdbID] = movie
+	}
+	if r.report.DryRun || len(order) == 0 {
+		return nil
+	}
+
+	movies := make([]models.Movie, 0, len(order))
+	for _, id := range order {
+		movies = append(movies, current[id])
+	}
+	if err := s.movieRepo.UpsertMovies(ctx, movies); err != nil {
+		return err
+	}
+	indexMovies(ctx, s.indexers, movies...)
+	return nil
+}
+
+// apply returns base with the record applied, or the reason the row is rejected.
+func (r *importRun) apply(record *importer.Record, base models.Movie) (models.Movie, error) {
+	movie := cloneMovie(base)
+	if err := record.Apply(&movie); err != nil {
+		return models.Movie{}, err
+	}
+	// Identity, timestamps and the fields maintained elsewhere are never imported.
+	movie.ID = base.ID
+	movie.ImdbID = record.ImdbID
+	movie.CreatedAt = base.CreatedAt
+	movie.AIDraft = base.AIDraft
+	movie.Collections = base.Collections
+	movie.Images = base.Images
+	movie.Stream = base.Stream
+	movie.Subtitles = base.Subtitles
+
+	genres, err := r.genres.resolve(movie.Genre)
+	if err != nil {
+		return models.Movie{}, err
+	}
+	movie.Genre = genres
+	if err := r.service.validate.Struct(&movie); err != nil {
+		return models.Movie{}, err
+	}
+	return movie, nil
+}
+
+func (r *importRun) fail(line int, imdbID string, err error) {
+	r.report.Failed++
+	if len(r.report.Errors) == maxImportErrors {
+		r.report.ErrorsTruncated = true
+		return
+	}
+	r.report.Errors = append(r.report.Errors, models.ImportRowError{Line: line, ImdbID: imdbID, Error: err.Error()})
+}
+
+// cloneMovie copies the slices and pointers of movie, so applying a row to the copy cannot
+// change the original.
+func cloneMovie(movie models.Movie) models.Movie {
+	movie.Genre = slices.Clone(movie.Genre)
+	movie.Keywords = slices.Clone(movie.Keywords)
+	movie.MoodTags = slices.Clone(movie.MoodTags)
+	movie.Backdrops = slices.Clone(movie.Backdrops)
+	if movie.ReleaseDate != nil {
+		date := *movie.ReleaseDate
+		movie.ReleaseDate = &date
+	}
+	return movie
+}
+
+// genreLookup resolves imported genres, which may carry only an ID or only a name, against the
+// genre list.
+type genreLookup struct {
+	byID   map[int]models.Genre
+	byName map[string]models.Genre
+}
+
+func newGenreLookup(genres []models.Genre) genreLookup {
+	lookup := genreLookup{
+		byID:   make(map[int]models.Genre, len(genres)),
+		byName: make(map[string]models.Genre, len(genres)),
+	}
+	for _, genre := range genres {
+		lookup.byID[genre.GenreID] = genre
+		lookup.byName[strings.ToLower(genre.GenreName)] = genre
+	}
+	return lookup
+}
+
+func (l genreLookup) resolve(genres []models.Genre) ([]models.Genre, error) {
+	resolved := make([]models.Genre, 0, len(genres))
+	var missing []string
+	for _, genre := range genres {
+		var stored models.Genre
+		var ok bool
+		if genre.GenreID != 0 {
+			stored, ok = l.byID[genre.GenreID]
+			if !ok {
+				missing = append(missing, strconv.Itoa(genre.GenreID))
+			}
+		} else {
+			stored, ok = l.byName[strings.ToLower(strings.TrimSpace(genre.GenreName))]
+			if !ok {
+				missing = append(missing, genre.GenreName)
+			}
+		}
+		if ok && !slices.Contains(resolved, stored) {
+			resolved = append(resolved, stored)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownGenres, strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
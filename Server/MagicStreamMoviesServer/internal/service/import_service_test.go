@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/importer"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const importCSV = "imdb_id,title,poster_path,youtube_id,genre,ranking_value,ranking_name\n" +
+	"tt2543164,Arrival,https://img.example/arrival.jpg,tMiGl0EFJbE,Sci-Fi,2,Good\n" +
+	"tt1856101,Blade Runner 2049,https://img.example/br2049.jpg,gCcx85zbxz4,Sci-Fi|Western,1,Excellent\n" +
+	"tt0083658,Blade Runner,,,3,1,Excellent\n" +
+	"tt2543164,Arrival,https://img.example/arrival.jpg,tMiGl0EFJbE,3|Drama,2,Good\n"
+
+func TestImportMovies(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	genreRepo := new(mocks.MockGenreRepository)
+	svc := service.NewImportService(movieRepo, genreRepo, nil)
+
+	scifi := models.Genre{GenreID: 3, GenreName: "Sci-Fi"}
+	drama := models.Genre{GenreID: 1, GenreName: "Drama"}
+	genreRepo.On("GetGenres", mock.Anything).Return([]models.Genre{drama, scifi}, nil)
+	// Blade Runner is already in the catalog, so the row only needs to carry what changes.
+	stored := models.Movie{
+		ImdbID:     "tt0083658",
+		Title:      "Blade Runner (1982)",
+		PosterPath: "https://img.example/br.jpg",
+		YouTubeID:  "eogpIG53Cis",
+		Ranking:    models.Ranking{RankingValue: 2, RankingName: "Good"},
+	}
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt2543164", "tt1856101", "tt0083658", "tt2543164"}).
+		Return([]models.Movie{stored}, nil)
+
+	var saved []models.Movie
+	movieRepo.On("UpsertMovies", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).([]models.Movie)
+	}).Return(nil)
+
+	reader, err := importer.Open(strings.NewReader(importCSV), "movies.csv", "")
+	assert.NoError(t, err)
+	report, err := svc.ImportMovies(context.Background(), reader, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Rows)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 2, report.Updated)
+	assert.Equal(t, 1, report.Failed)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, 3, report.Errors[0].Line)
+	assert.Equal(t, "tt1856101", report.Errors[0].ImdbID)
+	assert.Contains(t, report.Errors[0].Error, "Western")
+
+	assert.Len(t, saved, 2)
+	arrival, bladeRunner := saved[0], saved[1]
+	// The repeated row is applied over the first one.
+	assert.Equal(t, []models.Genre{scifi, drama}, arrival.Genre)
+	assert.Equal(t, "Blade Runner", bladeRunner.Title)
+	assert.Equal(t, "https://img.example/br.jpg", bladeRunner.PosterPath)
+	assert.Equal(t, models.Ranking{RankingValue: 1, RankingName: "Excellent"}, bladeRunner.Ranking)
+}
+
+func TestImportMovies_DryRun(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	genreRepo := new(mocks.MockGenreRepository)
+	svc := service.NewImportService(movieRepo, genreRepo, nil)
+
+	genreRepo.On("GetGenres", mock.Anything).Return([]models.Genre{{GenreID: 3, GenreName: "Sci-Fi"}}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, mock.Anything).Return([]models.Movie{}, nil)
+
+	data := `{"imdb_id":"tt2543164","title":"Arrival","poster_path":"https://img.example/arrival.jpg","youtube_id":"tMiGl0EFJbE","genre":[{"genre_id":3}],"ranking":{"ranking_value":2,"ranking_name":"Good"}}` + "\n" +
+		`{"imdb_id":"tt1856101","title":"Blade Runner 2049"}` + "\n"
+	reader, err := importer.Open(strings.NewReader(data), "", importer.FormatJSONL)
+	assert.NoError(t, err)
+	report, err := svc.ImportMovies(context.Background(), reader, true)
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Failed)
+	assert.Contains(t, report.Errors[0].Error, "PosterPath")
+	movieRepo.AssertNotCalled(t, "UpsertMovies", mock.Anything, mock.Anything)
+}
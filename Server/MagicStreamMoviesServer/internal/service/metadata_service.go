@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/metadata"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+)
+
+var (
+	ErrMetadataUnavailable = errors.New("metadata lookups are not configured")
+	ErrMetadataNotFound    = errors.New("no metadata found for the title")
+	ErrMetadataProvider    = errors.New("metadata provider failed")
+	ErrInvalidMetadata     = errors.New("the provider's details would leave the movie invalid")
+)
+
+type MetadataService interface {
+	// FillMovie completes a movie about to be added with the provider's title, poster, genres,
+	// runtime, synopsis and release date. Fields already set are kept, and genres missing from
+	// the genre list are left out.
+	FillMovie(ctx context.Context, movie *models.Movie) error
+	// EnrichMovie fills in a catalog movie's empty fields the same way, or replaces them all
+	// with the provider's values when overwrite is set, and saves it.
+	EnrichMovie(ctx context.Context, imdbID string, overwrite bool) (*models.Movie, error)
+}
+
+type metadataService struct {
+	provider  metadata.MetadataProvider
+	movieRepo repository.MovieRepository
+	genreRepo repository.GenreRepository
+	indexers  []MovieIndexer
+	validate  *validator.Validate
+}
+
+// metadataFields are the movie fields filled from the provider.
+var metadataFields = []string{"Title", "PosterPath", "Genre", "Runtime", "Synopsis", "ReleaseDate"}
+
+// NewMetadataService builds the metadata service. A nil provider makes every lookup fail with
+// ErrMetadataUnavailable.
+func NewMetadataService(provider metadata.MetadataProvider, movieRepo repository.MovieRepository, genreRepo repository.GenreRepository, indexers []MovieIndexer) MetadataService {
+	return &metadataService{
+		provider:  provider,
+		movieRepo: movieRepo,
+		genreRepo: genreRepo,
+		indexers:  indexers,
+		validate:  validator.New(),
+	}
+}
+
+func (s *metadataService) FillMovie(ctx context.Context, movie *models.Movie) error {
+	details, err := s.lookup(ctx, movie.ImdbID)
+	if err != nil {
+		return err
+	}
+	return s.apply(ctx, movie, details, false)
+}
+
+func (s *metadataService) EnrichMovie(ctx context.Context, imdbID string, overwrite bool) (*models.Movie, error) {
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.lookup(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, movie, details, overwrite); err != nil {
+		return nil, err
+	}
+	// The provider's values are saved without passing a handler, so the fields they go into are
+	// checked here. Other fields are left as they are stored.
+	if err := s.validate.StructPartial(movie, metadataFields...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	if err := s.movieRepo.SetMovieMetadata(ctx, *movie); err != nil {
+		return nil, err
+	}
+
+	enriched, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	indexMovies(ctx, s.indexers, *enriched)
+	return enriched, nil
+}
+
+func (s *metadataService) lookup(ctx context.Context, imdbID string) (*metadata.Details, error) {
+	if s.provider == nil {
+		return nil, ErrMetadataUnavailable
+	}
+	details, err := s.provider.Lookup(ctx, imdbID)
+	switch {
+	case errors.Is(err, metadata.ErrNotFound):
+		return nil, ErrMetadataNotFound
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", ErrMetadataProvider, err)
+	}
+	return details, nil
+}
+
+// apply copies the provider's values onto the movie, only into empty fields unless overwrite
+// is set. Values the provider does not know never clear a field.
+func (s *metadataService) apply(ctx context.Context, movie *models.Movie, details *metadata.Details, overwrite bool) error {
+	if details.Title != "" && (overwrite || movie.Title == "") {
+		movie.Title = details.Title
+	}
+	if details.PosterURL != "" && (overwrite || movie.PosterPath == "") {
+		movie.PosterPath = details.PosterURL
+	}
+	if details.Runtime > 0 && (overwrite || movie.Runtime == 0) {
+		movie.Runtime = details.Runtime
+	}
+	if details.Synopsis != "" && (overwrite || movie.Synopsis == "") {
+		movie.Synopsis = details.Synopsis
+	}
+	if details.ReleaseDate != nil && (overwrite || movie.ReleaseDate == nil) {
+		movie.ReleaseDate = details.ReleaseDate
+	}
+
+	if len(details.Genres) == 0 || (!overwrite && len(movie.Genre) > 0) {
+		return nil
+	}
+	stored, err := s.genreRepo.GetGenres(ctx)
+	if err != nil {
+		return err
+	}
+	lookup := newGenreLookup(stored)
+	var genres []models.Genre
+	for _, name := range details.Genres {
+		genre, ok := lookup.byName[strings.ToLower(strings.TrimSpace(name))]
+		if ok && !slices.Contains(genres, genre) {
+			genres = append(genres, genre)
+		}
+	}
+	if len(genres) > 0 {
+		movie.Genre = genres
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/metadata"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func metadataFixtures(t *testing.T) metadata.MetadataProvider {
+	t.Helper()
+	dir := t.TempDir()
+	fixture := `{"title":"Arrival","poster_url":"https://example.com/arrival.jpg","genres":["drama","Sci-Fi","Mystery"],
+		"runtime":116,"synopsis":"A linguist works with the military.","release_date":"2016-11-11"}`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tt2543164.json"), []byte(fixture), 0o644))
+	return metadata.NewFixtureProvider(dir)
+}
+
+var metadataGenres = []models.Genre{{GenreID: 1, GenreName: "Drama"}, {GenreID: 2, GenreName: "Sci-Fi"}}
+
+func TestMetadataService_FillMovieKeepsSetFields(t *testing.T) {
+	genreRepo := new(mocks.MockGenreRepository)
+	svc := service.NewMetadataService(metadataFixtures(t), nil, genreRepo, nil)
+
+	genreRepo.On("GetGenres", mock.Anything).Return(metadataGenres, nil)
+
+	movie := models.Movie{ImdbID: "tt2543164", Title: "Arrival (2016)", YouTubeID: "tFMo3UJ4B4g"}
+	err := svc.FillMovie(context.Background(), &movie)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Arrival (2016)", movie.Title)
+	assert.Equal(t, "https://example.com/arrival.jpg", movie.PosterPath)
+	assert.Equal(t, 116, movie.Runtime)
+	assert.Equal(t, 2016, movie.ReleaseDate.Year())
+	// Mystery is not in the genre list.
+	assert.Equal(t, metadataGenres, movie.Genre)
+	assert.Equal(t, "tFMo3UJ4B4g", movie.YouTubeID)
+}
+
+func TestMetadataService_FillMovieErrors(t *testing.T) {
+	svc := service.NewMetadataService(metadataFixtures(t), nil, nil, nil)
+	err := svc.FillMovie(context.Background(), &models.Movie{ImdbID: "tt0000001"})
+	assert.True(t, errors.Is(err, service.ErrMetadataNotFound))
+
+	svc = service.NewMetadataService(nil, nil, nil, nil)
+	err = svc.FillMovie(context.Background(), &models.Movie{ImdbID: "tt2543164"})
+	assert.True(t, errors.Is(err, service.ErrMetadataUnavailable))
+}
+
+func TestMetadataService_EnrichMovieOverwrites(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	genreRepo := new(mocks.MockGenreRepository)
+	indexer := new(mocks.MockSearchService)
+	svc := service.NewMetadataService(metadataFixtures(t), movieRepo, genreRepo, []service.MovieIndexer{indexer})
+
+	stored := &models.Movie{ImdbID: "tt2543164", Title: "Arival", Genre: []models.Genre{{GenreID: 3, GenreName: "Horror"}}, YouTubeID: "tFMo3UJ4B4g"}
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(stored, nil)
+	genreRepo.On("GetGenres", mock.Anything).Return(metadataGenres, nil)
+	movieRepo.On("SetMovieMetadata", mock.Anything, mock.MatchedBy(func(m models.Movie) bool {
+		return m.Title == "Arrival" && len(m.Genre) == 2 && m.Synopsis == "A linguist works with the military." && m.YouTubeID == "tFMo3UJ4B4g"
+	})).Return(nil)
+	indexer.On("IndexMovie", mock.Anything, mock.Anything).Return(nil)
+
+	movie, err := svc.EnrichMovie(context.Background(), "tt2543164", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tt2543164", movie.ImdbID)
+	movieRepo.AssertExpectations(t)
+	indexer.AssertExpectations(t)
+}
+
+func TestMetadataService_EnrichMovieChecksProviderValues(t *testing.T) {
+	dir := t.TempDir()
+	fixture := `{"title":"Arrival","poster_url":"/arrival.jpg","runtime":116}`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tt2543164.json"), []byte(fixture), 0o644))
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewMetadataService(metadata.NewFixtureProvider(dir), movieRepo, nil, nil)
+
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(&models.Movie{ImdbID: "tt2543164", Title: "Arrival", Genre: metadataGenres}, nil)
+
+	_, err := svc.EnrichMovie(context.Background(), "tt2543164", true)
+
+	assert.True(t, errors.Is(err, service.ErrInvalidMetadata))
+	movieRepo.AssertNotCalled(t, "SetMovieMetadata", mock.Anything, mock.Anything)
+}
+
+func TestMetadataService_EnrichMovieOnlyFillsEmptyFields(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewMetadataService(metadataFixtures(t), movieRepo, nil, nil)
+
+	stored := &models.Movie{ImdbID: "tt2543164", Title: "Arival", Genre: []models.Genre{{GenreID: 3, GenreName: "Horror"}}}
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(stored, nil)
+	movieRepo.On("SetMovieMetadata", mock.Anything, mock.MatchedBy(func(m models.Movie) bool {
+		return m.Title == "Arival" && m.Genre[0].GenreName == "Horror" && m.Runtime == 116
+	})).Return(nil)
+
+	_, err := svc.EnrichMovie(context.Background(), "tt2543164", false)
+
+	assert.NoError(t, err)
+	movieRepo.AssertExpectations(t)
+}
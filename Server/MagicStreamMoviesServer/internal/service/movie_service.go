@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
 	"strings"
+	"time"
 
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/cache"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
 	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
@@ -12,30 +15,71 @@ import (
 )
 
 type MovieService interface {
-	GetMovies(ctx context.Context) ([]models.Movie, error)
+	GetMovies(ctx context.Context, filters models.MovieFilters) ([]models.Movie, error)
+	GetFacets(ctx context.Context, filters models.MovieFilters) (*models.Facets, error)
+	// ExportMovies calls fn for each movie matching filters, ordered by title, without loading
+	// the catalog into memory. Review figures are only included when withRatings is set.
+	ExportMovies(ctx context.Context, filters models.MovieFilters, withRatings bool, fn func(movie models.MovieExport) error) error
 	GetMovie(ctx context.Context, imdbID string) (*models.Movie, error)
 	AddMovie(ctx context.Context, movie models.Movie) error
 	UpdateAdminReview(ctx context.Context, imdbID string, review string) (string, string, error)
-	GetRecommendedMovies(ctx context.Context, userId string) ([]models.Movie, error)
-	GetAllGenres(ctx context.Context) ([]models.Genre, error)
+	GetRecommendedMovies(ctx context.Context, userId string, page models.Pagination) ([]models.RecommendedMovie, error)
+	ExplainRecommendation(ctx context.Context, userId string, imdbID string) (*models.RecommendationExplanation, error)
+	GetSimilarMovies(ctx context.Context, imdbID string, limit int) ([]models.Movie, error)
+	RecordWatch(ctx context.Context, entry models.WatchHistory) error
+	AddReview(ctx context.Context, review models.Review) error
+	// IndexMovie drops the cached similar movies of a movie changed by another service, so the
+	// movie service can be listed among that service's indexers.
+	IndexMovie(ctx context.Context, movie models.Movie) error
 }
 
+// MovieIndexer keeps a derived index, such as search, in step with the movies collection.
+type MovieIndexer interface {
+	IndexMovie(ctx context.Context, movie models.Movie) error
+}
+
+// maxPageSize caps client supplied page sizes so a single request cannot scan the catalog.
+const maxPageSize = 100
+
 type movieService struct {
-	movieRepo repository.MovieRepository
-	userRepo  repository.UserRepository
-	config    *config.Config
+	movieRepo    repository.MovieRepository
+	genreRepo    repository.GenreRepository
+	userRepo     repository.UserRepository
+	activityRepo repository.ActivityRepository
+	eventRepo    repository.EventRepository
+	recommender  Recommender
+	indexers     []MovieIndexer
+	similarCache *cache.TTLCache[string, []string]
+	config       *config.Config
 }
 
-func NewMovieService(movieRepo repository.MovieRepository, userRepo repository.UserRepository, cfg *config.Config) MovieService {
+func NewMovieService(movieRepo repository.MovieRepository, genreRepo repository.GenreRepository, userRepo repository.UserRepository, activityRepo repository.ActivityRepository, eventRepo repository.EventRepository, recommender Recommender, indexers []MovieIndexer, cfg *config.Config) MovieService {
 	return &movieService{
-		movieRepo: movieRepo,
-		userRepo:  userRepo,
-		config:    cfg,
+		movieRepo:    movieRepo,
+		genreRepo:    genreRepo,
+		userRepo:     userRepo,
+		activityRepo: activityRepo,
+		eventRepo:    eventRepo,
+		recommender:  recommender,
+		indexers:     indexers,
+		similarCache: cache.NewTTLCache[string, []string](cfg.SimilarMoviesCacheTTL),
+		config:       cfg,
+	}
+}
+
+func (s *movieService) GetMovies(ctx context.Context, filters models.MovieFilters) ([]models.Movie, error) {
+	if filters.IsEmpty() {
+		return s.movieRepo.GetMovies(ctx)
 	}
+	return s.movieRepo.FilterMovies(ctx, nil, filters)
 }
 
-func (s *movieService) GetMovies(ctx context.Context) ([]models.Movie, error) {
-	return s.movieRepo.GetMovies(ctx)
+func (s *movieService) GetFacets(ctx context.Context, filters models.MovieFilters) (*models.Facets, error) {
+	return s.movieRepo.GetFacets(ctx, nil, filters)
+}
+
+func (s *movieService) ExportMovies(ctx context.Context, filters models.MovieFilters, withRatings bool, fn func(movie models.MovieExport) error) error {
+	return s.movieRepo.StreamMovies(ctx, filters, withRatings, fn)
 }
 
 func (s *movieService) GetMovie(ctx context.Context, imdbID string) (*models.Movie, error) {
@@ -43,8 +87,20 @@ func (s *movieService) GetMovie(ctx context.Context, imdbID string) (*models.Mov
 }
 
 func (s *movieService) AddMovie(ctx context.Context, movie models.Movie) error {
-	_, err := s.movieRepo.CreateMovie(ctx, movie)
-	return err
+	genres, err := canonicalGenres(ctx, s.genreRepo, movie.Genre)
+	if err != nil {
+		return err
+	}
+	movie.Genre = genres
+
+	_, err = s.movieRepo.CreateMovie(ctx, movie)
+	if err != nil {
+		return err
+	}
+
+	s.similarCache.Delete(movie.ImdbID)
+	indexMovies(ctx, s.indexers, movie)
+	return nil
 }
 
 func (s *movieService) UpdateAdminReview(ctx context.Context, imdbID string, review string) (string, string, error) {
@@ -57,21 +113,100 @@ func (s *movieService) UpdateAdminReview(ctx context.Context, imdbID string, rev
 	if err != nil {
 		return "", "", err
 	}
+	s.similarCache.Delete(imdbID)
+	if movie, err := s.movieRepo.GetMovie(ctx, imdbID); err == nil {
+		indexMovies(ctx, s.indexers, *movie)
+	}
 
 	return sentiment, review, nil
 }
 
-func (s *movieService) GetRecommendedMovies(ctx context.Context, userId string) ([]models.Movie, error) {
-	genres, err := s.userRepo.GetUserFavouriteGenres(ctx, userId)
+func (s *movieService) GetRecommendedMovies(ctx context.Context, userId string, page models.Pagination) ([]models.RecommendedMovie, error) {
+	return s.recommender.Recommend(ctx, userId, page)
+}
+
+func (s *movieService) ExplainRecommendation(ctx context.Context, userId string, imdbID string) (*models.RecommendationExplanation, error) {
+	return s.recommender.Explain(ctx, userId, imdbID)
+}
+
+// GetSimilarMovies returns the movies most similar in content to imdbID. The ranked IDs are
+// cached per source movie and dropped whenever that movie changes; the movies themselves are
+// read on every call, so edits to them show up at once.
+func (s *movieService) GetSimilarMovies(ctx context.Context, imdbID string, limit int) ([]models.Movie, error) {
+	ids, ok := s.similarCache.Get(imdbID)
+	if !ok {
+		source, err := s.movieRepo.GetMovie(ctx, imdbID)
+		if err != nil {
+			return nil, err
+		}
+		catalog, err := s.movieRepo.GetMovies(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = []string{}
+		for _, match := range RankSimilarMovies(*source, catalog, maxSimilarMovies) {
+			ids = append(ids, match.ImdbID)
+		}
+		s.similarCache.Set(imdbID, ids)
+	}
+
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
+	return orderByIDs(movies, ids), nil
+}
+
+func (s *movieService) IndexMovie(ctx context.Context, movie models.Movie) error {
+	s.similarCache.Delete(movie.ImdbID)
+	return nil
+}
+
+func (s *movieService) RecordWatch(ctx context.Context, entry models.WatchHistory) error {
+	movie, err := s.movieRepo.GetMovie(ctx, entry.ImdbID)
+	if err != nil {
+		return err
+	}
+	if movie.IsSeries() {
+		return ErrWatchSeriesTitle
+	}
+	entry.WatchedAt = time.Now()
+	if err := s.activityRepo.UpsertWatchHistory(ctx, entry); err != nil {
+		return err
+	}
+
+	recordEvent(ctx, s.eventRepo, entry.UserID, entry.ImdbID, models.EventPlay)
+	return nil
+}
+
+func (s *movieService) AddReview(ctx context.Context, review models.Review) error {
+	if _, err := s.movieRepo.GetMovie(ctx, review.ImdbID); err != nil {
+		return err
+	}
+	review.CreatedAt = time.Now()
+	if err := s.activityRepo.UpsertReview(ctx, review); err != nil {
+		return err
+	}
 
-	return s.movieRepo.GetRecommendedMovies(ctx, genres, s.config.RecommendedMovieLimit)
+	recordEvent(ctx, s.eventRepo, review.UserID, review.ImdbID, models.EventReview)
+	return nil
 }
 
-func (s *movieService) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
-	return s.movieRepo.GetAllGenres(ctx)
+// indexMovies brings the derived indexes up to date after movies were written. The writes
+// themselves have already succeeded and the periodic reindex jobs repair any gap, so errors are
+// only logged.
+func indexMovies(ctx context.Context, indexers []MovieIndexer, movies ...models.Movie) {
+	for _, movie := range movies {
+		for _, indexer := range indexers {
+			if err := indexer.IndexMovie(ctx, movie); err != nil {
+				log.Printf("failed to index movie %s: %v", movie.ImdbID, err)
+			}
+		}
+	}
 }
 
 func (s *movieService) getReviewRanking(ctx context.Context, adminReview string) (string, int, error) {
@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestGetSimilarMovies_CachesUntilSourceChanges(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	genreRepo := new(mocks.MockGenreRepository)
+	cfg := &config.Config{SimilarMoviesCacheTTL: time.Minute}
+	svc := service.NewMovieService(movieRepo, genreRepo, nil, nil, nil, nil, nil, cfg)
+
+	drama := models.Genre{GenreID: 1, GenreName: "Drama"}
+	genreRepo.On("GetGenresByIDs", mock.Anything, []int{1}).Return([]models.Genre{drama}, nil)
+	source := models.Movie{ImdbID: "tt1", Title: "Source", Genre: []models.Genre{drama}}
+	other := models.Movie{ImdbID: "tt2", Title: "Other", Genre: []models.Genre{drama}}
+	edited := models.Movie{ImdbID: "tt2", Title: "Other (edited)", Genre: []models.Genre{drama}}
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&source, nil)
+	movieRepo.On("GetMovies", mock.Anything).Return([]models.Movie{source, other}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt2"}).Return([]models.Movie{other}, nil).Once()
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt2"}).Return([]models.Movie{edited}, nil)
+	movieRepo.On("CreateMovie", mock.Anything, mock.Anything).Return(&mongo.InsertOneResult{}, nil)
+
+	first, err := svc.GetSimilarMovies(context.Background(), "tt1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Movie{other}, first)
+
+	// The ranking is cached but the movies are read again, so edits show up at once.
+	second, err := svc.GetSimilarMovies(context.Background(), "tt1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Movie{edited}, second)
+	movieRepo.AssertNumberOfCalls(t, "GetMovies", 1)
+
+	assert.NoError(t, svc.AddMovie(context.Background(), source))
+	_, err = svc.GetSimilarMovies(context.Background(), "tt1", 10)
+	assert.NoError(t, err)
+	movieRepo.AssertNumberOfCalls(t, "GetMovies", 2)
+
+	// Other services drop the cached ranking through the indexer hook.
+	assert.NoError(t, svc.IndexMovie(context.Background(), source))
+	_, err = svc.GetSimilarMovies(context.Background(), "tt1", 10)
+	assert.NoError(t, err)
+	movieRepo.AssertNumberOfCalls(t, "GetMovies", 3)
+}
+
+func TestRecordWatch_RecordsPlayEvent(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	eventRepo := new(mocks.MockEventRepository)
+	svc := service.NewMovieService(movieRepo, nil, nil, activityRepo, eventRepo, nil, nil, &config.Config{})
+
+	entry := models.WatchHistory{UserID: "user123", ImdbID: "tt1", ProgressSeconds: 60}
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1"}, nil)
+	activityRepo.On("UpsertWatchHistory", mock.Anything, mock.Anything).Return(nil)
+	eventRepo.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e models.Event) bool {
+		return e.UserID == "user123" && e.ImdbID == "tt1" && e.Type == models.EventPlay && time.Since(e.CreatedAt) < time.Minute
+	})).Return(nil)
+
+	err := svc.RecordWatch(context.Background(), entry)
+
+	assert.NoError(t, err)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestRecordWatch_RejectsSeries(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	svc := service.NewMovieService(movieRepo, nil, nil, activityRepo, nil, nil, nil, &config.Config{})
+
+	movieRepo.On("GetMovie", mock.Anything, "tt0903747").Return(&models.Movie{ImdbID: "tt0903747", ContentType: models.ContentTypeSeries}, nil)
+
+	err := svc.RecordWatch(context.Background(), models.WatchHistory{UserID: "user123", ImdbID: "tt0903747"})
+
+	assert.ErrorIs(t, err, service.ErrWatchSeriesTitle)
+	activityRepo.AssertNotCalled(t, "UpsertWatchHistory", mock.Anything, mock.Anything)
+}
+
+func TestAddMovie_IndexesForSearch(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	index := search.NewMemoryIndex()
+	svc := service.NewMovieService(movieRepo, nil, nil, nil, nil, nil, []service.MovieIndexer{service.NewSearchService(index, movieRepo)}, &config.Config{})
+
+	movie := models.Movie{ImdbID: "tt1", Title: "Arrival"}
+	movieRepo.On("CreateMovie", mock.Anything, movie).Return(&mongo.InsertOneResult{}, nil)
+
+	err := svc.AddMovie(context.Background(), movie)
+	assert.NoError(t, err)
+
+	hits, err := index.Search(context.Background(), "arival", 10)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, "tt1", hits[0].ImdbID)
+}
+
+func TestAddMovie_UsesStoredGenres(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	genreRepo := new(mocks.MockGenreRepository)
+	svc := service.NewMovieService(movieRepo, genreRepo, nil, nil, nil, nil, nil, &config.Config{})
+
+	genreRepo.On("GetGenresByIDs", mock.Anything, []int{3, 3}).Return([]models.Genre{{GenreID: 3, GenreName: "Sci-Fi"}}, nil)
+	movieRepo.On("CreateMovie", mock.Anything, mock.MatchedBy(func(m models.Movie) bool {
+		return len(m.Genre) == 1 && m.Genre[0].GenreName == "Sci-Fi"
+	})).Return(&mongo.InsertOneResult{}, nil)
+
+	movie := models.Movie{ImdbID: "tt1", Title: "Arrival", Genre: []models.Genre{{GenreID: 3, GenreName: "SciFi"}, {GenreID: 3, GenreName: "Sci-Fi"}}}
+	err := svc.AddMovie(context.Background(), movie)
+
+	assert.NoError(t, err)
+	movieRepo.AssertExpectations(t)
+}
+
+func TestAddMovie_RejectsUnknownGenres(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	genreRepo := new(mocks.MockGenreRepository)
+	svc := service.NewMovieService(movieRepo, genreRepo, nil, nil, nil, nil, nil, &config.Config{})
+
+	genreRepo.On("GetGenresByIDs", mock.Anything, []int{3, 99}).Return([]models.Genre{{GenreID: 3, GenreName: "Sci-Fi"}}, nil)
+
+	movie := models.Movie{ImdbID: "tt1", Title: "Arrival", Genre: []models.Genre{{GenreID: 3}, {GenreID: 99, GenreName: "Sci-Fy"}}}
+	err := svc.AddMovie(context.Background(), movie)
+
+	assert.ErrorIs(t, err, service.ErrUnknownGenres)
+	assert.Contains(t, err.Error(), "99")
+	movieRepo.AssertNotCalled(t, "CreateMovie", mock.Anything, mock.Anything)
+}
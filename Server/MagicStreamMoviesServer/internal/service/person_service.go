@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrInvalidID      = errors.New("invalid id")
+	ErrPersonNotFound = errors.New("person not found")
+	ErrCreditNotFound = errors.New("credit not found")
+)
+
+// defaultPeoplePageSize applies to filmographies and people listings.
+const defaultPeoplePageSize = 20
+
+type PersonService interface {
+	// GetPerson returns the person with one page of their filmography.
+	GetPerson(ctx context.Context, id string, page models.Pagination) (*models.PersonPage, error)
+	SearchPeople(ctx context.Context, query string, page models.Pagination) ([]models.Person, error)
+	CreatePerson(ctx context.Context, person models.Person) (*models.Person, error)
+	UpdatePerson(ctx context.Context, id string, person models.Person) (*models.Person, error)
+	// DeletePerson removes the person together with all their credits.
+	DeletePerson(ctx context.Context, id string) error
+
+	GetMovieCredits(ctx context.Context, imdbID string) ([]models.MovieCredit, error)
+	AddCredit(ctx context.Context, imdbID string, req models.CreditRequest) (*models.MovieCredit, error)
+	DeleteCredit(ctx context.Context, imdbID string, creditID string) error
+}
+
+type personService struct {
+	personRepo repository.PersonRepository
+	movieRepo  repository.MovieRepository
+	indexers   []MovieIndexer
+}
+
+func NewPersonService(personRepo repository.PersonRepository, movieRepo repository.MovieRepository, indexers []MovieIndexer) PersonService {
+	return &personService{
+		personRepo: personRepo,
+		movieRepo:  movieRepo,
+		indexers:   indexers,
+	}
+}
+
+func (s *personService) GetPerson(ctx context.Context, id string, page models.Pagination) (*models.PersonPage, error) {
+	personID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	person, err := s.getPerson(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	page = normalizePage(page, defaultPeoplePageSize)
+	filmography, err := s.personRepo.GetFilmography(ctx, personID, page.Skip(), page.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.PersonPage{
+		Person:      *person,
+		Filmography: filmography,
+		Page:        page.Page,
+		PageSize:    page.PageSize,
+	}
+	if int64(len(result.Filmography)) > page.PageSize {
+		result.Filmography = result.Filmography[:page.PageSize]
+		result.HasMore = true
+	}
+	return result, nil
+}
+
+func (s *personService) SearchPeople(ctx context.Context, query string, page models.Pagination) ([]models.Person, error) {
+	page = normalizePage(page, defaultPeoplePageSize)
+	return s.personRepo.SearchPeople(ctx, query, page.Skip(), page.PageSize)
+}
+
+func (s *personService) CreatePerson(ctx context.Context, person models.Person) (*models.Person, error) {
+	return s.personRepo.CreatePerson(ctx, person)
+}
+
+func (s *personService) UpdatePerson(ctx context.Context, id string, person models.Person) (*models.Person, error) {
+	personID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.getPerson(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	person.ID = personID
+	if err := s.personRepo.UpdatePerson(ctx, person); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrPersonNotFound
+		}
+		return nil, err
+	}
+	if person.Name != existing.Name {
+		imdbIDs, err := s.personRepo.GetCreditedMovieIDs(ctx, personID)
+		if err != nil {
+			return nil, err
+		}
+		s.refreshCreditNames(ctx, imdbIDs...)
+	}
+	return s.getPerson(ctx, personID)
+}
+
+func (s *personService) DeletePerson(ctx context.Context, id string) error {
+	personID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.personRepo.DeletePerson(ctx, personID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrPersonNotFound
+		}
+		return err
+	}
+	imdbIDs, err := s.personRepo.DeleteCreditsForPerson(ctx, personID)
+	if err != nil {
+		return err
+	}
+	s.refreshCreditNames(ctx, imdbIDs...)
+	return nil
+}
+
+func (s *personService) GetMovieCredits(ctx context.Context, imdbID string) ([]models.MovieCredit, error) {
+	if _, err := s.movieRepo.GetMovie(ctx, imdbID); err != nil {
+		return nil, err
+	}
+	return s.personRepo.GetMovieCredits(ctx, imdbID)
+}
+
+func (s *personService) AddCredit(ctx context.Context, imdbID string, req models.CreditRequest) (*models.MovieCredit, error) {
+	personID, err := parseID(req.PersonID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.movieRepo.GetMovie(ctx, imdbID); err != nil {
+		return nil, err
+	}
+	person, err := s.getPerson(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	credit, err := s.personRepo.CreateCredit(ctx, models.Credit{
+		PersonID:  personID,
+		ImdbID:    imdbID,
+		Role:      req.Role,
+		Character: req.Character,
+		Order:     req.Order,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.refreshCreditNames(ctx, imdbID)
+	return &models.MovieCredit{Credit: *credit, Person: *person}, nil
+}
+
+func (s *personService) DeleteCredit(ctx context.Context, imdbID string, creditID string) error {
+	id, err := parseID(creditID)
+	if err != nil {
+		return err
+	}
+	credit, err := s.personRepo.GetCredit(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrCreditNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if credit.ImdbID != imdbID {
+		return ErrCreditNotFound
+	}
+	if err := s.personRepo.DeleteCredit(ctx, id); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrCreditNotFound
+		}
+		return err
+	}
+	s.refreshCreditNames(ctx, imdbID)
+	return nil
+}
+
+// refreshCreditNames copies the names of the credited people onto each movie and reindexes it.
+// The credits themselves are already saved, so failures are only logged.
+func (s *personService) refreshCreditNames(ctx context.Context, imdbIDs ...string) {
+	for _, imdbID := range imdbIDs {
+		credits, err := s.personRepo.GetMovieCredits(ctx, imdbID)
+		if err != nil {
+			log.Printf("failed to load credits of %s: %v", imdbID, err)
+			continue
+		}
+		names := []string{}
+		seen := make(map[string]bool, len(credits))
+		for _, credit := range credits {
+			if !seen[credit.Person.Name] {
+				seen[credit.Person.Name] = true
+				names = append(names, credit.Person.Name)
+			}
+		}
+		if err := s.movieRepo.SetMovieCreditNames(ctx, imdbID, names); err != nil {
+			log.Printf("failed to update credit names of %s: %v", imdbID, err)
+			continue
+		}
+
+		movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+		if err != nil {
+			log.Printf("failed to reload movie %s: %v", imdbID, err)
+			continue
+		}
+		indexMovies(ctx, s.indexers, *movie)
+	}
+}
+
+func (s *personService) getPerson(ctx context.Context, id bson.ObjectID) (*models.Person, error) {
+	person, err := s.personRepo.GetPerson(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrPersonNotFound
+	}
+	return person, err
+}
+
+func parseID(id string) (bson.ObjectID, error) {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return bson.ObjectID{}, ErrInvalidID
+	}
+	return objectID, nil
+}
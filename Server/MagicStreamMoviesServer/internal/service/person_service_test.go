@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestPersonService_GetPersonPaginatesFilmography(t *testing.T) {
+	personRepo := new(mocks.MockPersonRepository)
+	svc := service.NewPersonService(personRepo, nil, nil)
+
+	id := bson.NewObjectID()
+	personRepo.On("GetPerson", mock.Anything, id).Return(&models.Person{ID: id, Name: "Rian Johnson"}, nil)
+	personRepo.On("GetFilmography", mock.Anything, id, int64(2), int64(3)).Return([]models.FilmographyEntry{
+		{Role: models.CreditDirector, Movie: models.Movie{ImdbID: "tt3"}},
+		{Role: models.CreditDirector, Movie: models.Movie{ImdbID: "tt2"}},
+		{Role: models.CreditWriter, Movie: models.Movie{ImdbID: "tt1"}},
+	}, nil)
+
+	page, err := svc.GetPerson(context.Background(), id.Hex(), models.Pagination{Page: 2, PageSize: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Rian Johnson", page.Person.Name)
+	assert.Len(t, page.Filmography, 2)
+	assert.True(t, page.HasMore)
+}
+
+func TestPersonService_GetPersonErrors(t *testing.T) {
+	personRepo := new(mocks.MockPersonRepository)
+	svc := service.NewPersonService(personRepo, nil, nil)
+
+	_, err := svc.GetPerson(context.Background(), "not-an-id", models.Pagination{})
+	assert.True(t, errors.Is(err, service.ErrInvalidID))
+
+	id := bson.NewObjectID()
+	personRepo.On("GetPerson", mock.Anything, id).Return(nil, mongo.ErrNoDocuments)
+	_, err = svc.GetPerson(context.Background(), id.Hex(), models.Pagination{})
+	assert.True(t, errors.Is(err, service.ErrPersonNotFound))
+}
+
+func TestPersonService_UpdateDeletedPerson(t *testing.T) {
+	personRepo := new(mocks.MockPersonRepository)
+	svc := service.NewPersonService(personRepo, nil, nil)
+
+	id := bson.NewObjectID()
+	personRepo.On("GetPerson", mock.Anything, id).Return(&models.Person{ID: id, Name: "Rian Johnson"}, nil)
+	personRepo.On("UpdatePerson", mock.Anything, mock.Anything).Return(mongo.ErrNoDocuments)
+
+	_, err := svc.UpdatePerson(context.Background(), id.Hex(), models.Person{Name: "Rian Johnson"})
+
+	assert.True(t, errors.Is(err, service.ErrPersonNotFound))
+}
+
+func TestPersonService_AddCreditReindexesMovie(t *testing.T) {
+	personRepo := new(mocks.MockPersonRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	indexer := new(mocks.MockSearchService)
+	svc := service.NewPersonService(personRepo, movieRepo, []service.MovieIndexer{indexer})
+
+	id := bson.NewObjectID()
+	person := models.Person{ID: id, Name: "Emily Blunt"}
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", Title: "Looper"}, nil)
+	personRepo.On("GetPerson", mock.Anything, id).Return(&person, nil)
+	personRepo.On("CreateCredit", mock.Anything, models.Credit{PersonID: id, ImdbID: "tt1", Role: models.CreditActor, Character: "Sara"}).
+		Return(&models.Credit{ID: bson.NewObjectID(), PersonID: id, ImdbID: "tt1", Role: models.CreditActor, Character: "Sara"}, nil)
+	personRepo.On("GetMovieCredits", mock.Anything, "tt1").Return([]models.MovieCredit{
+		{Credit: models.Credit{Role: models.CreditActor}, Person: person},
+		{Credit: models.Credit{Role: models.CreditDirector}, Person: models.Person{Name: "Rian Johnson"}},
+	}, nil)
+	movieRepo.On("SetMovieCreditNames", mock.Anything, "tt1", []string{"Emily Blunt", "Rian Johnson"}).Return(nil)
+	indexer.On("IndexMovie", mock.Anything, mock.MatchedBy(func(m models.Movie) bool { return m.ImdbID == "tt1" })).Return(nil)
+
+	credit, err := svc.AddCredit(context.Background(), "tt1", models.CreditRequest{PersonID: id.Hex(), Role: models.CreditActor, Character: "Sara"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Emily Blunt", credit.Person.Name)
+	movieRepo.AssertExpectations(t)
+	indexer.AssertExpectations(t)
+}
+
+func TestPersonService_DeleteCreditOfAnotherMovie(t *testing.T) {
+	personRepo := new(mocks.MockPersonRepository)
+	svc := service.NewPersonService(personRepo, nil, nil)
+
+	id := bson.NewObjectID()
+	personRepo.On("GetCredit", mock.Anything, id).Return(&models.Credit{ID: id, ImdbID: "tt2"}, nil)
+
+	err := svc.DeleteCredit(context.Background(), "tt1", id.Hex())
+
+	assert.True(t, errors.Is(err, service.ErrCreditNotFound))
+	personRepo.AssertNotCalled(t, "DeleteCredit")
+}
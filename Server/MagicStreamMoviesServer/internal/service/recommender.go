@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+)
+
+const (
+	StrategyGenre         = "genre"
+	StrategyCollaborative = "collaborative"
+)
+
+// Recommender is the strategy MovieService uses to build a user's recommendations. Every
+// recommendation carries the reasons it was made, and Explain gives the full score breakdown.
+type Recommender interface {
+	Recommend(ctx context.Context, userId string, page models.Pagination) ([]models.RecommendedMovie, error)
+	Explain(ctx context.Context, userId string, imdbID string) (*models.RecommendationExplanation, error)
+}
+
+// NewRecommender builds the recommender selected by cfg.RecommendationStrategy.
+func NewRecommender(
+	movieRepo repository.MovieRepository,
+	userRepo repository.UserRepository,
+	activityRepo repository.ActivityRepository,
+	similarityRepo repository.SimilarityRepository,
+	vectors *search.VectorIndex,
+	cfg *config.Config,
+) (Recommender, error) {
+	switch cfg.RecommendationStrategy {
+	case StrategyGenre:
+		return NewGenreRecommender(movieRepo, userRepo, activityRepo, cfg), nil
+	case StrategyCollaborative:
+		return NewCollaborativeRecommender(movieRepo, userRepo, activityRepo, similarityRepo, vectors, cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown recommendation strategy %q", cfg.RecommendationStrategy)
+	}
+}
+
+// genreRecommender returns top-ranked movies in the user's favourite genres that the user has
+// not already watched or reviewed. Users without favourites get the global top-ranked list.
+type genreRecommender struct {
+	movieRepo    repository.MovieRepository
+	userRepo     repository.UserRepository
+	activityRepo repository.ActivityRepository
+	config       *config.Config
+}
+
+func NewGenreRecommender(movieRepo repository.MovieRepository, userRepo repository.UserRepository, activityRepo repository.ActivityRepository, cfg *config.Config) Recommender {
+	return &genreRecommender{
+		movieRepo:    movieRepo,
+		userRepo:     userRepo,
+		activityRepo: activityRepo,
+		config:       cfg,
+	}
+}
+
+func (r *genreRecommender) Recommend(ctx context.Context, userId string, page models.Pagination) ([]models.RecommendedMovie, error) {
+	genres, err := r.userRepo.GetUserFavouriteGenres(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	seen, err := r.activityRepo.GetSeenMovieIDs(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	page = normalizePage(page, r.config.RecommendedMovieLimit)
+	movies, err := r.movieRepo.GetRecommendedMovies(ctx, genres, seen, page.Skip(), page.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	recommended := make([]models.RecommendedMovie, 0, len(movies))
+	for _, movie := range movies {
+		score, _ := r.score(movie, genres)
+		recommended = append(recommended, models.RecommendedMovie{
+			Movie:   movie,
+			Score:   score,
+			Reasons: r.reasons(movie, genres),
+		})
+	}
+	return recommended, nil
+}
+
+func (r *genreRecommender) Explain(ctx context.Context, userId string, imdbID string) (*models.RecommendationExplanation, error) {
+	movie, err := r.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	genres, err := r.userRepo.GetUserFavouriteGenres(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	seen, err := r.activityRepo.GetSeenMovieIDs(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	score, components := r.score(*movie, genres)
+	return &models.RecommendationExplanation{
+		ImdbID:      movie.ImdbID,
+		Title:       movie.Title,
+		Strategy:    StrategyGenre,
+		Score:       score,
+		AlreadySeen: slices.Contains(seen, imdbID),
+		Components:  components,
+		Reasons:     r.reasons(*movie, genres),
+	}, nil
+}
+
+// score reports the genre filter as a zero-weight component: it decides eligibility, while the
+// order within the favourite genres comes from the admin ranking alone.
+func (r *genreRecommender) score(movie models.Movie, favourites []string) (float64, []models.ScoreComponent) {
+	genreMatch := 0.0
+	if len(favourites) == 0 || len(matchingGenres(movie, favourites)) > 0 {
+		genreMatch = 1
+	}
+	ranking := rankingScore(movie.Ranking)
+	return ranking, []models.ScoreComponent{
+		{Signal: "genre_match", Value: genreMatch, Weight: 0, Contribution: 0},
+		{Signal: "ranking", Value: ranking, Weight: 1, Contribution: ranking},
+	}
+}
+
+func (r *genreRecommender) reasons(movie models.Movie, favourites []string) []models.RecommendationReason {
+	reasons := []models.RecommendationReason{}
+	for _, genre := range matchingGenres(movie, favourites) {
+		reasons = append(reasons, models.RecommendationReason{Type: models.ReasonGenreMatch, Genre: genre})
+	}
+	if len(favourites) == 0 || isTopRanked(movie.Ranking) {
+		reasons = append(reasons, models.RecommendationReason{Type: models.ReasonTopRanked, RankingName: movie.Ranking.RankingName})
+	}
+	return reasons
+}
+
+func matchingGenres(movie models.Movie, genres []string) []string {
+	matches := []string{}
+	for _, genre := range movie.Genre {
+		if slices.Contains(genres, genre.GenreName) {
+			matches = append(matches, genre.GenreName)
+		}
+	}
+	return matches
+}
+
+// normalizePage fills in defaults for missing values and clamps the page size.
+func normalizePage(page models.Pagination, defaultSize int64) models.Pagination {
+	if page.Page < 1 {
+		page.Page = 1
+	}
+	if page.PageSize < 1 {
+		page.PageSize = defaultSize
+	}
+	if page.PageSize > maxPageSize {
+		page.PageSize = maxPageSize
+	}
+	return page
+}
@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenreRecommender_ExcludesSeenMovies(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	cfg := &config.Config{RecommendedMovieLimit: 5}
+	recommender := service.NewGenreRecommender(movieRepo, userRepo, activityRepo, cfg)
+
+	genres := []string{"Comedy"}
+	seen := []string{"tt1", "tt2"}
+	movies := []models.Movie{{ImdbID: "tt3", Title: "Unseen"}}
+
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "user123").Return(genres, nil)
+	activityRepo.On("GetSeenMovieIDs", mock.Anything, "user123").Return(seen, nil)
+	movieRepo.On("GetRecommendedMovies", mock.Anything, genres, seen, int64(0), int64(5)).Return(movies, nil)
+
+	result, err := recommender.Recommend(context.Background(), "user123", models.Pagination{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "tt3", result[0].ImdbID)
+	movieRepo.AssertExpectations(t)
+	activityRepo.AssertExpectations(t)
+}
+
+func TestGenreRecommender_NoFavouritesFallsBackToGlobal(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	cfg := &config.Config{RecommendedMovieLimit: 5}
+	recommender := service.NewGenreRecommender(movieRepo, userRepo, activityRepo, cfg)
+
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "user123").Return([]string{}, nil)
+	activityRepo.On("GetSeenMovieIDs", mock.Anything, "user123").Return([]string{}, nil)
+	movieRepo.On("GetRecommendedMovies", mock.Anything, []string{}, []string{}, int64(20), int64(10)).Return([]models.Movie{}, nil)
+
+	_, err := recommender.Recommend(context.Background(), "user123", models.Pagination{Page: 3, PageSize: 10})
+
+	assert.NoError(t, err)
+	movieRepo.AssertExpectations(t)
+}
+
+func TestGenreRecommender_ClampsPageSize(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	cfg := &config.Config{RecommendedMovieLimit: 5}
+	recommender := service.NewGenreRecommender(movieRepo, userRepo, activityRepo, cfg)
+
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "user123").Return([]string{"Drama"}, nil)
+	activityRepo.On("GetSeenMovieIDs", mock.Anything, "user123").Return([]string{}, nil)
+	movieRepo.On("GetRecommendedMovies", mock.Anything, []string{"Drama"}, []string{}, int64(0), int64(100)).Return([]models.Movie{}, nil)
+
+	_, err := recommender.Recommend(context.Background(), "user123", models.Pagination{PageSize: 1000})
+
+	assert.NoError(t, err)
+	movieRepo.AssertExpectations(t)
+}
+
+func TestCollaborativeRecommender_BlendsSignals(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	similarityRepo := new(mocks.MockSimilarityRepository)
+	cfg := &config.Config{
+		RecommendedMovieLimit: 2,
+		RecommendationWeights: config.RecommendationWeights{CF: 0.6, Genre: 0.3, Ranking: 0.1},
+	}
+	recommender := service.NewCollaborativeRecommender(movieRepo, userRepo, activityRepo, similarityRepo, nil, cfg)
+
+	comedy := models.Genre{GenreID: 1, GenreName: "Comedy"}
+	drama := models.Genre{GenreID: 2, GenreName: "Drama"}
+	watched := models.Movie{ImdbID: "tt1", Genre: []models.Genre{drama}, Ranking: models.Ranking{RankingValue: 1}}
+	neighbour := models.Movie{ImdbID: "tt2", Genre: []models.Genre{drama}, Ranking: models.Ranking{RankingValue: 3}}
+	favourite := models.Movie{ImdbID: "tt3", Genre: []models.Genre{comedy}, Ranking: models.Ranking{RankingValue: 999}}
+
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "user123").Return([]string{"Comedy"}, nil)
+	activityRepo.On("GetWatchHistory", mock.Anything, "user123").Return([]models.WatchHistory{{UserID: "user123", ImdbID: "tt1", Completed: true}}, nil)
+	activityRepo.On("GetUserReviews", mock.Anything, "user123").Return([]models.Review{}, nil)
+	similarityRepo.On("GetSimilarities", mock.Anything, []string{"tt1"}).Return([]models.MovieSimilarity{
+		{ImdbID: "tt1", Neighbors: []models.SimilarMovie{{ImdbID: "tt2", Score: 0.9}}},
+	}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt1"}).Return([]models.Movie{watched}, nil)
+	movieRepo.On("GetRecommendedMovies", mock.Anything, []string{"Comedy"}, []string{"tt1"}, int64(0), int64(500)).Return([]models.Movie{favourite}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt2"}).Return([]models.Movie{neighbour}, nil)
+
+	result, err := recommender.Recommend(context.Background(), "user123", models.Pagination{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	// tt2 scores 0.6 (CF) + 0.3 (Drama affinity 1) + 0.1/3; tt3 scores 0.3 (Comedy affinity 1).
+	assert.Equal(t, "tt2", result[0].ImdbID)
+	assert.Equal(t, "tt3", result[1].ImdbID)
+	assert.Contains(t, result[0].Reasons, models.RecommendationReason{Type: models.ReasonBecauseYouWatched, ImdbID: "tt1"})
+	assert.Contains(t, result[0].Reasons, models.RecommendationReason{Type: models.ReasonGenreMatch, Genre: "Drama"})
+	assert.Equal(t, []models.RecommendationReason{{Type: models.ReasonGenreMatch, Genre: "Comedy"}}, result[1].Reasons)
+
+	// Pages slice one ranking, so the second page of one picks up where the first left off.
+	first, err := recommender.Recommend(context.Background(), "user123", models.Pagination{Page: 1, PageSize: 1})
+	assert.NoError(t, err)
+	second, err := recommender.Recommend(context.Background(), "user123", models.Pagination{Page: 2, PageSize: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, first, 1) && assert.Len(t, second, 1) {
+		assert.Equal(t, "tt2", first[0].ImdbID)
+		assert.Equal(t, "tt3", second[0].ImdbID)
+	}
+}
+
+func TestCollaborativeRecommender_Explain(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	similarityRepo := new(mocks.MockSimilarityRepository)
+	cfg := &config.Config{
+		RecommendationWeights: config.RecommendationWeights{CF: 0.6, Genre: 0.3, Ranking: 0.1},
+	}
+	recommender := service.NewCollaborativeRecommender(movieRepo, userRepo, activityRepo, similarityRepo, nil, cfg)
+
+	movie := models.Movie{
+		ImdbID:  "tt9",
+		Title:   "Top Comedy",
+		Genre:   []models.Genre{{GenreID: 1, GenreName: "Comedy"}},
+		Ranking: models.Ranking{RankingValue: 1, RankingName: "Excellent"},
+	}
+
+	movieRepo.On("GetMovie", mock.Anything, "tt9").Return(&movie, nil)
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "user123").Return([]string{"Comedy"}, nil)
+	activityRepo.On("GetWatchHistory", mock.Anything, "user123").Return([]models.WatchHistory{}, nil)
+	activityRepo.On("GetUserReviews", mock.Anything, "user123").Return([]models.Review{}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, mock.Anything).Return([]models.Movie{}, nil)
+
+	explanation, err := recommender.Explain(context.Background(), "user123", "tt9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, service.StrategyCollaborative, explanation.Strategy)
+	assert.False(t, explanation.AlreadySeen)
+	assert.Len(t, explanation.Components, 4)
+	assert.InDelta(t, 0.4, explanation.Score, 1e-9)
+	assert.Equal(t, []models.RecommendationReason{
+		{Type: models.ReasonGenreMatch, Genre: "Comedy"},
+		{Type: models.ReasonTopRanked, RankingName: "Excellent"},
+	}, explanation.Reasons)
+	similarityRepo.AssertNotCalled(t, "GetSimilarities")
+}
+
+func TestCollaborativeRecommender_SemanticSignal(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	userRepo := new(mocks.MockUserRepository)
+	activityRepo := new(mocks.MockActivityRepository)
+	similarityRepo := new(mocks.MockSimilarityRepository)
+	vectors := search.NewVectorIndex()
+	cfg := &config.Config{
+		RecommendedMovieLimit: 1,
+		RecommendationWeights: config.RecommendationWeights{Semantic: 1},
+	}
+	recommender := service.NewCollaborativeRecommender(movieRepo, userRepo, activityRepo, similarityRepo, vectors, cfg)
+
+	vectors.Replace(map[string][]float32{
+		"tt1": {1, 0},
+		"tt2": {0.9, 0.1},
+		"tt3": {0, 1},
+	})
+	userRepo.On("GetUserFavouriteGenres", mock.Anything, "user123").Return([]string{}, nil)
+	activityRepo.On("GetWatchHistory", mock.Anything, "user123").Return([]models.WatchHistory{{ImdbID: "tt1", Completed: true}}, nil)
+	activityRepo.On("GetUserReviews", mock.Anything, "user123").Return([]models.Review{}, nil)
+	similarityRepo.On("GetSimilarities", mock.Anything, []string{"tt1"}).Return([]models.MovieSimilarity{}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt1"}).Return([]models.Movie{{ImdbID: "tt1"}}, nil)
+	movieRepo.On("GetRecommendedMovies", mock.Anything, []string{}, []string{"tt1"}, int64(0), int64(500)).
+		Return([]models.Movie{{ImdbID: "tt3"}}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt2"}).Return([]models.Movie{{ImdbID: "tt2"}}, nil)
+
+	result, err := recommender.Recommend(context.Background(), "user123", models.Pagination{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "tt2", result[0].ImdbID)
+}
+
+func TestComputeItemSimilarities(t *testing.T) {
+	history := []models.WatchHistory{
+		{UserID: "u1", ImdbID: "tt1", Completed: true},
+		{UserID: "u1", ImdbID: "tt2", Completed: true},
+		{UserID: "u2", ImdbID: "tt1", Completed: true},
+		{UserID: "u2", ImdbID: "tt2", Completed: true},
+		{UserID: "u2", ImdbID: "tt3", Completed: true},
+	}
+	reviews := []models.Review{{UserID: "u3", ImdbID: "tt3", Rating: 5}}
+
+	similarities := service.ComputeItemSimilarities(history, reviews, 1, time.Now())
+
+	assert.Len(t, similarities, 3)
+	assert.Equal(t, "tt1", similarities[0].ImdbID)
+	assert.Len(t, similarities[0].Neighbors, 1)
+	assert.Equal(t, "tt2", similarities[0].Neighbors[0].ImdbID)
+	assert.InDelta(t, 1.0, similarities[0].Neighbors[0].Score, 1e-9)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+)
+
+// maxFacetedHits is how many search hits are filtered and counted into facets.
+const maxFacetedHits = 1000
+
+type SearchService interface {
+	// Search returns the best matches for query that pass filters, along with facet counts
+	// over every match.
+	Search(ctx context.Context, query string, filters models.MovieFilters, limit int) (*models.SearchResponse, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]models.Suggestion, error)
+	// Reindex rebuilds the search index from the movies collection.
+	Reindex(ctx context.Context) error
+	MovieIndexer
+}
+
+type searchService struct {
+	index     search.SearchIndex
+	movieRepo repository.MovieRepository
+}
+
+func NewSearchService(index search.SearchIndex, movieRepo repository.MovieRepository) SearchService {
+	return &searchService{
+		index:     index,
+		movieRepo: movieRepo,
+	}
+}
+
+func (s *searchService) Search(ctx context.Context, query string, filters models.MovieFilters, limit int) (*models.SearchResponse, error) {
+	// Facets describe the whole result set, so they are computed over more hits than are returned.
+	hits, err := s.index.Search(ctx, query, maxFacetedHits)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ImdbID)
+	}
+	var movies []models.Movie
+	if filters.IsEmpty() {
+		movies, err = s.movieRepo.GetMoviesByIDs(ctx, ids)
+	} else {
+		movies, err = s.movieRepo.FilterMovies(ctx, ids, filters)
+	}
+	if err != nil {
+		return nil, err
+	}
+	facets, err := s.movieRepo.GetFacets(ctx, ids, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ImdbID] = movie
+	}
+	response := &models.SearchResponse{Results: []models.SearchResult{}, Facets: *facets}
+	for _, hit := range hits {
+		if limit > 0 && len(response.Results) == limit {
+			break
+		}
+		if movie, ok := byID[hit.ImdbID]; ok {
+			response.Results = append(response.Results, models.SearchResult{Movie: movie, Score: hit.Score})
+		}
+	}
+	return response, nil
+}
+
+func (s *searchService) Suggest(ctx context.Context, prefix string, limit int) ([]models.Suggestion, error) {
+	return s.index.Suggest(ctx, prefix, limit)
+}
+
+func (s *searchService) Reindex(ctx context.Context) error {
+	movies, err := s.movieRepo.GetMovies(ctx)
+	if err != nil {
+		return err
+	}
+	return s.index.Rebuild(ctx, movies)
+}
+
+func (s *searchService) IndexMovie(ctx context.Context, movie models.Movie) error {
+	return s.index.Index(ctx, movie)
+}
@@ -0,0 +1,41 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearch_FiltersHitsAndKeepsRelevanceOrder(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	index := search.NewMemoryIndex()
+	svc := service.NewSearchService(index, movieRepo)
+
+	drama := models.Genre{GenreID: 1, GenreName: "Drama"}
+	catalog := []models.Movie{
+		{ImdbID: "tt1", Title: "Night Train", Genre: []models.Genre{drama}},
+		{ImdbID: "tt2", Title: "Night Train Night", Genre: []models.Genre{drama}},
+		{ImdbID: "tt3", Title: "Night Shift"},
+	}
+	movieRepo.On("GetMovies", mock.Anything).Return(catalog, nil)
+	assert.NoError(t, svc.Reindex(context.Background()))
+
+	filters := models.MovieFilters{Genres: []string{"Drama"}}
+	facets := &models.Facets{Genres: []models.FacetCount{{Value: "Drama", Count: 2}}}
+	movieRepo.On("FilterMovies", mock.Anything, []string{"tt2", "tt3", "tt1"}, filters).
+		Return([]models.Movie{catalog[0], catalog[1]}, nil)
+	movieRepo.On("GetFacets", mock.Anything, []string{"tt2", "tt3", "tt1"}, filters).Return(facets, nil)
+
+	response, err := svc.Search(context.Background(), "night", filters, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Results, 1)
+	assert.Equal(t, "tt2", response.Results[0].ImdbID)
+	assert.Equal(t, *facets, response.Facets)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/embedding"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+)
+
+var ErrSemanticSearchUnavailable = errors.New("semantic search is not configured")
+
+// embedBatchSize caps how many texts are sent to the embedder in one request.
+const embedBatchSize = 100
+
+type SemanticService interface {
+	// Search returns the movies whose embeddings are closest to the embedding of query.
+	Search(ctx context.Context, query string, limit int) ([]models.SearchResult, error)
+	// Sync embeds every movie whose text changed since it was last embedded and reloads the
+	// vector index from the stored embeddings.
+	Sync(ctx context.Context) error
+	MovieIndexer
+}
+
+type semanticService struct {
+	embedder      embedding.Embedder
+	embeddingRepo repository.EmbeddingRepository
+	movieRepo     repository.MovieRepository
+	vectors       *search.VectorIndex
+}
+
+// NewSemanticService builds the service. embedder may be nil, in which case searches fail with
+// ErrSemanticSearchUnavailable and indexing does nothing.
+func NewSemanticService(embedder embedding.Embedder, embeddingRepo repository.EmbeddingRepository, movieRepo repository.MovieRepository, vectors *search.VectorIndex) SemanticService {
+	return &semanticService{
+		embedder:      embedder,
+		embeddingRepo: embeddingRepo,
+		movieRepo:     movieRepo,
+		vectors:       vectors,
+	}
+}
+
+func (s *semanticService) Search(ctx context.Context, query string, limit int) ([]models.SearchResult, error) {
+	if s.embedder == nil {
+		return nil, ErrSemanticSearchUnavailable
+	}
+	vectors, err := s.embedTexts(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	hits := s.vectors.Search(vectors[0], limit, nil)
+
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ImdbID)
+	}
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ImdbID] = movie
+	}
+	results := []models.SearchResult{}
+	for _, hit := range hits {
+		if movie, ok := byID[hit.ImdbID]; ok {
+			results = append(results, models.SearchResult{Movie: movie, Score: hit.Score})
+		}
+	}
+	return results, nil
+}
+
+func (s *semanticService) IndexMovie(ctx context.Context, movie models.Movie) error {
+	if s.embedder == nil {
+		return nil
+	}
+	embeddings, err := s.embed(ctx, []models.Movie{movie})
+	if err != nil {
+		return err
+	}
+	if err := s.embeddingRepo.UpsertEmbeddings(ctx, embeddings); err != nil {
+		return err
+	}
+	s.vectors.Upsert(movie.ImdbID, embeddings[0].Vector)
+	return nil
+}
+
+func (s *semanticService) Sync(ctx context.Context) error {
+	if s.embedder == nil {
+		return nil
+	}
+	movies, err := s.movieRepo.GetMovies(ctx)
+	if err != nil {
+		return err
+	}
+	stored, err := s.embeddingRepo.GetEmbeddings(ctx, s.embedder.Model())
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]models.MovieEmbedding, len(stored))
+	for _, e := range stored {
+		current[e.ImdbID] = e
+	}
+	var stale []models.Movie
+	for _, movie := range movies {
+		if e, ok := current[movie.ImdbID]; !ok || e.TextHash != textHash(embeddingText(movie)) {
+			stale = append(stale, movie)
+		}
+	}
+
+	for start := 0; start < len(stale); start += embedBatchSize {
+		batch := stale[start:min(start+embedBatchSize, len(stale))]
+		embeddings, err := s.embed(ctx, batch)
+		if err != nil {
+			return err
+		}
+		if err := s.embeddingRepo.UpsertEmbeddings(ctx, embeddings); err != nil {
+			return err
+		}
+		for _, e := range embeddings {
+			current[e.ImdbID] = e
+		}
+	}
+
+	// Embeddings of movies that no longer exist are left out of the index.
+	vectors := make(map[string][]float32, len(movies))
+	for _, movie := range movies {
+		vectors[movie.ImdbID] = current[movie.ImdbID].Vector
+	}
+	s.vectors.Replace(vectors)
+	return nil
+}
+
+func (s *semanticService) embed(ctx context.Context, movies []models.Movie) ([]models.MovieEmbedding, error) {
+	texts := make([]string, 0, len(movies))
+	for _, movie := range movies {
+		texts = append(texts, embeddingText(movie))
+	}
+	vectors, err := s.embedTexts(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	embeddings := make([]models.MovieEmbedding, 0, len(movies))
+	for i, movie := range movies {
+		embeddings = append(embeddings, models.MovieEmbedding{
+			ImdbID:    movie.ImdbID,
+			Model:     s.embedder.Model(),
+			TextHash:  textHash(texts[i]),
+			Vector:    vectors[i],
+			UpdatedAt: now,
+		})
+	}
+	return embeddings, nil
+}
+
+// embedTexts embeds texts and checks that the embedder returned one vector for each.
+func (s *semanticService) embedTexts(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors, err := s.embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(vectors), len(texts))
+	}
+	return vectors, nil
+}
+
+// embeddingText is the description of a movie that gets embedded.
+func embeddingText(movie models.Movie) string {
+	genres := make([]string, 0, len(movie.Genre))
+	for _, genre := range movie.Genre {
+		genres = append(genres, genre.GenreName)
+	}
+	parts := []string{movie.Title}
+	if len(genres) > 0 {
+		parts = append(parts, "Genres: "+strings.Join(genres, ", "))
+	}
+	if movie.Synopsis != "" {
+		parts = append(parts, movie.Synopsis)
+	}
+	if len(movie.Keywords) > 0 {
+		parts = append(parts, "Keywords: "+strings.Join(movie.Keywords, ", "))
+	}
+	if len(movie.MoodTags) > 0 {
+		parts = append(parts, "Mood: "+strings.Join(movie.MoodTags, ", "))
+	}
+	if len(movie.CreditNames) > 0 {
+		parts = append(parts, "Cast and crew: "+strings.Join(movie.CreditNames, ", "))
+	}
+	if movie.AdminReview != "" {
+		parts = append(parts, movie.AdminReview)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
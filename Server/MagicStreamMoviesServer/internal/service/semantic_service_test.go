@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/embedding"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/search"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSemanticService_SyncOnlyEmbedsChangedMovies(t *testing.T) {
+	embeddingRepo := new(mocks.MockEmbeddingRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	embedder := embedding.NewHashEmbedder(32)
+	vectors := search.NewVectorIndex()
+	svc := service.NewSemanticService(embedder, embeddingRepo, movieRepo, vectors)
+
+	unchanged := models.Movie{ImdbID: "tt1", Title: "Primer"}
+	changed := models.Movie{ImdbID: "tt2", Title: "Looper"}
+	movieRepo.On("GetMovies", mock.Anything).Return([]models.Movie{unchanged, changed}, nil)
+
+	// Embed tt1 through IndexMovie so the stored hash matches its current text.
+	var stored []models.MovieEmbedding
+	embeddingRepo.On("UpsertEmbeddings", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = append(stored, args.Get(1).([]models.MovieEmbedding)...)
+	}).Return(nil)
+	assert.NoError(t, svc.IndexMovie(context.Background(), unchanged))
+	embeddingRepo.On("GetEmbeddings", mock.Anything, "hash-32").Return([]models.MovieEmbedding{
+		stored[0],
+		{ImdbID: "tt2", Model: "hash-32", TextHash: "outdated", Vector: make([]float32, 32)},
+	}, nil)
+
+	err := svc.Sync(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, stored, 2)
+	assert.Equal(t, "tt2", stored[1].ImdbID)
+	assert.Equal(t, 2, vectors.Len())
+}
+
+func TestSemanticService_Search(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	embedder := embedding.NewHashEmbedder(64)
+	vectors := search.NewVectorIndex()
+	svc := service.NewSemanticService(embedder, nil, movieRepo, vectors)
+
+	texts, _ := embedder.Embed(context.Background(), []string{"time travel loop", "romantic comedy"})
+	vectors.Upsert("tt1", texts[0])
+	vectors.Upsert("tt2", texts[1])
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt1"}).Return([]models.Movie{{ImdbID: "tt1", Title: "Primer"}}, nil)
+
+	results, err := svc.Search(context.Background(), "a time loop", 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Primer", results[0].Title)
+}
+
+// shortEmbedder drops the last vector, like a provider returning a truncated response.
+type shortEmbedder struct{ embedding.Embedder }
+
+func (e shortEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors, err := e.Embedder.Embed(ctx, texts)
+	return vectors[:len(vectors)-1], err
+}
+
+func TestSemanticService_RejectsMissingVectors(t *testing.T) {
+	embeddingRepo := new(mocks.MockEmbeddingRepository)
+	svc := service.NewSemanticService(shortEmbedder{embedding.NewHashEmbedder(32)}, embeddingRepo, nil, search.NewVectorIndex())
+
+	_, err := svc.Search(context.Background(), "a time loop", 1)
+	assert.Error(t, err)
+
+	err = svc.IndexMovie(context.Background(), models.Movie{ImdbID: "tt1", Title: "Primer"})
+	assert.Error(t, err)
+	embeddingRepo.AssertNotCalled(t, "UpsertEmbeddings", mock.Anything, mock.Anything)
+}
+
+func TestSemanticService_Unconfigured(t *testing.T) {
+	svc := service.NewSemanticService(nil, nil, nil, search.NewVectorIndex())
+
+	_, err := svc.Search(context.Background(), "a time loop", 1)
+
+	assert.ErrorIs(t, err, service.ErrSemanticSearchUnavailable)
+	assert.NoError(t, svc.IndexMovie(context.Background(), models.Movie{ImdbID: "tt1"}))
+	assert.NoError(t, svc.Sync(context.Background()))
+}
@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrNotSeries        = errors.New("title is not a series")
+	ErrSeasonNotFound   = errors.New("season not found")
+	ErrEpisodeNotFound  = errors.New("episode not found")
+	ErrSeriesFinished   = errors.New("no episode left to watch")
+	ErrWatchSeriesTitle = errors.New("series progress is recorded per episode")
+	ErrEpisodeIDTaken   = errors.New("imdb_id is already used by another title or episode")
+)
+
+type SeriesService interface {
+	GetSeries(ctx context.Context, imdbID string) (*models.SeriesDetail, error)
+	GetSeason(ctx context.Context, imdbID string, seasonNumber int) (*models.SeasonDetail, error)
+	GetEpisode(ctx context.Context, imdbID string, seasonNumber int, episodeNumber int) (*models.Episode, error)
+	SaveSeason(ctx context.Context, season models.Season) (*models.Season, error)
+	// DeleteSeason removes the season together with its episodes.
+	DeleteSeason(ctx context.Context, imdbID string, seasonNumber int) error
+	// SaveEpisode creates or replaces an episode of an existing season. A self-hosted stream
+	// source must name media in the blob store.
+	SaveEpisode(ctx context.Context, episode models.Episode) (*models.Episode, error)
+	DeleteEpisode(ctx context.Context, imdbID string, seasonNumber int, episodeNumber int) error
+
+	// RecordEpisodeWatch saves the user's progress in an episode and moves the series' watch
+	// history entry on to the episode to play next.
+	RecordEpisodeWatch(ctx context.Context, progress models.EpisodeProgress) error
+	// GetNextEpisode returns the episode to resume or start, or ErrSeriesFinished once the user
+	// has watched every episode.
+	GetNextEpisode(ctx context.Context, userId string, imdbID string) (*models.NextEpisode, error)
+}
+
+type seriesService struct {
+	seriesRepo   repository.SeriesRepository
+	movieRepo    repository.MovieRepository
+	activityRepo repository.ActivityRepository
+	eventRepo    repository.EventRepository
+	store        storage.BlobStore
+	streams      StreamService
+	indexers     []MovieIndexer
+}
+
+func NewSeriesService(seriesRepo repository.SeriesRepository, movieRepo repository.MovieRepository, activityRepo repository.ActivityRepository, eventRepo repository.EventRepository, store storage.BlobStore, streams StreamService, indexers []MovieIndexer) SeriesService {
+	return &seriesService{
+		seriesRepo:   seriesRepo,
+		movieRepo:    movieRepo,
+		activityRepo: activityRepo,
+		eventRepo:    eventRepo,
+		store:        store,
+		streams:      streams,
+		indexers:     indexers,
+	}
+}
+
+func (s *seriesService) GetSeries(ctx context.Context, imdbID string) (*models.SeriesDetail, error) {
+	series, err := s.getSeries(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	seasons, err := s.seriesRepo.GetSeasons(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SeriesDetail{Movie: *series, Seasons: seasons}, nil
+}
+
+func (s *seriesService) GetSeason(ctx context.Context, imdbID string, seasonNumber int) (*models.SeasonDetail, error) {
+	if _, err := s.getSeries(ctx, imdbID); err != nil {
+		return nil, err
+	}
+	season, err := s.getSeason(ctx, imdbID, seasonNumber)
+	if err != nil {
+		return nil, err
+	}
+	episodes, err := s.seriesRepo.GetEpisodes(ctx, imdbID, seasonNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SeasonDetail{Season: *season, Episodes: episodes}, nil
+}
+
+func (s *seriesService) GetEpisode(ctx context.Context, imdbID string, seasonNumber int, episodeNumber int) (*models.Episode, error) {
+	if _, err := s.getSeries(ctx, imdbID); err != nil {
+		return nil, err
+	}
+	return s.getEpisode(ctx, imdbID, seasonNumber, episodeNumber)
+}
+
+func (s *seriesService) SaveSeason(ctx context.Context, season models.Season) (*models.Season, error) {
+	if _, err := s.getSeries(ctx, season.SeriesID); err != nil {
+		return nil, err
+	}
+	return s.seriesRepo.UpsertSeason(ctx, season)
+}
+
+func (s *seriesService) DeleteSeason(ctx context.Context, imdbID string, seasonNumber int) error {
+	if _, err := s.getSeries(ctx, imdbID); err != nil {
+		return err
+	}
+	if err := s.seriesRepo.DeleteSeason(ctx, imdbID, seasonNumber); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrSeasonNotFound
+		}
+		return err
+	}
+	s.refreshEpisodeTitles(ctx, imdbID)
+	return nil
+}
+
+func (s *seriesService) SaveEpisode(ctx context.Context, episode models.Episode) (*models.Episode, error) {
+	if _, err := s.getSeries(ctx, episode.SeriesID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getSeason(ctx, episode.SeriesID, episode.SeasonNumber); err != nil {
+		return nil, err
+	}
+	if episode.Stream != nil {
+		stream, err := checkStreamSource(ctx, s.store, *episode.Stream)
+		if err != nil {
+			return nil, err
+		}
+		episode.Stream = stream
+	}
+	if episode.Stream == nil && episode.YouTubeID == "" {
+		return nil, fmt.Errorf("%w: an episode without a self-hosted stream needs a youtube_id", ErrInvalidStreamSource)
+	}
+	if err := s.checkEpisodeID(ctx, episode); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.seriesRepo.UpsertEpisode(ctx, episode)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrEpisodeIDTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if episode.ImdbID != "" {
+		s.streams.Invalidate(episode.ImdbID)
+	}
+	s.refreshEpisodeTitles(ctx, episode.SeriesID)
+	return saved, nil
+}
+
+func (s *seriesService) DeleteEpisode(ctx context.Context, imdbID string, seasonNumber int, episodeNumber int) error {
+	if _, err := s.getSeries(ctx, imdbID); err != nil {
+		return err
+	}
+	if err := s.seriesRepo.DeleteEpisode(ctx, imdbID, seasonNumber, episodeNumber); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrEpisodeNotFound
+		}
+		return err
+	}
+	s.refreshEpisodeTitles(ctx, imdbID)
+	return nil
+}
+
+func (s *seriesService) RecordEpisodeWatch(ctx context.Context, progress models.EpisodeProgress) error {
+	if _, err := s.getSeries(ctx, progress.SeriesID); err != nil {
+		return err
+	}
+	if _, err := s.getEpisode(ctx, progress.SeriesID, progress.SeasonNumber, progress.EpisodeNumber); err != nil {
+		return err
+	}
+	progress.WatchedAt = time.Now()
+	if err := s.seriesRepo.UpsertEpisodeProgress(ctx, progress); err != nil {
+		return err
+	}
+
+	episodes, err := s.seriesRepo.GetSeriesEpisodes(ctx, progress.SeriesID)
+	if err != nil {
+		return err
+	}
+	// The series itself gets a watch history entry too, so recommendations, similar titles and
+	// the continue watching rail treat it like any other title.
+	entry := models.WatchHistory{
+		UserID:    progress.UserID,
+		ImdbID:    progress.SeriesID,
+		WatchedAt: progress.WatchedAt,
+	}
+	if next := nextEpisode(episodes, &progress); next != nil {
+		entry.SeasonNumber = next.Episode.SeasonNumber
+		entry.EpisodeNumber = next.Episode.EpisodeNumber
+		entry.ProgressSeconds = next.ProgressSeconds
+	} else {
+		entry.Completed = true
+	}
+	if err := s.activityRepo.UpsertWatchHistory(ctx, entry); err != nil {
+		return err
+	}
+
+	recordEvent(ctx, s.eventRepo, progress.UserID, progress.SeriesID, models.EventPlay)
+	return nil
+}
+
+func (s *seriesService) GetNextEpisode(ctx context.Context, userId string, imdbID string) (*models.NextEpisode, error) {
+	if _, err := s.getSeries(ctx, imdbID); err != nil {
+		return nil, err
+	}
+	latest, err := s.seriesRepo.GetLatestEpisodeProgress(ctx, userId, imdbID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		latest, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	episodes, err := s.seriesRepo.GetSeriesEpisodes(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := nextEpisode(episodes, latest)
+	if next == nil {
+		return nil, ErrSeriesFinished
+	}
+	return next, nil
+}
+
+// nextEpisode picks the episode to play after latest, the user's most recent progress, in
+// viewing order: regular seasons first, specials (season 0) last. An unfinished episode is
+// resumed; otherwise the one following it is started. It returns nil when nothing follows.
+func nextEpisode(episodes []models.Episode, latest *models.EpisodeProgress) *models.NextEpisode {
+	ordered := make([]models.Episode, len(episodes))
+	copy(ordered, episodes)
+	sortByViewingOrder(ordered)
+
+	if len(ordered) == 0 {
+		return nil
+	}
+	if latest == nil {
+		return &models.NextEpisode{Episode: ordered[0], Reason: models.NextEpisodeStart}
+	}
+
+	position := viewingOrder(latest.SeasonNumber, latest.EpisodeNumber)
+	for _, episode := range ordered {
+		order := viewingOrder(episode.SeasonNumber, episode.EpisodeNumber)
+		if order == position && !latest.Completed {
+			return &models.NextEpisode{Episode: episode, ProgressSeconds: latest.ProgressSeconds, Reason: models.NextEpisodeResume}
+		}
+		if order.after(position) {
+			return &models.NextEpisode{Episode: episode, Reason: models.NextEpisodeNext}
+		}
+	}
+	return nil
+}
+
+type episodeOrder struct {
+	season  int
+	episode int
+}
+
+func viewingOrder(seasonNumber int, episodeNumber int) episodeOrder {
+	if seasonNumber == 0 {
+		seasonNumber = math.MaxInt
+	}
+	return episodeOrder{season: seasonNumber, episode: episodeNumber}
+}
+
+func (o episodeOrder) after(other episodeOrder) bool {
+	if o.season != other.season {
+		return o.season > other.season
+	}
+	return o.episode > other.episode
+}
+
+func sortByViewingOrder(episodes []models.Episode) {
+	slices.SortStableFunc(episodes, func(a, b models.Episode) int {
+		x := viewingOrder(a.SeasonNumber, a.EpisodeNumber)
+		y := viewingOrder(b.SeasonNumber, b.EpisodeNumber)
+		switch {
+		case x.after(y):
+			return 1
+		case y.after(x):
+			return -1
+		}
+		return 0
+	})
+}
+
+// refreshEpisodeTitles copies the episode titles onto the series and reindexes it. The episodes
+// themselves are already saved, so failures are only logged.
+func (s *seriesService) refreshEpisodeTitles(ctx context.Context, imdbID string) {
+	episodes, err := s.seriesRepo.GetSeriesEpisodes(ctx, imdbID)
+	if err != nil {
+		log.Printf("failed to load episodes of %s: %v", imdbID, err)
+		return
+	}
+	titles := make([]string, 0, len(episodes))
+	for _, episode := range episodes {
+		titles = append(titles, episode.Title)
+	}
+	if err := s.movieRepo.SetMovieEpisodeTitles(ctx, imdbID, titles); err != nil {
+		log.Printf("failed to update episode titles of %s: %v", imdbID, err)
+		return
+	}
+
+	series, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		log.Printf("failed to reload series %s: %v", imdbID, err)
+		return
+	}
+	indexMovies(ctx, s.indexers, *series)
+}
+
+// checkEpisodeID checks that the episode's own IMDb ID names no title and no other episode, as
+// streams and signed URLs are looked up by it.
+func (s *seriesService) checkEpisodeID(ctx context.Context, episode models.Episode) error {
+	if episode.ImdbID == "" {
+		return nil
+	}
+	if _, err := s.movieRepo.GetMovie(ctx, episode.ImdbID); err == nil {
+		return ErrEpisodeIDTaken
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	other, err := s.seriesRepo.GetEpisodeByImdbID(ctx, episode.ImdbID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if other.SeriesID != episode.SeriesID || other.SeasonNumber != episode.SeasonNumber || other.EpisodeNumber != episode.EpisodeNumber {
+		return ErrEpisodeIDTaken
+	}
+	return nil
+}
+
+// getSeries loads the title and checks that it is a series.
+func (s *seriesService) getSeries(ctx context.Context, imdbID string) (*models.Movie, error) {
+	series, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if !series.IsSeries() {
+		return nil, ErrNotSeries
+	}
+	return series, nil
+}
+
+func (s *seriesService) getSeason(ctx context.Context, imdbID string, seasonNumber int) (*models.Season, error) {
+	season, err := s.seriesRepo.GetSeason(ctx, imdbID, seasonNumber)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrSeasonNotFound
+	}
+	return season, err
+}
+
+func (s *seriesService) getEpisode(ctx context.Context, imdbID string, seasonNumber int, episodeNumber int) (*models.Episode, error) {
+	episode, err := s.seriesRepo.GetEpisode(ctx, imdbID, seasonNumber, episodeNumber)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrEpisodeNotFound
+	}
+	return episode, err
+}
@@ -0,0 +1,223 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var seriesEpisodes = []models.Episode{
+	{SeriesID: "tt0903747", SeasonNumber: 0, EpisodeNumber: 1, Title: "Good Cop Bad Cop"},
+	{SeriesID: "tt0903747", SeasonNumber: 1, EpisodeNumber: 1, Title: "Pilot"},
+	{SeriesID: "tt0903747", SeasonNumber: 1, EpisodeNumber: 2, Title: "Cat's in the Bag..."},
+	{SeriesID: "tt0903747", SeasonNumber: 2, EpisodeNumber: 1, Title: "Seven Thirty-Seven"},
+}
+
+func newSeriesMocks() (*mocks.MockSeriesRepository, *mocks.MockMovieRepository) {
+	seriesRepo := new(mocks.MockSeriesRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	movieRepo.On("GetMovie", mock.Anything, "tt0903747").
+		Return(&models.Movie{ImdbID: "tt0903747", Title: "Breaking Bad", ContentType: models.ContentTypeSeries}, nil)
+	seriesRepo.On("GetSeriesEpisodes", mock.Anything, "tt0903747").Return(seriesEpisodes, nil)
+	return seriesRepo, movieRepo
+}
+
+func TestSeriesService_GetNextEpisode(t *testing.T) {
+	tests := []struct {
+		name    string
+		latest  *models.EpisodeProgress
+		season  int
+		episode int
+		reason  string
+	}{
+		{"NothingWatched", nil, 1, 1, models.NextEpisodeStart},
+		{"Unfinished", &models.EpisodeProgress{SeasonNumber: 1, EpisodeNumber: 2, ProgressSeconds: 300}, 1, 2, models.NextEpisodeResume},
+		{"SeasonFinale", &models.EpisodeProgress{SeasonNumber: 1, EpisodeNumber: 2, Completed: true}, 2, 1, models.NextEpisodeNext},
+		{"SpecialsAfterRegularSeasons", &models.EpisodeProgress{SeasonNumber: 2, EpisodeNumber: 1, Completed: true}, 0, 1, models.NextEpisodeNext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seriesRepo, movieRepo := newSeriesMocks()
+			svc := service.NewSeriesService(seriesRepo, movieRepo, nil, nil, nil, nil, nil)
+			if tt.latest == nil {
+				seriesRepo.On("GetLatestEpisodeProgress", mock.Anything, "u1", "tt0903747").Return(nil, mongo.ErrNoDocuments)
+			} else {
+				seriesRepo.On("GetLatestEpisodeProgress", mock.Anything, "u1", "tt0903747").Return(tt.latest, nil)
+			}
+
+			next, err := svc.GetNextEpisode(context.Background(), "u1", "tt0903747")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.season, next.Episode.SeasonNumber)
+			assert.Equal(t, tt.episode, next.Episode.EpisodeNumber)
+			assert.Equal(t, tt.reason, next.Reason)
+			if tt.reason == models.NextEpisodeResume {
+				assert.Equal(t, 300, next.ProgressSeconds)
+			}
+		})
+	}
+}
+
+func TestSeriesService_GetNextEpisodeWhenFinished(t *testing.T) {
+	seriesRepo, movieRepo := newSeriesMocks()
+	svc := service.NewSeriesService(seriesRepo, movieRepo, nil, nil, nil, nil, nil)
+	seriesRepo.On("GetLatestEpisodeProgress", mock.Anything, "u1", "tt0903747").
+		Return(&models.EpisodeProgress{SeasonNumber: 0, EpisodeNumber: 1, Completed: true}, nil)
+
+	_, err := svc.GetNextEpisode(context.Background(), "u1", "tt0903747")
+
+	assert.True(t, errors.Is(err, service.ErrSeriesFinished))
+}
+
+func TestSeriesService_RejectsMovies(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewSeriesService(nil, movieRepo, nil, nil, nil, nil, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt1375666").Return(&models.Movie{ImdbID: "tt1375666", ContentType: models.ContentTypeMovie}, nil)
+
+	_, err := svc.GetSeries(context.Background(), "tt1375666")
+
+	assert.True(t, errors.Is(err, service.ErrNotSeries))
+}
+
+func TestSeriesService_RecordEpisodeWatchAdvancesSeries(t *testing.T) {
+	tests := []struct {
+		name         string
+		progress     models.EpisodeProgress
+		expectSeries func(models.WatchHistory) bool
+	}{
+		{
+			name:     "InProgress",
+			progress: models.EpisodeProgress{SeasonNumber: 1, EpisodeNumber: 1, ProgressSeconds: 90},
+			expectSeries: func(e models.WatchHistory) bool {
+				return e.SeasonNumber == 1 && e.EpisodeNumber == 1 && e.ProgressSeconds == 90 && !e.Completed
+			},
+		},
+		{
+			name:     "Completed",
+			progress: models.EpisodeProgress{SeasonNumber: 1, EpisodeNumber: 1, Completed: true},
+			expectSeries: func(e models.WatchHistory) bool {
+				return e.SeasonNumber == 1 && e.EpisodeNumber == 2 && e.ProgressSeconds == 0 && !e.Completed
+			},
+		},
+		{
+			name:     "LastEpisode",
+			progress: models.EpisodeProgress{SeasonNumber: 0, EpisodeNumber: 1, Completed: true},
+			expectSeries: func(e models.WatchHistory) bool {
+				return e.Completed && e.EpisodeNumber == 0
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seriesRepo, movieRepo := newSeriesMocks()
+			activityRepo := new(mocks.MockActivityRepository)
+			svc := service.NewSeriesService(seriesRepo, movieRepo, activityRepo, nil, nil, nil, nil)
+
+			progress := tt.progress
+			progress.UserID = "u1"
+			progress.SeriesID = "tt0903747"
+			seriesRepo.On("GetEpisode", mock.Anything, "tt0903747", progress.SeasonNumber, progress.EpisodeNumber).
+				Return(&models.Episode{SeasonNumber: progress.SeasonNumber, EpisodeNumber: progress.EpisodeNumber}, nil)
+			seriesRepo.On("UpsertEpisodeProgress", mock.Anything, mock.Anything).Return(nil)
+			activityRepo.On("UpsertWatchHistory", mock.Anything, mock.MatchedBy(func(e models.WatchHistory) bool {
+				return e.UserID == "u1" && e.ImdbID == "tt0903747" && tt.expectSeries(e)
+			})).Return(nil)
+
+			err := svc.RecordEpisodeWatch(context.Background(), progress)
+
+			assert.NoError(t, err)
+			activityRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSeriesService_RecordEpisodeWatchUnknownEpisode(t *testing.T) {
+	seriesRepo, movieRepo := newSeriesMocks()
+	svc := service.NewSeriesService(seriesRepo, movieRepo, nil, nil, nil, nil, nil)
+	seriesRepo.On("GetEpisode", mock.Anything, "tt0903747", 9, 1).Return(nil, mongo.ErrNoDocuments)
+
+	err := svc.RecordEpisodeWatch(context.Background(), models.EpisodeProgress{SeriesID: "tt0903747", SeasonNumber: 9, EpisodeNumber: 1})
+
+	assert.True(t, errors.Is(err, service.ErrEpisodeNotFound))
+	seriesRepo.AssertNotCalled(t, "UpsertEpisodeProgress", mock.Anything, mock.Anything)
+}
+
+func TestSeriesService_SaveEpisodeReindexesSeries(t *testing.T) {
+	seriesRepo, movieRepo := newSeriesMocks()
+	indexer := new(mocks.MockSearchService)
+	svc := service.NewSeriesService(seriesRepo, movieRepo, nil, nil, nil, nil, []service.MovieIndexer{indexer})
+
+	episode := models.Episode{SeriesID: "tt0903747", SeasonNumber: 1, EpisodeNumber: 1, Title: "Pilot", Runtime: 58, YouTubeID: "HhesaQXLuRY"}
+	seriesRepo.On("GetSeason", mock.Anything, "tt0903747", 1).Return(&models.Season{SeriesID: "tt0903747", SeasonNumber: 1}, nil)
+	seriesRepo.On("UpsertEpisode", mock.Anything, episode).Return(&episode, nil)
+	movieRepo.On("SetMovieEpisodeTitles", mock.Anything, "tt0903747",
+		[]string{"Good Cop Bad Cop", "Pilot", "Cat's in the Bag...", "Seven Thirty-Seven"}).Return(nil)
+	indexer.On("IndexMovie", mock.Anything, mock.MatchedBy(func(m models.Movie) bool { return m.ImdbID == "tt0903747" })).Return(nil)
+
+	saved, err := svc.SaveEpisode(context.Background(), episode)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Pilot", saved.Title)
+	movieRepo.AssertExpectations(t)
+	indexer.AssertExpectations(t)
+}
+
+func TestSeriesService_SaveEpisodeNeedsSeason(t *testing.T) {
+	seriesRepo, movieRepo := newSeriesMocks()
+	svc := service.NewSeriesService(seriesRepo, movieRepo, nil, nil, nil, nil, nil)
+	seriesRepo.On("GetSeason", mock.Anything, "tt0903747", 3).Return(nil, mongo.ErrNoDocuments)
+
+	_, err := svc.SaveEpisode(context.Background(), models.Episode{SeriesID: "tt0903747", SeasonNumber: 3, EpisodeNumber: 1})
+
+	assert.True(t, errors.Is(err, service.ErrSeasonNotFound))
+}
+
+func TestSeriesService_SaveEpisodeStream(t *testing.T) {
+	ctx := context.Background()
+	seriesRepo, movieRepo := newSeriesMocks()
+	store := newStreamStore(t)
+	streams := new(mocks.MockStreamService)
+	svc := service.NewSeriesService(seriesRepo, movieRepo, nil, nil, store, streams, nil)
+	streams.On("Invalidate", "tt0959621").Return()
+	seriesRepo.On("GetSeason", mock.Anything, "tt0903747", 1).Return(&models.Season{SeriesID: "tt0903747", SeasonNumber: 1}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1"}, nil)
+	movieRepo.On("GetMovie", mock.Anything, mock.Anything).Return(nil, mongo.ErrNoDocuments)
+	seriesRepo.On("GetEpisodeByImdbID", mock.Anything, "tt0959621").Return(nil, mongo.ErrNoDocuments)
+	seriesRepo.On("GetEpisodeByImdbID", mock.Anything, "tt1054724").
+		Return(&models.Episode{SeriesID: "tt0903747", SeasonNumber: 1, EpisodeNumber: 2, ImdbID: "tt1054724"}, nil)
+	seriesRepo.On("UpsertEpisode", mock.Anything, mock.Anything).Return(&models.Episode{}, nil)
+	movieRepo.On("SetMovieEpisodeTitles", mock.Anything, "tt0903747", mock.Anything).Return(nil)
+
+	pilot := models.Episode{SeriesID: "tt0903747", SeasonNumber: 1, EpisodeNumber: 1, ImdbID: "tt0959621", Title: "Pilot", Runtime: 58,
+		Stream: &models.StreamSource{Type: models.StreamHLS, Key: "media/tt2/hls/master.m3u8"}}
+	_, err := svc.SaveEpisode(ctx, pilot)
+	assert.NoError(t, err)
+	seriesRepo.AssertCalled(t, "UpsertEpisode", mock.Anything, pilot)
+	// Media players may have the episode's old source cached.
+	streams.AssertCalled(t, "Invalidate", "tt0959621")
+
+	missing := pilot
+	missing.Stream = &models.StreamSource{Type: models.StreamFile, Key: "media/tt0959621/missing.mp4"}
+	_, err = svc.SaveEpisode(ctx, missing)
+	assert.True(t, errors.Is(err, service.ErrInvalidStreamSource))
+
+	// Switching back to YouTube needs a youtube_id.
+	youTube := pilot
+	youTube.Stream = &models.StreamSource{Type: models.StreamYouTube}
+	_, err = svc.SaveEpisode(ctx, youTube)
+	assert.True(t, errors.Is(err, service.ErrInvalidStreamSource))
+
+	for _, imdbID := range []string{"tt1", "tt1054724"} {
+		taken := pilot
+		taken.ImdbID = imdbID
+		_, err = svc.SaveEpisode(ctx, taken)
+		assert.True(t, errors.Is(err, service.ErrEpisodeIDTaken), imdbID)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+)
+
+// SimilarityJob recomputes the item-item neighbours used by the collaborative recommender.
+// It is meant to be run periodically by the scheduler.
+type SimilarityJob struct {
+	activityRepo   repository.ActivityRepository
+	similarityRepo repository.SimilarityRepository
+	neighbors      int
+}
+
+func NewSimilarityJob(activityRepo repository.ActivityRepository, similarityRepo repository.SimilarityRepository, neighbors int) *SimilarityJob {
+	return &SimilarityJob{
+		activityRepo:   activityRepo,
+		similarityRepo: similarityRepo,
+		neighbors:      neighbors,
+	}
+}
+
+func (j *SimilarityJob) Run(ctx context.Context) error {
+	history, err := j.activityRepo.GetAllWatchHistory(ctx)
+	if err != nil {
+		return err
+	}
+	reviews, err := j.activityRepo.GetAllReviews(ctx)
+	if err != nil {
+		return err
+	}
+
+	computedAt := time.Now()
+	similarities := ComputeItemSimilarities(history, reviews, j.neighbors, computedAt)
+	return j.similarityRepo.ReplaceSimilarities(ctx, similarities, computedAt)
+}
+
+// ComputeItemSimilarities builds per-user preference vectors from watch history and ratings and
+// returns, for every movie, its k most cosine-similar movies.
+func ComputeItemSimilarities(history []models.WatchHistory, reviews []models.Review, k int, computedAt time.Time) []models.MovieSimilarity {
+	userHistory := make(map[string][]models.WatchHistory)
+	for _, entry := range history {
+		userHistory[entry.UserID] = append(userHistory[entry.UserID], entry)
+	}
+	userReviews := make(map[string][]models.Review)
+	for _, review := range reviews {
+		userReviews[review.UserID] = append(userReviews[review.UserID], review)
+	}
+	users := make(map[string]struct{})
+	for userId := range userHistory {
+		users[userId] = struct{}{}
+	}
+	for userId := range userReviews {
+		users[userId] = struct{}{}
+	}
+
+	norms := make(map[string]float64)
+	dots := make(map[string]map[string]float64)
+	for userId := range users {
+		prefs := interactionWeights(userHistory[userId], userReviews[userId])
+		for i, pi := range prefs {
+			norms[i] += pi * pi
+			for j, pj := range prefs {
+				if i == j {
+					continue
+				}
+				if dots[i] == nil {
+					dots[i] = make(map[string]float64)
+				}
+				dots[i][j] += pi * pj
+			}
+		}
+	}
+
+	similarities := make([]models.MovieSimilarity, 0, len(dots))
+	for i, row := range dots {
+		neighbors := make([]models.SimilarMovie, 0, len(row))
+		for j, dot := range row {
+			denom := math.Sqrt(norms[i]) * math.Sqrt(norms[j])
+			if denom == 0 {
+				continue
+			}
+			neighbors = append(neighbors, models.SimilarMovie{ImdbID: j, Score: dot / denom})
+		}
+		sort.Slice(neighbors, func(a, b int) bool {
+			if neighbors[a].Score != neighbors[b].Score {
+				return neighbors[a].Score > neighbors[b].Score
+			}
+			return neighbors[a].ImdbID < neighbors[b].ImdbID
+		})
+		if k > 0 && len(neighbors) > k {
+			neighbors = neighbors[:k]
+		}
+		similarities = append(similarities, models.MovieSimilarity{
+			ImdbID:     i,
+			Neighbors:  neighbors,
+			ComputedAt: computedAt,
+		})
+	}
+
+	sort.Slice(similarities, func(a, b int) bool {
+		return similarities[a].ImdbID < similarities[b].ImdbID
+	})
+	return similarities
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/cache"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/urlsign"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrNotSelfHosted       = errors.New("title plays from YouTube")
+	ErrStreamNotFound      = errors.New("stream not found")
+	ErrInvalidStreamSource = errors.New("invalid stream source")
+	ErrSigningUnavailable  = errors.New("signed stream URLs are not configured")
+)
+
+// StreamService plays movies and the episodes of series. Episodes are addressed by their own
+// imdb_id wherever a title's is expected.
+type StreamService interface {
+	// GetPlayback returns where the title plays from.
+	GetPlayback(ctx context.Context, imdbID string) (*models.StreamSource, error)
+	// OpenMedia opens a self-hosted title's media. An empty name opens the media file, or the
+	// master playlist for HLS; other names are playlists and segments next to the master
+	// playlist. The caller closes the blob.
+	OpenMedia(ctx context.Context, imdbID string, name string) (*storage.Blob, error)
+	// SetStreamSource points the title at media in the blob store, which must exist. A YouTube
+	// source removes the stream source.
+	SetStreamSource(ctx context.Context, imdbID string, source models.StreamSource) (*models.Movie, error)
+	// SignPlaybackURL issues a short-lived URL that lets the user play a self-hosted title
+	// without an Authorization header.
+	SignPlaybackURL(ctx context.Context, userID string, imdbID string) (*models.PlaybackURL, error)
+	// Invalidate drops the title's stream source that OpenMedia keeps for a short while. Writers
+	// that change a title's or an episode's stream source other than through SetStreamSource
+	// must call it after the write, and before removing the media the old source named.
+	Invalidate(imdbID string)
+}
+
+// streamSourceCacheTTL is how long OpenMedia reuses a title's stream source. An HLS player
+// fetches a segment every few seconds, and each would otherwise look the title up again.
+const streamSourceCacheTTL = 30 * time.Second
+
+type streamService struct {
+	movieRepo   repository.MovieRepository
+	seriesRepo  repository.SeriesRepository
+	store       storage.BlobStore
+	signer      *urlsign.Signer
+	publicURL   string
+	urlTTL      time.Duration
+	sourceCache *cache.TTLCache[string, models.StreamSource]
+}
+
+// NewStreamService builds the stream service. A nil signer turns signed URLs off.
+func NewStreamService(movieRepo repository.MovieRepository, seriesRepo repository.SeriesRepository, store storage.BlobStore, signer *urlsign.Signer, cfg *config.Config) StreamService {
+	return &streamService{
+		movieRepo:  movieRepo,
+		seriesRepo: seriesRepo,
+		store:      store,
+		signer:     signer,
+		publicURL:  cfg.PublicURL,
+		urlTTL:     cfg.StreamURLTTL,
+
+		sourceCache: cache.NewTTLCache[string, models.StreamSource](streamSourceCacheTTL),
+	}
+}
+
+func (s *streamService) GetPlayback(ctx context.Context, imdbID string) (*models.StreamSource, error) {
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Not a title, but maybe an episode. A missing episode reports the title as missing.
+		episode, episodeErr := s.seriesRepo.GetEpisodeByImdbID(ctx, imdbID)
+		if episodeErr == nil {
+			source := episode.Playback()
+			return &source, nil
+		}
+		if !errors.Is(episodeErr, mongo.ErrNoDocuments) {
+			return nil, episodeErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	source := movie.Playback()
+	return &source, nil
+}
+
+func (s *streamService) OpenMedia(ctx context.Context, imdbID string, name string) (*storage.Blob, error) {
+	source, ok := s.sourceCache.Get(imdbID)
+	if !ok {
+		playback, err := s.GetPlayback(ctx, imdbID)
+		if err != nil {
+			return nil, err
+		}
+		source = *playback
+		s.sourceCache.Set(imdbID, source)
+	}
+	if !source.SelfHosted() {
+		return nil, ErrNotSelfHosted
+	}
+
+	key := source.Key
+	if name != "" {
+		if source.Type != models.StreamHLS {
+			return nil, ErrStreamNotFound
+		}
+		// The name comes from the URL, so it must stay below the playlist's directory.
+		dir := path.Dir(source.Key)
+		key = path.Join(dir, name)
+		if storage.CheckKey(name) != nil || !strings.HasPrefix(key, dir+"/") {
+			return nil, ErrStreamNotFound
+		}
+	}
+
+	blob, err := s.store.Open(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return nil, ErrStreamNotFound
+	}
+	return blob, err
+}
+
+func (s *streamService) SetStreamSource(ctx context.Context, imdbID string, source models.StreamSource) (*models.Movie, error) {
+	if _, err := s.movieRepo.GetMovie(ctx, imdbID); err != nil {
+		return nil, err
+	}
+
+	stream, err := checkStreamSource(ctx, s.store, source)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.movieRepo.SetMovieStream(ctx, imdbID, stream); err != nil {
+		return nil, err
+	}
+	s.Invalidate(imdbID)
+	return s.movieRepo.GetMovie(ctx, imdbID)
+}
+
+func (s *streamService) Invalidate(imdbID string) {
+	s.sourceCache.Delete(imdbID)
+}
+
+func (s *streamService) SignPlaybackURL(ctx context.Context, userID string, imdbID string) (*models.PlaybackURL, error) {
+	if s.signer == nil {
+		return nil, ErrSigningUnavailable
+	}
+	source, err := s.GetPlayback(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+	if !source.SelfHosted() {
+		return nil, ErrNotSelfHosted
+	}
+
+	expires := time.Now().Add(s.urlTTL).Truncate(time.Second)
+	query := s.signer.Sign(userID, imdbID, expires)
+	return &models.PlaybackURL{
+		URL:       s.publicURL + "/stream/" + url.PathEscape(imdbID) + "?" + query.Encode(),
+		ExpiresAt: expires.UTC(),
+	}, nil
+}
+
+// checkStreamSource checks that a self-hosted source names media in the store and returns the
+// source to save, or nil for YouTube.
+func checkStreamSource(ctx context.Context, store storage.BlobStore, source models.StreamSource) (*models.StreamSource, error) {
+	if !source.SelfHosted() {
+		return nil, nil
+	}
+	if err := storage.CheckKey(source.Key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStreamSource, err)
+	}
+	// OpenMedia serves anything next to a master playlist, so one at the root of the store
+	// would serve the whole store.
+	if source.Type == models.StreamHLS && path.Dir(source.Key) == "." {
+		return nil, fmt.Errorf("%w: an HLS playlist must sit in a directory of its own", ErrInvalidStreamSource)
+	}
+	if _, err := store.Stat(ctx, source.Key); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no media stored under %q", ErrInvalidStreamSource, source.Key)
+		}
+		return nil, err
+	}
+	return &models.StreamSource{Type: source.Type, Key: source.Key}, nil
+}
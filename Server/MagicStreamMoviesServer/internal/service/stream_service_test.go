@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/urlsign"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func newStreamStore(t *testing.T) storage.BlobStore {
+	t.Helper()
+	ctx := context.Background()
+	store, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	files := map[string]string{
+		"media/tt1/movie.mp4":           "mp4 bytes",
+		"media/tt2/hls/master.m3u8":     "#EXTM3U",
+		"media/tt2/hls/720p/index.m3u8": "#EXTM3U",
+		"media/tt2/hls/720p/seg001.ts":  "ts bytes",
+		"media/tt2/secret.txt":          "outside the playlist directory",
+	}
+	for key, content := range files {
+		assert.NoError(t, store.Put(ctx, key, strings.NewReader(content), ""))
+	}
+	return store
+}
+
+func TestStreamService_OpenMedia(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewStreamService(movieRepo, nil, newStreamStore(t), nil, &config.Config{})
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", Stream: &models.StreamSource{Type: models.StreamFile, Key: "media/tt1/movie.mp4"}}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt2").Return(&models.Movie{ImdbID: "tt2", Stream: &models.StreamSource{Type: models.StreamHLS, Key: "media/tt2/hls/master.m3u8"}}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt3").Return(&models.Movie{ImdbID: "tt3", YouTubeID: "dQw4w9WgXcQ"}, nil)
+
+	read := func(imdbID string, name string) (string, error) {
+		blob, err := svc.OpenMedia(ctx, imdbID, name)
+		if err != nil {
+			return "", err
+		}
+		defer blob.Close()
+		data, err := io.ReadAll(blob)
+		return string(data), err
+	}
+
+	content, err := read("tt1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "mp4 bytes", content)
+
+	content, err = read("tt2", "720p/seg001.ts")
+	assert.NoError(t, err)
+	assert.Equal(t, "ts bytes", content)
+
+	blob, err := svc.OpenMedia(ctx, "tt2", "")
+	if assert.NoError(t, err) {
+		blob.Close()
+		assert.Equal(t, "application/vnd.apple.mpegurl", blob.ContentType)
+	}
+
+	for _, name := range []string{"../secret.txt", "720p/../../secret.txt", "720p/missing.ts"} {
+		_, err = read("tt2", name)
+		assert.True(t, errors.Is(err, service.ErrStreamNotFound), name)
+	}
+	_, err = read("tt1", "movie.mp4")
+	assert.True(t, errors.Is(err, service.ErrStreamNotFound))
+	_, err = read("tt3", "")
+	assert.True(t, errors.Is(err, service.ErrNotSelfHosted))
+
+	// Sources are cached, so the segments of a title don't look it up again.
+	movieRepo.AssertNumberOfCalls(t, "GetMovie", 3)
+}
+
+func TestStreamService_EpisodePlayback(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	seriesRepo := new(mocks.MockSeriesRepository)
+	svc := service.NewStreamService(movieRepo, seriesRepo, newStreamStore(t), nil, &config.Config{})
+
+	movieRepo.On("GetMovie", mock.Anything, mock.Anything).Return(nil, mongo.ErrNoDocuments)
+	seriesRepo.On("GetEpisodeByImdbID", mock.Anything, "tt0959621").
+		Return(&models.Episode{ImdbID: "tt0959621", Stream: &models.StreamSource{Type: models.StreamHLS, Key: "media/tt2/hls/master.m3u8"}}, nil)
+	seriesRepo.On("GetEpisodeByImdbID", mock.Anything, "tt404").Return(nil, mongo.ErrNoDocuments)
+
+	blob, err := svc.OpenMedia(ctx, "tt0959621", "720p/seg001.ts")
+	if assert.NoError(t, err) {
+		blob.Close()
+	}
+
+	_, err = svc.GetPlayback(ctx, "tt404")
+	assert.True(t, errors.Is(err, mongo.ErrNoDocuments))
+}
+
+func TestStreamService_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewStreamService(movieRepo, nil, newStreamStore(t), nil, &config.Config{})
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", Stream: &models.StreamSource{Type: models.StreamFile, Key: "media/tt1/movie.mp4"}}, nil).Once()
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", Stream: &models.StreamSource{Type: models.StreamHLS, Key: "media/tt2/hls/master.m3u8"}}, nil)
+
+	blob, err := svc.OpenMedia(ctx, "tt1", "")
+	if assert.NoError(t, err) {
+		blob.Close()
+	}
+
+	// The source was changed behind the service, which keeps playing the cached one until told.
+	_, err = svc.OpenMedia(ctx, "tt1", "720p/seg001.ts")
+	assert.True(t, errors.Is(err, service.ErrStreamNotFound))
+	svc.Invalidate("tt1")
+	blob, err = svc.OpenMedia(ctx, "tt1", "720p/seg001.ts")
+	if assert.NoError(t, err) {
+		blob.Close()
+	}
+}
+
+func TestStreamService_SetStreamSource(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	store := newStreamStore(t)
+	assert.NoError(t, store.Put(ctx, "master.m3u8", strings.NewReader("#EXTM3U"), ""))
+	svc := service.NewStreamService(movieRepo, nil, store, nil, &config.Config{})
+
+	movie := &models.Movie{ImdbID: "tt1"}
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(movie, nil)
+	source := &models.StreamSource{Type: models.StreamFile, Key: "media/tt1/movie.mp4"}
+	movieRepo.On("SetMovieStream", mock.Anything, "tt1", source).Return(nil).Once()
+	movieRepo.On("SetMovieStream", mock.Anything, "tt1", (*models.StreamSource)(nil)).Return(nil).Once()
+
+	_, err := svc.SetStreamSource(ctx, "tt1", *source)
+	assert.NoError(t, err)
+
+	// Switching back to YouTube drops the stored source.
+	_, err = svc.SetStreamSource(ctx, "tt1", models.StreamSource{Type: models.StreamYouTube, Key: "ignored"})
+	assert.NoError(t, err)
+
+	_, err = svc.SetStreamSource(ctx, "tt1", models.StreamSource{Type: models.StreamFile, Key: "media/tt1/missing.mp4"})
+	assert.True(t, errors.Is(err, service.ErrInvalidStreamSource))
+	_, err = svc.SetStreamSource(ctx, "tt1", models.StreamSource{Type: models.StreamHLS, Key: "../master.m3u8"})
+	assert.True(t, errors.Is(err, service.ErrInvalidStreamSource))
+	_, err = svc.SetStreamSource(ctx, "tt1", models.StreamSource{Type: models.StreamHLS, Key: "master.m3u8"})
+	assert.True(t, errors.Is(err, service.ErrInvalidStreamSource))
+
+	movieRepo.AssertExpectations(t)
+}
+
+func TestStreamService_SignPlaybackURL(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	signer := urlsign.NewSigner(urlsign.Key{ID: "k1", Secret: []byte(strings.Repeat("s", 32))})
+	cfg := &config.Config{PublicURL: "https://api.example.com", StreamURLTTL: time.Hour}
+	svc := service.NewStreamService(movieRepo, nil, newStreamStore(t), signer, cfg)
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", Stream: &models.StreamSource{Type: models.StreamFile, Key: "media/tt1/movie.mp4"}}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt3").Return(&models.Movie{ImdbID: "tt3", YouTubeID: "dQw4w9WgXcQ"}, nil)
+
+	playback, err := svc.SignPlaybackURL(ctx, "user123", "tt1")
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, playback) {
+		assert.WithinDuration(t, time.Now().Add(time.Hour), playback.ExpiresAt, 2*time.Second)
+		parsed, err := url.Parse(playback.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "api.example.com", parsed.Host)
+		assert.Equal(t, "/stream/tt1", parsed.Path)
+		userID, err := signer.Verify(parsed.Query(), "tt1", time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, "user123", userID)
+	}
+
+	_, err = svc.SignPlaybackURL(ctx, "user123", "tt3")
+	assert.True(t, errors.Is(err, service.ErrNotSelfHosted))
+
+	unsigned := service.NewStreamService(movieRepo, nil, newStreamStore(t), nil, cfg)
+	_, err = unsigned.SignPlaybackURL(ctx, "user123", "tt1")
+	assert.True(t, errors.Is(err, service.ErrSigningUnavailable))
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/subtitles"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"golang.org/x/text/language"
+)
+
+var (
+	// ErrInvalidSubtitles wraps the reason a file is neither valid SRT nor valid WebVTT.
+	ErrInvalidSubtitles      = subtitles.ErrInvalid
+	ErrSubtitlesTooLarge     = errors.New("subtitle file is too large")
+	ErrInvalidLanguage       = errors.New("language must be a BCP 47 tag such as en or pt-BR")
+	ErrSubtitleTrackNotFound = errors.New("subtitle track not found")
+)
+
+// subtitlesPrefix is the storage prefix of every subtitle track.
+const subtitlesPrefix = "subtitles"
+
+// webVTTContentType is the content type of stored tracks.
+const webVTTContentType = "text/vtt; charset=utf-8"
+
+type SubtitleService interface {
+	// SaveTrack converts an SRT or WebVTT file to WebVTT and stores it as the movie's track in
+	// the language, replacing the earlier track in that language.
+	SaveTrack(ctx context.Context, imdbID string, lang string, req models.SubtitleTrackRequest, r io.Reader) (*models.SubtitleTrack, error)
+	// UpdateTrack changes a track's label or default flag.
+	UpdateTrack(ctx context.Context, imdbID string, lang string, req models.SubtitleTrackRequest) (*models.SubtitleTrack, error)
+	// DeleteTrack removes a track and its file.
+	DeleteTrack(ctx context.Context, imdbID string, lang string) error
+	// OpenTrack opens the WebVTT file of a track. The caller closes it.
+	OpenTrack(ctx context.Context, imdbID string, lang string) (*storage.Blob, error)
+}
+
+type subtitleService struct {
+	movieRepo repository.MovieRepository
+	store     storage.BlobStore
+	publicURL string
+	maxBytes  int64
+}
+
+func NewSubtitleService(movieRepo repository.MovieRepository, store storage.BlobStore, cfg *config.Config) SubtitleService {
+	return &subtitleService{
+		movieRepo: movieRepo,
+		store:     store,
+		publicURL: cfg.PublicURL,
+		maxBytes:  cfg.SubtitleMaxBytes,
+	}
+}
+
+func (s *subtitleService) SaveTrack(ctx context.Context, imdbID string, lang string, req models.SubtitleTrackRequest, r io.Reader) (*models.SubtitleTrack, error) {
+	lang, err := canonicalLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, ErrSubtitlesTooLarge
+	}
+	vtt, err := subtitles.ToWebVTT(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys are named after the content, so a replaced track gets a new ETag.
+	sum := sha256.Sum256(vtt)
+	key := path.Join(subtitlesPrefix, imdbID, strings.ToLower(lang)+"-"+hex.EncodeToString(sum[:8])+".vtt")
+	if err := s.store.Put(ctx, key, bytes.NewReader(vtt), webVTTContentType); err != nil {
+		return nil, err
+	}
+
+	// The movie's earlier track keeps its label and default flag unless the request changes
+	// them; a new one is labelled with its language.
+	var previous string
+	if i := findTrack(movie.Subtitles, lang); i >= 0 {
+		previous = movie.Subtitles[i].Key
+	}
+	track := models.SubtitleTrack{Language: lang, Label: lang, Key: key, URL: s.trackURL(imdbID, lang), UpdatedAt: time.Now().UTC()}
+	replaced, err := s.movieRepo.SaveMovieSubtitleTrack(ctx, imdbID, track)
+	if err != nil {
+		if key != previous {
+			s.remove(ctx, key)
+		}
+		return nil, err
+	}
+	if replaced != nil && replaced.Key != key {
+		s.remove(ctx, replaced.Key)
+	}
+	if req.Label != nil || req.Default != nil {
+		if err := s.movieRepo.UpdateMovieSubtitleTrack(ctx, imdbID, lang, req.Label, req.Default); err != nil {
+			return nil, s.trackError(err)
+		}
+	}
+	return s.currentTrack(ctx, imdbID, lang)
+}
+
+func (s *subtitleService) UpdateTrack(ctx context.Context, imdbID string, lang string, req models.SubtitleTrackRequest) (*models.SubtitleTrack, error) {
+	movie, i, err := s.getTrack(ctx, imdbID, lang)
+	if err != nil {
+		return nil, err
+	}
+	lang = movie.Subtitles[i].Language
+	if err := s.movieRepo.UpdateMovieSubtitleTrack(ctx, imdbID, lang, req.Label, req.Default); err != nil {
+		return nil, s.trackError(err)
+	}
+	return s.currentTrack(ctx, imdbID, lang)
+}
+
+func (s *subtitleService) DeleteTrack(ctx context.Context, imdbID string, lang string) error {
+	movie, i, err := s.getTrack(ctx, imdbID, lang)
+	if err != nil {
+		return err
+	}
+	track, err := s.movieRepo.DeleteMovieSubtitleTrack(ctx, imdbID, movie.Subtitles[i].Language)
+	if err != nil {
+		return s.trackError(err)
+	}
+	s.remove(ctx, track.Key)
+	return nil
+}
+
+func (s *subtitleService) OpenTrack(ctx context.Context, imdbID string, lang string) (*storage.Blob, error) {
+	movie, i, err := s.getTrack(ctx, imdbID, lang)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := s.store.Open(ctx, movie.Subtitles[i].Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrSubtitleTrackNotFound
+	}
+	return blob, err
+}
+
+// getTrack returns the movie with the index of its track in the language.
+func (s *subtitleService) getTrack(ctx context.Context, imdbID string, lang string) (*models.Movie, int, error) {
+	lang, err := canonicalLanguage(lang)
+	if err != nil {
+		return nil, 0, err
+	}
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, 0, err
+	}
+	i := findTrack(movie.Subtitles, lang)
+	if i < 0 {
+		return nil, 0, ErrSubtitleTrackNotFound
+	}
+	return movie, i, nil
+}
+
+// currentTrack reads a track back after a change.
+func (s *subtitleService) currentTrack(ctx context.Context, imdbID string, lang string) (*models.SubtitleTrack, error) {
+	movie, i, err := s.getTrack(ctx, imdbID, lang)
+	if err != nil {
+		return nil, err
+	}
+	return &movie.Subtitles[i], nil
+}
+
+// trackError reports a track that went away between reading the movie and changing it as not
+// found.
+func (s *subtitleService) trackError(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrSubtitleTrackNotFound
+	}
+	return err
+}
+
+func (s *subtitleService) trackURL(imdbID string, lang string) string {
+	return s.publicURL + "/movie/" + imdbID + "/subtitles/" + lang + ".vtt"
+}
+
+// remove deletes a track's file. The movie no longer refers to it, so failures are only logged.
+func (s *subtitleService) remove(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("failed to remove subtitles %s: %v", key, err)
+	}
+}
+
+// canonicalLanguage checks a BCP 47 tag and returns its canonical form, so "pt-br" and "pt-BR"
+// name the same track.
+func canonicalLanguage(lang string) (string, error) {
+	tag, err := language.Parse(lang)
+	if err != nil || tag == language.Und {
+		return "", ErrInvalidLanguage
+	}
+	return tag.String(), nil
+}
+
+func findTrack(tracks []models.SubtitleTrack, lang string) int {
+	for i, track := range tracks {
+		if strings.EqualFold(track.Language, lang) {
+			return i
+		}
+	}
+	return -1
+}
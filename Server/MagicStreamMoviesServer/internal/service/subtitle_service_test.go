@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const testSRT = "1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\n"
+
+func newSubtitleService(t *testing.T, movieRepo *mocks.MockMovieRepository) (service.SubtitleService, storage.BlobStore) {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	cfg := &config.Config{PublicURL: "https://api.example.com", SubtitleMaxBytes: 1 << 10}
+	return service.NewSubtitleService(movieRepo, store, cfg), store
+}
+
+func TestSubtitleService_SaveTrackConvertsSRT(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	svc, store := newSubtitleService(t, movieRepo)
+
+	old := "subtitles/tt2543164/pt-br-0000000000000000.vtt"
+	assert.NoError(t, store.Put(ctx, old, strings.NewReader("WEBVTT\n"), "text/vtt"))
+	before := models.SubtitleTrack{Language: "pt-BR", Label: "Português", Key: old}
+	movie := &models.Movie{ImdbID: "tt2543164", Subtitles: []models.SubtitleTrack{
+		{Language: "en", Label: "English", Default: true, Key: "subtitles/tt2543164/en-1111111111111111.vtt"},
+		before,
+	}}
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(movie, nil)
+	movieRepo.On("SaveMovieSubtitleTrack", mock.Anything, "tt2543164", mock.Anything).Run(func(args mock.Arguments) {
+		track := args.Get(2).(models.SubtitleTrack)
+		movie.Subtitles[1].Key, movie.Subtitles[1].URL = track.Key, track.URL
+	}).Return(&before, nil)
+	isDefault := true
+	movieRepo.On("UpdateMovieSubtitleTrack", mock.Anything, "tt2543164", "pt-BR", (*string)(nil), &isDefault).Run(func(mock.Arguments) {
+		movie.Subtitles[0].Default, movie.Subtitles[1].Default = false, true
+	}).Return(nil)
+
+	track, err := svc.SaveTrack(ctx, "tt2543164", "pt-br", models.SubtitleTrackRequest{Default: &isDefault}, strings.NewReader(testSRT))
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, track) {
+		assert.Equal(t, "pt-BR", track.Language)
+		assert.Equal(t, "Português", track.Label)
+		assert.True(t, track.Default)
+		assert.Equal(t, "https://api.example.com/movie/tt2543164/subtitles/pt-BR.vtt", track.URL)
+		assert.True(t, strings.HasPrefix(track.Key, "subtitles/tt2543164/pt-br-"))
+
+		saved := movieRepo.Calls[1].Arguments.Get(2).(models.SubtitleTrack)
+		assert.Equal(t, models.SubtitleTrack{Language: "pt-BR", Label: "pt-BR", Key: track.Key, URL: track.URL, UpdatedAt: saved.UpdatedAt}, saved)
+
+		blob, err := store.Open(ctx, track.Key)
+		if assert.NoError(t, err) {
+			data, _ := io.ReadAll(blob)
+			blob.Close()
+			assert.Equal(t, "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.500\nHello\n", string(data))
+		}
+	}
+	_, err = store.Stat(ctx, old)
+	assert.True(t, errors.Is(err, storage.ErrNotFound))
+}
+
+func TestSubtitleService_SaveTrackRejectsBadFiles(t *testing.T) {
+	movieRepo := new(mocks.MockMovieRepository)
+	svc, _ := newSubtitleService(t, movieRepo)
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(&models.Movie{ImdbID: "tt2543164"}, nil)
+
+	_, err := svc.SaveTrack(context.Background(), "tt2543164", "en", models.SubtitleTrackRequest{}, strings.NewReader("1\n00:00:05,000 --> 00:00:04,000\nHi\n"))
+	assert.True(t, errors.Is(err, service.ErrInvalidSubtitles))
+
+	_, err = svc.SaveTrack(context.Background(), "tt2543164", "en", models.SubtitleTrackRequest{}, strings.NewReader(strings.Repeat(testSRT, 100)))
+	assert.True(t, errors.Is(err, service.ErrSubtitlesTooLarge))
+
+	_, err = svc.SaveTrack(context.Background(), "tt2543164", "english", models.SubtitleTrackRequest{}, strings.NewReader(testSRT))
+	assert.True(t, errors.Is(err, service.ErrInvalidLanguage))
+
+	movieRepo.AssertNotCalled(t, "SaveMovieSubtitleTrack", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSubtitleService_UpdateAndDeleteTrack(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	svc, store := newSubtitleService(t, movieRepo)
+
+	key := "subtitles/tt2543164/en-1111111111111111.vtt"
+	assert.NoError(t, store.Put(ctx, key, strings.NewReader("WEBVTT\n"), "text/vtt"))
+	track := models.SubtitleTrack{Language: "en", Label: "English", Key: key}
+	movie := &models.Movie{ImdbID: "tt2543164", Subtitles: []models.SubtitleTrack{track}}
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(movie, nil)
+	label := "English (SDH)"
+	movieRepo.On("UpdateMovieSubtitleTrack", mock.Anything, "tt2543164", "en", &label, (*bool)(nil)).Run(func(mock.Arguments) {
+		movie.Subtitles[0].Label = label
+	}).Return(nil)
+	movieRepo.On("DeleteMovieSubtitleTrack", mock.Anything, "tt2543164", "en").Return(&track, nil)
+
+	updated, err := svc.UpdateTrack(ctx, "tt2543164", "EN", models.SubtitleTrackRequest{Label: &label})
+	assert.NoError(t, err)
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, "English (SDH)", updated.Label)
+	}
+
+	_, err = svc.UpdateTrack(ctx, "tt2543164", "fr", models.SubtitleTrackRequest{Label: &label})
+	assert.True(t, errors.Is(err, service.ErrSubtitleTrackNotFound))
+
+	assert.NoError(t, svc.DeleteTrack(ctx, "tt2543164", "en"))
+	_, err = store.Stat(ctx, key)
+	assert.True(t, errors.Is(err, storage.ErrNotFound))
+}
+
+func TestSubtitleService_TrackRemovedMeanwhile(t *testing.T) {
+	ctx := context.Background()
+	movieRepo := new(mocks.MockMovieRepository)
+	svc, _ := newSubtitleService(t, movieRepo)
+
+	movie := &models.Movie{ImdbID: "tt2543164", Subtitles: []models.SubtitleTrack{{Language: "en", Label: "English"}}}
+	movieRepo.On("GetMovie", mock.Anything, "tt2543164").Return(movie, nil)
+	movieRepo.On("UpdateMovieSubtitleTrack", mock.Anything, "tt2543164", "en", mock.Anything, mock.Anything).Return(mongo.ErrNoDocuments)
+	movieRepo.On("DeleteMovieSubtitleTrack", mock.Anything, "tt2543164", "en").Return(nil, mongo.ErrNoDocuments)
+
+	isDefault := true
+	_, err := svc.UpdateTrack(ctx, "tt2543164", "en", models.SubtitleTrackRequest{Default: &isDefault})
+	assert.True(t, errors.Is(err, service.ErrSubtitleTrackNotFound))
+	err = svc.DeleteTrack(ctx, "tt2543164", "en")
+	assert.True(t, errors.Is(err, service.ErrSubtitleTrackNotFound))
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/transcoder"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrTranscodingUnavailable   = errors.New("transcoding is not configured")
+	ErrTranscodeJobNotFound     = errors.New("transcode job not found")
+	ErrTranscodeJobFinished     = errors.New("transcode job has already finished")
+	ErrTranscodeJobNotRetryable = errors.New("only failed or canceled transcode jobs can be retried")
+	ErrNoTranscodeSource        = errors.New("no media to transcode")
+)
+
+// progressInterval is how often a running job's progress is saved and its lease renewed, which
+// is also how soon the worker notices the job was canceled.
+const progressInterval = 2 * time.Second
+
+// jobLease is how long a running job stays claimed after its lease was last renewed. A job
+// whose worker stopped is queued again once its lease runs out.
+const jobLease = time.Minute
+
+type TranscodeService interface {
+	// QueueTranscode queues a transcode of the media at sourceKey into an HLS ladder for the
+	// title.
+	QueueTranscode(ctx context.Context, imdbID string, sourceKey string, createdBy string) (*models.TranscodeJob, error)
+	// TranscodeMovie queues a transcode of req.SourceKey, or else of the title's media file or
+	// the source of its last transcode.
+	TranscodeMovie(ctx context.Context, imdbID string, req models.TranscodeRequest, createdBy string) (*models.TranscodeJob, error)
+	GetJob(ctx context.Context, id string) (*models.TranscodeJob, error)
+	// GetMovieJobs returns the title's jobs, newest first.
+	GetMovieJobs(ctx context.Context, imdbID string) ([]models.TranscodeJob, error)
+	// CancelJob stops a queued or running job. A running job stops within a few seconds and
+	// its output is removed.
+	CancelJob(ctx context.Context, id string) (*models.TranscodeJob, error)
+	// RetryJob queues a failed or canceled job again.
+	RetryJob(ctx context.Context, id string) (*models.TranscodeJob, error)
+	// RunQueue works through queued jobs one at a time until none are left, and is meant to be
+	// run periodically by the scheduler. The title plays from a job's master playlist once the
+	// job is ready, and not before.
+	RunQueue(ctx context.Context) error
+	// RequeueInterrupted queues running jobs whose worker stopped renewing their lease again,
+	// such as jobs a restart cut off.
+	RequeueInterrupted(ctx context.Context) error
+}
+
+type transcodeService struct {
+	transcodeRepo repository.TranscodeRepository
+	movieRepo     repository.MovieRepository
+	store         storage.BlobStore
+	transcoder    transcoder.Transcoder
+	streams       StreamService
+	workDir       string
+}
+
+// NewTranscodeService builds the transcode service. A nil transcoder makes queueing fail with
+// ErrTranscodingUnavailable, and queued jobs are left alone.
+func NewTranscodeService(transcodeRepo repository.TranscodeRepository, movieRepo repository.MovieRepository, store storage.BlobStore, tc transcoder.Transcoder, streams StreamService, cfg *config.Config) TranscodeService {
+	return &transcodeService{
+		transcodeRepo: transcodeRepo,
+		movieRepo:     movieRepo,
+		store:         store,
+		transcoder:    tc,
+		streams:       streams,
+		workDir:       cfg.TranscodeWorkDir,
+	}
+}
+
+func (s *transcodeService) QueueTranscode(ctx context.Context, imdbID string, sourceKey string, createdBy string) (*models.TranscodeJob, error) {
+	if s.transcoder == nil {
+		return nil, ErrTranscodingUnavailable
+	}
+	if _, err := s.movieRepo.GetMovie(ctx, imdbID); err != nil {
+		return nil, err
+	}
+	return s.queue(ctx, imdbID, sourceKey, createdBy)
+}
+
+func (s *transcodeService) TranscodeMovie(ctx context.Context, imdbID string, req models.TranscodeRequest, createdBy string) (*models.TranscodeJob, error) {
+	if s.transcoder == nil {
+		return nil, ErrTranscodingUnavailable
+	}
+	movie, err := s.movieRepo.GetMovie(ctx, imdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceKey := req.SourceKey
+	if sourceKey == "" && movie.Stream != nil && movie.Stream.Type == models.StreamFile {
+		sourceKey = movie.Stream.Key
+	}
+	if sourceKey == "" {
+		jobs, err := s.transcodeRepo.GetTranscodeJobs(ctx, imdbID)
+		if err != nil {
+			return nil, err
+		}
+		if len(jobs) == 0 {
+			return nil, ErrNoTranscodeSource
+		}
+		sourceKey = jobs[0].SourceKey
+	}
+	return s.queue(ctx, imdbID, sourceKey, createdBy)
+}
+
+func (s *transcodeService) queue(ctx context.Context, imdbID string, sourceKey string, createdBy string) (*models.TranscodeJob, error) {
+	if _, err := s.store.Stat(ctx, sourceKey); err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			return nil, ErrNoTranscodeSource
+		}
+		return nil, err
+	}
+	return s.transcodeRepo.CreateTranscodeJob(ctx, models.TranscodeJob{
+		ImdbID:    imdbID,
+		SourceKey: sourceKey,
+		CreatedBy: createdBy,
+	})
+}
+
+func (s *transcodeService) GetJob(ctx context.Context, id string) (*models.TranscodeJob, error) {
+	jobID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrTranscodeJobNotFound
+	}
+	job, err := s.transcodeRepo.GetTranscodeJob(ctx, jobID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrTranscodeJobNotFound
+	}
+	return job, err
+}
+
+func (s *transcodeService) GetMovieJobs(ctx context.Context, imdbID string) ([]models.TranscodeJob, error) {
+	if _, err := s.movieRepo.GetMovie(ctx, imdbID); err != nil {
+		return nil, err
+	}
+	return s.transcodeRepo.GetTranscodeJobs(ctx, imdbID)
+}
+
+func (s *transcodeService) CancelJob(ctx context.Context, id string) (*models.TranscodeJob, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.transcodeRepo.CancelTranscodeJob(ctx, job.ID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrTranscodeJobFinished
+		}
+		return nil, err
+	}
+	return s.GetJob(ctx, id)
+}
+
+func (s *transcodeService) RetryJob(ctx context.Context, id string) (*models.TranscodeJob, error) {
+	if s.transcoder == nil {
+		return nil, ErrTranscodingUnavailable
+	}
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.transcodeRepo.RetryTranscodeJob(ctx, job.ID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrTranscodeJobNotRetryable
+		}
+		return nil, err
+	}
+	return s.GetJob(ctx, id)
+}
+
+func (s *transcodeService) RequeueInterrupted(ctx context.Context) error {
+	if s.transcoder == nil {
+		return nil
+	}
+	requeued, err := s.transcodeRepo.RequeueExpiredTranscodeJobs(ctx)
+	if err != nil {
+		return err
+	}
+	if requeued > 0 {
+		log.Printf("requeued %d interrupted transcode jobs", requeued)
+	}
+	return nil
+}
+
+func (s *transcodeService) RunQueue(ctx context.Context) error {
+	if s.transcoder == nil {
+		return nil
+	}
+	// Jobs of a worker that died on another instance are picked up here too.
+	if err := s.RequeueInterrupted(ctx); err != nil {
+		return err
+	}
+	for ctx.Err() == nil {
+		job, err := s.transcodeRepo.ClaimTranscodeJob(ctx, time.Now().Add(jobLease))
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.process(ctx, job)
+	}
+	return ctx.Err()
+}
+
+// process runs a claimed job and saves how it ended. A job cut off by shutdown is left running,
+// to be queued again once its lease runs out.
+func (s *transcodeService) process(ctx context.Context, job *models.TranscodeJob) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	progress := &heartbeat{repo: s.transcodeRepo, id: job.ID, cancel: cancel}
+	// A job canceled right after it was claimed stops before its source is downloaded.
+	progress.beat(ctx)
+	stop := progress.start(ctx)
+
+	err := s.transcode(jobCtx, job, progress.set)
+	stop()
+	switch {
+	case ctx.Err() != nil:
+		return
+	case progress.canceled:
+		log.Printf("transcode job %s was canceled", job.ID.Hex())
+		s.removeOutput(ctx, job)
+		return
+	case err != nil:
+		log.Printf("transcode job %s failed: %v", job.ID.Hex(), err)
+		job.Status = models.TranscodeFailed
+		job.Error = err.Error()
+		s.removeOutput(ctx, job)
+	default:
+		job.Status = models.TranscodeReady
+		job.Progress = 1
+	}
+
+	if err := s.transcodeRepo.FinishTranscodeJob(ctx, *job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// Canceled after the last progress update.
+			s.removeOutput(ctx, job)
+			return
+		}
+		log.Printf("failed to save transcode job %s: %v", job.ID.Hex(), err)
+		return
+	}
+	if job.Status == models.TranscodeReady {
+		s.publish(ctx, job)
+	}
+}
+
+// publish makes the title play from a ready job, and removes the output of the job it played
+// from before.
+func (s *transcodeService) publish(ctx context.Context, job *models.TranscodeJob) {
+	movie, err := s.movieRepo.GetMovie(ctx, job.ImdbID)
+	if err != nil {
+		log.Printf("failed to play %s from transcode job %s: %v", job.ImdbID, job.ID.Hex(), err)
+		return
+	}
+	// The stream service drops the source it keeps for players, so they ask for the new output
+	// before the old one goes.
+	source := models.StreamSource{Type: models.StreamHLS, Key: job.PlaylistKey}
+	if _, err := s.streams.SetStreamSource(ctx, job.ImdbID, source); err != nil {
+		log.Printf("failed to play %s from transcode job %s: %v", job.ImdbID, job.ID.Hex(), err)
+		return
+	}
+
+	// Only output of the worker is removed, not playlists an admin pointed the title at.
+	previous := movie.Stream
+	if previous == nil || previous.Type != models.StreamHLS {
+		return
+	}
+	prefix := path.Dir(previous.Key)
+	if path.Dir(prefix) != "media/"+job.ImdbID+"/hls" || prefix == transcodePrefix(job) {
+		return
+	}
+	if err := s.store.DeletePrefix(ctx, prefix); err != nil {
+		log.Printf("failed to remove previous output of %s: %v", job.ImdbID, err)
+	}
+}
+
+// transcode fetches the source into a scratch directory, transcodes it there and stores the
+// output under the job's prefix.
+func (s *transcodeService) transcode(ctx context.Context, job *models.TranscodeJob, progress func(float64)) error {
+	dir, err := os.MkdirTemp(s.workDir, "transcode-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "source"+path.Ext(job.SourceKey))
+	// Downloads and uploads of large files take a while, so they stop as soon as the job
+	// is canceled too.
+	if err := s.download(ctx, job.SourceKey, input); err != nil {
+		return err
+	}
+	outDir := filepath.Join(dir, "hls")
+	if err := os.Mkdir(outDir, 0o755); err != nil {
+		return err
+	}
+	output, err := s.transcoder.Transcode(ctx, input, outDir, progress)
+	if err != nil {
+		return err
+	}
+
+	// A retried job may have left output behind.
+	s.removeOutput(ctx, job)
+	prefix := transcodePrefix(job)
+	err = filepath.WalkDir(outDir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(outDir, name)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		key := prefix + "/" + filepath.ToSlash(rel)
+		return s.store.Put(ctx, key, &contextReader{ctx: ctx, r: file}, storage.ContentTypeOf(key))
+	})
+	if err != nil {
+		return err
+	}
+
+	job.PlaylistKey = prefix + "/" + output.Master
+	job.Renditions = nil
+	for _, variant := range output.Variants {
+		job.Renditions = append(job.Renditions, models.TranscodeRendition{
+			Name:        variant.Name,
+			Width:       variant.Width,
+			Height:      variant.Height,
+			Bandwidth:   variant.Bandwidth,
+			PlaylistKey: prefix + "/" + variant.Playlist,
+		})
+	}
+	job.Thumbnails = nil
+	for _, thumbnail := range output.Thumbnails {
+		job.Thumbnails = append(job.Thumbnails, prefix+"/"+thumbnail)
+	}
+	return nil
+}
+
+func (s *transcodeService) download(ctx context.Context, key string, name string) error {
+	blob, err := s.store.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, &contextReader{ctx: ctx, r: blob}); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// removeOutput deletes what a job stored. Nothing refers to it, so failures are only logged.
+func (s *transcodeService) removeOutput(ctx context.Context, job *models.TranscodeJob) {
+	if err := s.store.DeletePrefix(ctx, transcodePrefix(job)); err != nil {
+		log.Printf("failed to remove output of transcode job %s: %v", job.ID.Hex(), err)
+	}
+}
+
+// transcodePrefix is where a job's output is stored, below the title's media so the stream
+// endpoint serves it.
+func transcodePrefix(job *models.TranscodeJob) string {
+	return "media/" + job.ImdbID + "/hls/" + job.ID.Hex()
+}
+
+// heartbeat saves a running job's progress and renews its lease every progressInterval for as
+// long as the job runs, and cancels the job's context once it finds the job was canceled.
+type heartbeat struct {
+	repo     repository.TranscodeRepository
+	id       bson.ObjectID
+	cancel   context.CancelFunc
+	canceled bool
+
+	mu   sync.Mutex
+	done float64
+}
+
+// set records the share of the work done, to be saved with the next beat.
+func (h *heartbeat) set(done float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.done = done
+}
+
+// start beats every progressInterval until the returned function is called, which waits for
+// the last beat to end.
+func (h *heartbeat) start(ctx context.Context) func() {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				h.beat(ctx)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// beat saves the progress. Beats never overlap, so only the progress needs the lock.
+func (h *heartbeat) beat(ctx context.Context) {
+	if h.canceled {
+		return
+	}
+	h.mu.Lock()
+	done := h.done
+	h.mu.Unlock()
+	err := h.repo.UpdateTranscodeProgress(ctx, h.id, done, time.Now().Add(jobLease))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		h.canceled = true
+		h.cancel()
+	} else if err != nil {
+		log.Printf("failed to save progress of transcode job %s: %v", h.id.Hex(), err)
+	}
+}
+
+// contextReader fails reads once ctx is done, so long copies stop when the job does.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
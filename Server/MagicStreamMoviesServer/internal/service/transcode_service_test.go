@@ -0,0 +1,266 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/transcoder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// fakeTranscoder stands in for ffmpeg.
+type fakeTranscoder func(ctx context.Context, input string, dir string, progress func(float64)) (*transcoder.Output, error)
+
+func (f fakeTranscoder) Transcode(ctx context.Context, input string, dir string, progress func(float64)) (*transcoder.Output, error) {
+	return f(ctx, input, dir, progress)
+}
+
+// writeLadder writes a one rendition ladder the way the ffmpeg transcoder lays it out.
+func writeLadder(t *testing.T, dir string) *transcoder.Output {
+	t.Helper()
+	files := map[string]string{
+		"master.m3u8":          "#EXTM3U\n",
+		"360p/index.m3u8":      "#EXTM3U\nsegment_0000.ts\n",
+		"360p/segment_0000.ts": "ts",
+		"thumbs/thumb_001.jpg": "jpeg",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return &transcoder.Output{
+		Master:     "master.m3u8",
+		Variants:   []transcoder.Variant{{Name: "360p", Width: 640, Height: 360, Bandwidth: 976000, Playlist: "360p/index.m3u8"}},
+		Thumbnails: []string{"thumbs/thumb_001.jpg"},
+	}
+}
+
+func newTranscodeService(t *testing.T, tc transcoder.Transcoder) (service.TranscodeService, *mocks.MockTranscodeRepository, *mocks.MockMovieRepository, storage.BlobStore, service.StreamService) {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	transcodeRepo := new(mocks.MockTranscodeRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	streams := service.NewStreamService(movieRepo, nil, store, nil, &config.Config{})
+	svc := service.NewTranscodeService(transcodeRepo, movieRepo, store, tc, streams, &config.Config{TranscodeWorkDir: t.TempDir()})
+	return svc, transcodeRepo, movieRepo, store, streams
+}
+
+// claims makes the mock repository hand out job once and then report an empty queue.
+func claims(transcodeRepo *mocks.MockTranscodeRepository, job *models.TranscodeJob) {
+	transcodeRepo.On("RequeueExpiredTranscodeJobs", mock.Anything).Return(int64(0), nil)
+	transcodeRepo.On("ClaimTranscodeJob", mock.Anything, mock.Anything).Return(job, nil).Once()
+	transcodeRepo.On("ClaimTranscodeJob", mock.Anything, mock.Anything).Return(nil, mongo.ErrNoDocuments)
+}
+
+func TestTranscodeService_RunQueuePublishesReadyJob(t *testing.T) {
+	ctx := context.Background()
+	tc := fakeTranscoder(func(ctx context.Context, input string, dir string, progress func(float64)) (*transcoder.Output, error) {
+		data, err := os.ReadFile(input)
+		assert.NoError(t, err)
+		assert.Equal(t, "source video", string(data))
+		assert.Equal(t, ".mp4", filepath.Ext(input))
+		progress(0.5)
+		return writeLadder(t, dir), nil
+	})
+	svc, transcodeRepo, movieRepo, store, streams := newTranscodeService(t, tc)
+	assert.NoError(t, store.Put(ctx, "media/tt1/upload.mp4", strings.NewReader("source video"), "video/mp4"))
+
+	// The title plays from an earlier transcode, whose output goes once this one is ready.
+	previous := "media/tt1/hls/" + bson.NewObjectID().Hex()
+	assert.NoError(t, store.Put(ctx, previous+"/master.m3u8", strings.NewReader("#EXTM3U\n"), ""))
+	movie := &models.Movie{ImdbID: "tt1", Stream: &models.StreamSource{Type: models.StreamHLS, Key: previous + "/master.m3u8"}}
+	getMovie := movieRepo.On("GetMovie", mock.Anything, "tt1")
+	getMovie.Run(func(mock.Arguments) {
+		saved := *movie
+		getMovie.ReturnArguments = mock.Arguments{&saved, nil}
+	})
+	// Someone is watching it, so the stream service has its source cached.
+	blob, err := streams.OpenMedia(ctx, "tt1", "")
+	if assert.NoError(t, err) {
+		blob.Close()
+	}
+
+	job := &models.TranscodeJob{ID: bson.NewObjectID(), ImdbID: "tt1", SourceKey: "media/tt1/upload.mp4", Status: models.TranscodeRunning}
+	prefix := "media/tt1/hls/" + job.ID.Hex()
+	claims(transcodeRepo, job)
+	transcodeRepo.On("UpdateTranscodeProgress", mock.Anything, job.ID, mock.Anything, mock.MatchedBy(func(lease time.Time) bool {
+		return lease.After(time.Now())
+	})).Return(nil)
+	transcodeRepo.On("FinishTranscodeJob", mock.Anything, mock.MatchedBy(func(j models.TranscodeJob) bool {
+		return j.Status == models.TranscodeReady && j.Progress == 1 && j.Error == "" &&
+			j.PlaylistKey == prefix+"/master.m3u8" &&
+			len(j.Renditions) == 1 && j.Renditions[0].PlaylistKey == prefix+"/360p/index.m3u8" && j.Renditions[0].Width == 640 &&
+			len(j.Thumbnails) == 1 && j.Thumbnails[0] == prefix+"/thumbs/thumb_001.jpg"
+	})).Return(nil)
+	movieRepo.On("SetMovieStream", mock.Anything, "tt1", &models.StreamSource{Type: models.StreamHLS, Key: prefix + "/master.m3u8"}).
+		Run(func(args mock.Arguments) { movie.Stream = args.Get(2).(*models.StreamSource) }).Return(nil)
+
+	assert.NoError(t, svc.RunQueue(ctx))
+
+	for _, key := range []string{"master.m3u8", "360p/index.m3u8", "360p/segment_0000.ts", "thumbs/thumb_001.jpg"} {
+		info, err := store.Stat(ctx, prefix+"/"+key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, storage.ContentTypeOf(key), info.ContentType)
+		}
+	}
+	_, err = store.Stat(ctx, previous+"/master.m3u8")
+	assert.True(t, errors.Is(err, storage.ErrNotFound))
+	// The player moves on to the new output right away.
+	blob, err = streams.OpenMedia(ctx, "tt1", "360p/segment_0000.ts")
+	if assert.NoError(t, err) {
+		blob.Close()
+	}
+	transcodeRepo.AssertExpectations(t)
+	movieRepo.AssertExpectations(t)
+}
+
+func TestTranscodeService_RunQueueRecordsFailure(t *testing.T) {
+	ctx := context.Background()
+	tc := fakeTranscoder(func(ctx context.Context, input string, dir string, progress func(float64)) (*transcoder.Output, error) {
+		return nil, errors.New("no video stream in the source")
+	})
+	svc, transcodeRepo, movieRepo, store, _ := newTranscodeService(t, tc)
+	assert.NoError(t, store.Put(ctx, "media/tt1/upload.mp4", strings.NewReader("audio only"), "video/mp4"))
+
+	job := &models.TranscodeJob{ID: bson.NewObjectID(), ImdbID: "tt1", SourceKey: "media/tt1/upload.mp4", Status: models.TranscodeRunning}
+	claims(transcodeRepo, job)
+	transcodeRepo.On("UpdateTranscodeProgress", mock.Anything, job.ID, mock.Anything, mock.Anything).Return(nil)
+	transcodeRepo.On("FinishTranscodeJob", mock.Anything, mock.MatchedBy(func(j models.TranscodeJob) bool {
+		return j.Status == models.TranscodeFailed && j.Error == "no video stream in the source" && j.PlaylistKey == ""
+	})).Return(nil)
+
+	assert.NoError(t, svc.RunQueue(ctx))
+	transcodeRepo.AssertExpectations(t)
+	movieRepo.AssertNotCalled(t, "SetMovieStream", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTranscodeService_RunQueueStopsCanceledJob(t *testing.T) {
+	ctx := context.Background()
+	transcoded := false
+	tc := fakeTranscoder(func(ctx context.Context, input string, dir string, progress func(float64)) (*transcoder.Output, error) {
+		transcoded = true
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	svc, transcodeRepo, movieRepo, store, _ := newTranscodeService(t, tc)
+	assert.NoError(t, store.Put(ctx, "media/tt1/upload.mp4", strings.NewReader("source video"), "video/mp4"))
+
+	job := &models.TranscodeJob{ID: bson.NewObjectID(), ImdbID: "tt1", SourceKey: "media/tt1/upload.mp4", Status: models.TranscodeRunning}
+	claims(transcodeRepo, job)
+	// The job was canceled, so it is no longer running.
+	transcodeRepo.On("UpdateTranscodeProgress", mock.Anything, job.ID, mock.Anything, mock.Anything).Return(mongo.ErrNoDocuments)
+
+	assert.NoError(t, svc.RunQueue(ctx))
+	// The heartbeat stops the job without waiting for progress, here before the download.
+	assert.False(t, transcoded)
+	transcodeRepo.AssertNotCalled(t, "FinishTranscodeJob", mock.Anything, mock.Anything)
+	movieRepo.AssertNotCalled(t, "SetMovieStream", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTranscodeService_QueueTranscode(t *testing.T) {
+	ctx := context.Background()
+	tc := fakeTranscoder(nil)
+	svc, transcodeRepo, movieRepo, store, _ := newTranscodeService(t, tc)
+	assert.NoError(t, store.Put(ctx, "media/tt1/upload.mp4", strings.NewReader("source video"), "video/mp4"))
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1"}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt0").Return(nil, mongo.ErrNoDocuments)
+	queued := &models.TranscodeJob{ID: bson.NewObjectID(), ImdbID: "tt1", SourceKey: "media/tt1/upload.mp4", Status: models.TranscodeQueued}
+	transcodeRepo.On("CreateTranscodeJob", mock.Anything, models.TranscodeJob{ImdbID: "tt1", SourceKey: "media/tt1/upload.mp4", CreatedBy: "admin"}).Return(queued, nil)
+
+	job, err := svc.QueueTranscode(ctx, "tt1", "media/tt1/upload.mp4", "admin")
+	assert.NoError(t, err)
+	assert.Equal(t, queued, job)
+
+	_, err = svc.QueueTranscode(ctx, "tt1", "media/tt1/missing.mp4", "admin")
+	assert.True(t, errors.Is(err, service.ErrNoTranscodeSource))
+	_, err = svc.QueueTranscode(ctx, "tt1", "../etc/passwd", "admin")
+	assert.True(t, errors.Is(err, service.ErrNoTranscodeSource))
+	_, err = svc.QueueTranscode(ctx, "tt0", "media/tt1/upload.mp4", "admin")
+	assert.True(t, errors.Is(err, mongo.ErrNoDocuments))
+
+	disabled, _, _, _, _ := newTranscodeService(t, nil)
+	_, err = disabled.QueueTranscode(ctx, "tt1", "media/tt1/upload.mp4", "admin")
+	assert.True(t, errors.Is(err, service.ErrTranscodingUnavailable))
+	assert.NoError(t, disabled.RunQueue(ctx))
+}
+
+func TestTranscodeService_TranscodeMovieFindsSource(t *testing.T) {
+	ctx := context.Background()
+	svc, transcodeRepo, movieRepo, store, _ := newTranscodeService(t, fakeTranscoder(nil))
+	assert.NoError(t, store.Put(ctx, "media/tt1/file.mp4", strings.NewReader("file"), "video/mp4"))
+	assert.NoError(t, store.Put(ctx, "media/tt2/upload.mkv", strings.NewReader("upload"), "video/x-matroska"))
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1", Stream: &models.StreamSource{Type: models.StreamFile, Key: "media/tt1/file.mp4"}}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt2").Return(&models.Movie{ImdbID: "tt2", Stream: &models.StreamSource{Type: models.StreamHLS, Key: "media/tt2/hls/1/master.m3u8"}}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt3").Return(&models.Movie{ImdbID: "tt3"}, nil)
+	transcodeRepo.On("GetTranscodeJobs", mock.Anything, "tt2").Return([]models.TranscodeJob{{ImdbID: "tt2", SourceKey: "media/tt2/upload.mkv"}}, nil)
+	transcodeRepo.On("GetTranscodeJobs", mock.Anything, "tt3").Return([]models.TranscodeJob{}, nil)
+	create := transcodeRepo.On("CreateTranscodeJob", mock.Anything, mock.Anything)
+	create.Run(func(args mock.Arguments) {
+		job := args.Get(1).(models.TranscodeJob)
+		create.ReturnArguments = mock.Arguments{&job, nil}
+	})
+
+	// The title's media file.
+	job, err := svc.TranscodeMovie(ctx, "tt1", models.TranscodeRequest{}, "admin")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "media/tt1/file.mp4", job.SourceKey)
+	}
+	// The source of the last transcode.
+	job, err = svc.TranscodeMovie(ctx, "tt2", models.TranscodeRequest{}, "admin")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "media/tt2/upload.mkv", job.SourceKey)
+	}
+	// An explicit source wins.
+	job, err = svc.TranscodeMovie(ctx, "tt1", models.TranscodeRequest{SourceKey: "media/tt2/upload.mkv"}, "admin")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "media/tt2/upload.mkv", job.SourceKey)
+	}
+	_, err = svc.TranscodeMovie(ctx, "tt3", models.TranscodeRequest{}, "admin")
+	assert.True(t, errors.Is(err, service.ErrNoTranscodeSource))
+}
+
+func TestTranscodeService_CancelAndRetry(t *testing.T) {
+	ctx := context.Background()
+	svc, transcodeRepo, _, _, _ := newTranscodeService(t, fakeTranscoder(nil))
+
+	running := &models.TranscodeJob{ID: bson.NewObjectID(), Status: models.TranscodeRunning}
+	ready := &models.TranscodeJob{ID: bson.NewObjectID(), Status: models.TranscodeReady}
+	transcodeRepo.On("GetTranscodeJob", mock.Anything, running.ID).Return(running, nil)
+	transcodeRepo.On("GetTranscodeJob", mock.Anything, ready.ID).Return(ready, nil)
+	transcodeRepo.On("CancelTranscodeJob", mock.Anything, running.ID).Return(nil)
+	transcodeRepo.On("CancelTranscodeJob", mock.Anything, ready.ID).Return(mongo.ErrNoDocuments)
+	transcodeRepo.On("RetryTranscodeJob", mock.Anything, running.ID).Return(nil)
+	transcodeRepo.On("RetryTranscodeJob", mock.Anything, ready.ID).Return(mongo.ErrNoDocuments)
+	missing := bson.NewObjectID()
+	transcodeRepo.On("GetTranscodeJob", mock.Anything, missing).Return(nil, mongo.ErrNoDocuments)
+
+	_, err := svc.CancelJob(ctx, running.ID.Hex())
+	assert.NoError(t, err)
+	_, err = svc.CancelJob(ctx, ready.ID.Hex())
+	assert.True(t, errors.Is(err, service.ErrTranscodeJobFinished))
+	_, err = svc.RetryJob(ctx, running.ID.Hex())
+	assert.NoError(t, err)
+	_, err = svc.RetryJob(ctx, ready.ID.Hex())
+	assert.True(t, errors.Is(err, service.ErrTranscodeJobNotRetryable))
+
+	_, err = svc.GetJob(ctx, missing.Hex())
+	assert.True(t, errors.Is(err, service.ErrTranscodeJobNotFound))
+	_, err = svc.CancelJob(ctx, "not-an-id")
+	assert.True(t, errors.Is(err, service.ErrTranscodeJobNotFound))
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+)
+
+var ErrUnknownTrendingWindow = errors.New("window must be one of 24h, 7d or 30d")
+
+// DefaultTrendingWindow is used when a caller does not ask for a specific window.
+const DefaultTrendingWindow = "7d"
+
+// TrendingWindows maps the supported windows to how far back they look. Events within a window
+// lose half their weight every quarter of the window.
+var TrendingWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// eventWeights is how much each kind of interaction says about a movie's popularity.
+var eventWeights = map[string]float64{
+	models.EventView:      1,
+	models.EventWatchlist: 2,
+	models.EventPlay:      3,
+	models.EventReview:    4,
+}
+
+type TrendingService interface {
+	// RecordEvent stores a client reported event for an existing movie. Repeats of the same
+	// event by the same user within the dedup window are dropped, so they cannot inflate the
+	// trending scores.
+	RecordEvent(ctx context.Context, event models.Event) error
+	GetTrending(ctx context.Context, window string, genre string, limit int64) ([]models.TrendingMovie, error)
+}
+
+type trendingService struct {
+	eventRepo   repository.EventRepository
+	movieRepo   repository.MovieRepository
+	dedupWindow time.Duration
+}
+
+func NewTrendingService(eventRepo repository.EventRepository, movieRepo repository.MovieRepository, cfg *config.Config) TrendingService {
+	return &trendingService{
+		eventRepo:   eventRepo,
+		movieRepo:   movieRepo,
+		dedupWindow: cfg.EventDedupWindow,
+	}
+}
+
+func (s *trendingService) RecordEvent(ctx context.Context, event models.Event) error {
+	if _, err := s.movieRepo.GetMovie(ctx, event.ImdbID); err != nil {
+		return err
+	}
+	event.CreatedAt = time.Now()
+	_, err := s.eventRepo.InsertEventOnce(ctx, event, event.CreatedAt.Add(-s.dedupWindow))
+	return err
+}
+
+func (s *trendingService) GetTrending(ctx context.Context, window string, genre string, limit int64) ([]models.TrendingMovie, error) {
+	if window == "" {
+		window = DefaultTrendingWindow
+	}
+	if _, ok := TrendingWindows[window]; !ok {
+		return nil, ErrUnknownTrendingWindow
+	}
+
+	scores, err := s.eventRepo.GetTrendingScores(ctx, window, genre, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(scores))
+	for _, score := range scores {
+		ids = append(ids, score.ImdbID)
+	}
+	movies, err := s.movieRepo.GetMoviesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ImdbID] = movie
+	}
+	trending := []models.TrendingMovie{}
+	for _, score := range scores {
+		if movie, ok := byID[score.ImdbID]; ok {
+			trending = append(trending, models.TrendingMovie{Movie: movie, TrendingScore: score.Score})
+		}
+	}
+	return trending, nil
+}
+
+// recordEvent stores an analytics event on behalf of another operation. Analytics must never
+// fail the operation itself, so errors are only logged.
+func recordEvent(ctx context.Context, eventRepo repository.EventRepository, userId string, imdbID string, eventType string) {
+	if eventRepo == nil {
+		return
+	}
+	event := models.Event{UserID: userId, ImdbID: imdbID, Type: eventType, CreatedAt: time.Now()}
+	if err := eventRepo.InsertEvent(ctx, event); err != nil {
+		log.Printf("failed to record %s event for %s: %v", eventType, imdbID, err)
+	}
+}
+
+// TrendingJob recomputes the trending scores of every window. It is meant to be run
+// periodically by the scheduler.
+type TrendingJob struct {
+	eventRepo repository.EventRepository
+	movieRepo repository.MovieRepository
+	now       func() time.Time
+}
+
+func NewTrendingJob(eventRepo repository.EventRepository, movieRepo repository.MovieRepository) *TrendingJob {
+	return &TrendingJob{
+		eventRepo: eventRepo,
+		movieRepo: movieRepo,
+		now:       time.Now,
+	}
+}
+
+func (j *TrendingJob) Run(ctx context.Context) error {
+	now := j.now()
+	for window, length := range TrendingWindows {
+		scores, err := j.eventRepo.AggregateTrendingScores(ctx, now.Add(-length), now, length/4, eventWeights)
+		if err != nil {
+			return err
+		}
+
+		// Denormalise genres so trending can be filtered by genre without a join.
+		ids := make([]string, 0, len(scores))
+		for _, score := range scores {
+			ids = append(ids, score.ImdbID)
+		}
+		movies, err := j.movieRepo.GetMoviesByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		genres := make(map[string][]string, len(movies))
+		for _, movie := range movies {
+			for _, genre := range movie.Genre {
+				genres[movie.ImdbID] = append(genres[movie.ImdbID], genre.GenreName)
+			}
+		}
+
+		for i := range scores {
+			scores[i].Window = window
+			scores[i].Genres = genres[scores[i].ImdbID]
+			scores[i].ComputedAt = now
+		}
+		if err := j.eventRepo.ReplaceTrendingScores(ctx, window, scores, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestGetTrending(t *testing.T) {
+	eventRepo := new(mocks.MockEventRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewTrendingService(eventRepo, movieRepo, &config.Config{})
+
+	eventRepo.On("GetTrendingScores", mock.Anything, "7d", "Comedy", int64(10)).Return([]models.TrendingScore{
+		{ImdbID: "tt2", Score: 9},
+		{ImdbID: "gone", Score: 5},
+		{ImdbID: "tt1", Score: 2},
+	}, nil)
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt2", "gone", "tt1"}).
+		Return([]models.Movie{{ImdbID: "tt1"}, {ImdbID: "tt2"}}, nil)
+
+	trending, err := svc.GetTrending(context.Background(), "", "Comedy", 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, trending, 2)
+	assert.Equal(t, "tt2", trending[0].ImdbID)
+	assert.Equal(t, 9.0, trending[0].TrendingScore)
+	assert.Equal(t, "tt1", trending[1].ImdbID)
+}
+
+func TestRecordEvent_DedupesAndChecksMovie(t *testing.T) {
+	eventRepo := new(mocks.MockEventRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	svc := service.NewTrendingService(eventRepo, movieRepo, &config.Config{EventDedupWindow: 30 * time.Minute})
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1"}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "nope").Return(nil, mongo.ErrNoDocuments)
+	eventRepo.On("InsertEventOnce", mock.Anything, mock.Anything, mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) > 29*time.Minute && time.Since(since) < 31*time.Minute
+	})).Return(false, nil)
+
+	event := models.Event{UserID: "user123", ImdbID: "tt1", Type: models.EventView}
+	assert.NoError(t, svc.RecordEvent(context.Background(), event))
+	eventRepo.AssertNumberOfCalls(t, "InsertEventOnce", 1)
+
+	event.ImdbID = "nope"
+	assert.ErrorIs(t, svc.RecordEvent(context.Background(), event), mongo.ErrNoDocuments)
+	eventRepo.AssertNumberOfCalls(t, "InsertEventOnce", 1)
+}
+
+func TestGetTrending_UnknownWindow(t *testing.T) {
+	svc := service.NewTrendingService(nil, nil, &config.Config{})
+
+	_, err := svc.GetTrending(context.Background(), "1y", "", 10)
+
+	assert.ErrorIs(t, err, service.ErrUnknownTrendingWindow)
+}
+
+func TestTrendingJob_Run(t *testing.T) {
+	eventRepo := new(mocks.MockEventRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	job := service.NewTrendingJob(eventRepo, movieRepo)
+
+	for window, length := range service.TrendingWindows {
+		eventRepo.On("AggregateTrendingScores", mock.Anything, mock.Anything, mock.Anything, length/4, mock.Anything).
+			Return([]models.TrendingScore{{ImdbID: "tt1", Score: 4}}, nil).Once()
+		eventRepo.On("ReplaceTrendingScores", mock.Anything, window, mock.MatchedBy(func(scores []models.TrendingScore) bool {
+			return len(scores) == 1 && scores[0].Window == window && assert.ObjectsAreEqual([]string{"Comedy", "Drama"}, scores[0].Genres)
+		}), mock.AnythingOfType("time.Time")).Return(nil).Once()
+	}
+	movieRepo.On("GetMoviesByIDs", mock.Anything, []string{"tt1"}).Return([]models.Movie{{
+		ImdbID: "tt1",
+		Genre:  []models.Genre{{GenreID: 1, GenreName: "Comedy"}, {GenreID: 2, GenreName: "Drama"}},
+	}}, nil)
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+	eventRepo.AssertExpectations(t)
+}
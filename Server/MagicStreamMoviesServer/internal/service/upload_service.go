@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/repository"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrUploadNotFound  = errors.New("upload not found")
+	ErrUploadOffset    = errors.New("upload offset does not match")
+	ErrUploadTooLarge  = errors.New("upload is too large")
+	ErrUploadCompleted = errors.New("upload is already complete")
+	ErrInvalidUpload   = errors.New("invalid upload")
+)
+
+// joinTimeout bounds joining the parts of an upload. A join that runs out of time, or dies with
+// the server, can be retried once its claim has expired.
+const joinTimeout = 2 * time.Hour
+
+// mediaExtension matches the file extensions kept on uploaded media keys.
+var mediaExtension = regexp.MustCompile(`^\.[a-z0-9]{1,8}$`)
+
+type UploadService interface {
+	// CreateUpload starts an upload of upload.Length bytes of media for upload.ImdbID.
+	CreateUpload(ctx context.Context, upload models.Upload) (*models.Upload, error)
+	GetUpload(ctx context.Context, id string) (*models.Upload, error)
+	// WriteChunk appends r, of size bytes or -1 when unknown, to the upload at offset, which
+	// must be the upload's current offset. Data that arrived before r failed is kept, so the
+	// client can resume after it. Once the last chunk is in, the parts are joined in the
+	// background into the title's media, which is queued to be transcoded, or made the title's
+	// stream source when transcoding is not configured. A write to a fully received upload
+	// whose join failed starts the join again.
+	WriteChunk(ctx context.Context, id string, offset int64, r io.Reader, size int64) (*models.Upload, error)
+	// DeleteUpload ends an unfinished upload and removes the data received so far.
+	DeleteUpload(ctx context.Context, id string) error
+	// MaxUploadSize is the largest upload accepted, in bytes.
+	MaxUploadSize() int64
+}
+
+type uploadService struct {
+	uploadRepo repository.UploadRepository
+	movieRepo  repository.MovieRepository
+	store      storage.BlobStore
+	transcodes TranscodeService
+	streams    StreamService
+	maxBytes   int64
+}
+
+func NewUploadService(uploadRepo repository.UploadRepository, movieRepo repository.MovieRepository, store storage.BlobStore, transcodes TranscodeService, streams StreamService, cfg *config.Config) UploadService {
+	return &uploadService{
+		uploadRepo: uploadRepo,
+		movieRepo:  movieRepo,
+		store:      store,
+		transcodes: transcodes,
+		streams:    streams,
+		maxBytes:   cfg.UploadMaxBytes,
+	}
+}
+
+func (s *uploadService) MaxUploadSize() int64 {
+	return s.maxBytes
+}
+
+func (s *uploadService) CreateUpload(ctx context.Context, upload models.Upload) (*models.Upload, error) {
+	if upload.ImdbID == "" {
+		return nil, fmt.Errorf("%w: imdb_id metadata is required", ErrInvalidUpload)
+	}
+	if upload.Length <= 0 {
+		return nil, fmt.Errorf("%w: Upload-Length must be positive", ErrInvalidUpload)
+	}
+	if upload.Length > s.maxBytes {
+		return nil, ErrUploadTooLarge
+	}
+	if _, err := s.movieRepo.GetMovie(ctx, upload.ImdbID); err != nil {
+		return nil, err
+	}
+	return s.uploadRepo.CreateUpload(ctx, upload)
+}
+
+func (s *uploadService) GetUpload(ctx context.Context, id string) (*models.Upload, error) {
+	uploadID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrUploadNotFound
+	}
+	upload, err := s.uploadRepo.GetUpload(ctx, uploadID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrUploadNotFound
+	}
+	return upload, err
+}
+
+func (s *uploadService) WriteChunk(ctx context.Context, id string, offset int64, r io.Reader, size int64) (*models.Upload, error) {
+	upload, err := s.GetUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Completed() {
+		return nil, ErrUploadCompleted
+	}
+	if offset != upload.Offset {
+		return nil, ErrUploadOffset
+	}
+	if size > upload.Length-offset {
+		return nil, fmt.Errorf("%w: chunk extends past Upload-Length", ErrUploadTooLarge)
+	}
+	if offset == upload.Length {
+		// Every byte is in, but the join has not finished.
+		if err := s.startJoin(ctx, upload); err != nil {
+			return nil, err
+		}
+		return upload, nil
+	}
+
+	// Each chunk gets a key of its own, so a concurrent write at the same offset cannot
+	// overwrite it before losing the race below.
+	chunk := &chunkReader{r: io.LimitReader(r, upload.Length-offset)}
+	key := fmt.Sprintf("%s/%020d-%s", uploadPrefix(upload.ID), offset, bson.NewObjectID().Hex())
+	if err := s.store.Put(ctx, key, chunk, "application/octet-stream"); err != nil {
+		return nil, err
+	}
+	if chunk.n == 0 {
+		s.deleteBlob(ctx, key)
+		return upload, nil
+	}
+
+	part := models.UploadPart{Key: key, Offset: offset, Size: chunk.n}
+	if err := s.uploadRepo.AppendUploadPart(ctx, upload.ID, offset, part); err != nil {
+		s.deleteBlob(ctx, key)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrUploadOffset
+		}
+		return nil, err
+	}
+	upload.Offset += part.Size
+	upload.Parts = append(upload.Parts, part)
+
+	if upload.Offset == upload.Length {
+		if err := s.startJoin(ctx, upload); err != nil {
+			return nil, err
+		}
+	}
+	return upload, nil
+}
+
+func (s *uploadService) DeleteUpload(ctx context.Context, id string) error {
+	upload, err := s.GetUpload(ctx, id)
+	if err != nil {
+		return err
+	}
+	if upload.Completed() {
+		return ErrUploadCompleted
+	}
+	if err := s.uploadRepo.DeleteUpload(ctx, upload.ID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrUploadNotFound
+		}
+		return err
+	}
+	s.deleteParts(ctx, upload)
+	return nil
+}
+
+// startJoin joins the parts of a fully received upload in the background, so the request that
+// sent the last chunk does not wait for the whole file to be copied. The claim keeps a retried
+// request from starting a second join while one is running.
+func (s *uploadService) startJoin(ctx context.Context, upload *models.Upload) error {
+	err := s.uploadRepo.ClaimUploadJoin(ctx, upload.ID, time.Now().Add(joinTimeout))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	joined := *upload
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), joinTimeout)
+		defer cancel()
+		if err := s.complete(ctx, &joined); err != nil {
+			log.Printf("failed to join upload %s: %v", joined.ID.Hex(), err)
+			if err := s.uploadRepo.ReleaseUploadJoin(ctx, joined.ID); err != nil {
+				log.Printf("failed to release upload %s: %v", joined.ID.Hex(), err)
+			}
+		}
+	}()
+	return nil
+}
+
+// complete joins the parts into the title's media and queues its transcode. Without a
+// transcoder the title plays the media as it was uploaded.
+func (s *uploadService) complete(ctx context.Context, upload *models.Upload) error {
+	key := "media/" + upload.ImdbID + "/" + upload.ID.Hex()
+	if ext := strings.ToLower(path.Ext(upload.Filename)); mediaExtension.MatchString(ext) {
+		key += ext
+	}
+	parts := &partsReader{ctx: ctx, store: s.store, parts: upload.Parts}
+	err := s.store.Put(ctx, key, parts, upload.ContentType)
+	parts.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := s.uploadRepo.CompleteUpload(ctx, upload.ID, key); err != nil {
+		return err
+	}
+	upload.Key = key
+	_, err = s.transcodes.QueueTranscode(ctx, upload.ImdbID, key, upload.CreatedBy)
+	if errors.Is(err, ErrTranscodingUnavailable) {
+		_, err = s.streams.SetStreamSource(ctx, upload.ImdbID, models.StreamSource{Type: models.StreamFile, Key: key})
+	}
+	if err != nil {
+		return err
+	}
+	s.deleteParts(ctx, upload)
+	return nil
+}
+
+// deleteParts removes the chunks of an upload. They are no longer referenced, so failures are
+// only logged.
+func (s *uploadService) deleteParts(ctx context.Context, upload *models.Upload) {
+	if err := s.store.DeletePrefix(ctx, uploadPrefix(upload.ID)); err != nil {
+		log.Printf("failed to remove parts of upload %s: %v", upload.ID.Hex(), err)
+	}
+}
+
+func (s *uploadService) deleteBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		log.Printf("failed to remove %s: %v", key, err)
+	}
+}
+
+func uploadPrefix(id bson.ObjectID) string {
+	return "uploads/" + id.Hex()
+}
+
+// chunkReader ends a chunk at the first read error rather than failing it, so the data that
+// arrived before a dropped connection is stored.
+type chunkReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if err != nil {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// partsReader reads the parts of an upload one after another, opening each in turn.
+type partsReader struct {
+	ctx     context.Context
+	store   storage.BlobStore
+	parts   []models.UploadPart
+	current *storage.Blob
+}
+
+func (r *partsReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.parts) == 0 {
+				return 0, io.EOF
+			}
+			blob, err := r.store.Open(r.ctx, r.parts[0].Key)
+			if err != nil {
+				return 0, fmt.Errorf("open upload part: %w", err)
+			}
+			r.current, r.parts = blob, r.parts[1:]
+		}
+		n, err := r.current.Read(p)
+		if errors.Is(err, io.EOF) {
+			r.current.Close()
+			r.current = nil
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+func (r *partsReader) Close() {
+	if r.current != nil {
+		r.current.Close()
+		r.current = nil
+	}
+}
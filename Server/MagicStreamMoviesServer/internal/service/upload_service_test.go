@@ -0,0 +1,254 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/mocks"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/models"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/service"
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// storedUpload makes the mock repository keep one upload session the way the database would.
+// Joins run in the background, so the session is guarded by a lock.
+func storedUpload(uploadRepo *mocks.MockUploadRepository, upload *models.Upload) {
+	var mu sync.Mutex
+	get := uploadRepo.On("GetUpload", mock.Anything, upload.ID)
+	get.Run(func(mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		saved := *upload
+		saved.Parts = append([]models.UploadPart(nil), upload.Parts...)
+		get.ReturnArguments = mock.Arguments{&saved, nil}
+	})
+	appendPart := uploadRepo.On("AppendUploadPart", mock.Anything, upload.ID, mock.Anything, mock.Anything)
+	appendPart.Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		offset, part := args.Get(2).(int64), args.Get(3).(models.UploadPart)
+		if offset != upload.Offset || upload.Completed() {
+			appendPart.ReturnArguments = mock.Arguments{mongo.ErrNoDocuments}
+			return
+		}
+		upload.Offset += part.Size
+		upload.Parts = append(upload.Parts, part)
+		appendPart.ReturnArguments = mock.Arguments{nil}
+	})
+	claim := uploadRepo.On("ClaimUploadJoin", mock.Anything, upload.ID, mock.Anything)
+	claim.Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		if upload.Completed() || upload.Offset != upload.Length || (upload.JoiningUntil != nil && upload.JoiningUntil.After(time.Now())) {
+			claim.ReturnArguments = mock.Arguments{mongo.ErrNoDocuments}
+			return
+		}
+		until := args.Get(2).(time.Time)
+		upload.JoiningUntil = &until
+		claim.ReturnArguments = mock.Arguments{nil}
+	})
+	uploadRepo.On("ReleaseUploadJoin", mock.Anything, upload.ID).Run(func(mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		upload.JoiningUntil = nil
+	}).Return(nil)
+	uploadRepo.On("CompleteUpload", mock.Anything, upload.ID, mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		upload.Key = args.String(2)
+		upload.CompletedAt = &now
+		upload.JoiningUntil = nil
+		upload.Parts = nil
+	}).Return(nil)
+}
+
+// waitForJoin waits until the background join of the parts has removed them, which is the
+// last thing it does.
+func waitForJoin(t *testing.T, store storage.BlobStore, parts []models.UploadPart) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		for _, part := range parts {
+			if _, err := store.Stat(context.Background(), part.Key); !errors.Is(err, storage.ErrNotFound) {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 5*time.Millisecond)
+}
+
+// failingReader returns its data and then fails, like a dropped connection.
+type failingReader struct {
+	r io.Reader
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset by peer")
+	}
+	return n, err
+}
+
+func newUploadService(t *testing.T) (service.UploadService, *mocks.MockUploadRepository, *mocks.MockMovieRepository, *mocks.MockTranscodeService, storage.BlobStore) {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	uploadRepo := new(mocks.MockUploadRepository)
+	movieRepo := new(mocks.MockMovieRepository)
+	transcodes := new(mocks.MockTranscodeService)
+	streams := service.NewStreamService(movieRepo, nil, store, nil, &config.Config{})
+	svc := service.NewUploadService(uploadRepo, movieRepo, store, transcodes, streams, &config.Config{UploadMaxBytes: 1 << 20})
+	return svc, uploadRepo, movieRepo, transcodes, store
+}
+
+func TestUploadService_ResumesAndCompletes(t *testing.T) {
+	ctx := context.Background()
+	svc, uploadRepo, movieRepo, transcodes, store := newUploadService(t)
+
+	upload := &models.Upload{ID: bson.NewObjectID(), ImdbID: "tt1", Filename: "Arrival.MP4", ContentType: "video/mp4", Length: 26}
+	storedUpload(uploadRepo, upload)
+	id := upload.ID.Hex()
+	key := "media/tt1/" + id + ".mp4"
+	// Without a transcoder the title plays the file as uploaded.
+	transcodes.On("QueueTranscode", mock.Anything, "tt1", key, "").Return(nil, service.ErrTranscodingUnavailable)
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1"}, nil)
+	movieRepo.On("SetMovieStream", mock.Anything, "tt1", &models.StreamSource{Type: models.StreamFile, Key: key}).Return(nil)
+
+	// The connection drops after ten bytes; they are kept.
+	written, err := svc.WriteChunk(ctx, id, 0, &failingReader{strings.NewReader("abcdefghij")}, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), written.Offset)
+
+	// Resuming from an old offset is refused.
+	_, err = svc.WriteChunk(ctx, id, 0, strings.NewReader("abcdefghij"), 10)
+	assert.True(t, errors.Is(err, service.ErrUploadOffset))
+
+	// A chunk running past the length is refused.
+	_, err = svc.WriteChunk(ctx, id, 10, strings.NewReader(strings.Repeat("x", 17)), 17)
+	assert.True(t, errors.Is(err, service.ErrUploadTooLarge))
+
+	written, err = svc.WriteChunk(ctx, id, 10, strings.NewReader("klmnopqrstuvwxyz"), 16)
+	assert.NoError(t, err)
+	if !assert.NotNil(t, written) {
+		return
+	}
+	assert.Equal(t, int64(26), written.Offset)
+
+	// The parts are joined after the request, and are gone once joined.
+	waitForJoin(t, store, written.Parts)
+	completed, err := svc.GetUpload(ctx, id)
+	if assert.NoError(t, err) {
+		assert.True(t, completed.Completed())
+		assert.Equal(t, key, completed.Key)
+	}
+	blob, err := store.Open(ctx, key)
+	if assert.NoError(t, err) {
+		data, _ := io.ReadAll(blob)
+		blob.Close()
+		assert.Equal(t, "abcdefghijklmnopqrstuvwxyz", string(data))
+	}
+
+	_, err = svc.WriteChunk(ctx, id, 26, strings.NewReader(""), 0)
+	assert.True(t, errors.Is(err, service.ErrUploadCompleted))
+	err = svc.DeleteUpload(ctx, id)
+	assert.True(t, errors.Is(err, service.ErrUploadCompleted))
+	movieRepo.AssertExpectations(t)
+}
+
+func TestUploadService_QueuesTranscode(t *testing.T) {
+	ctx := context.Background()
+	svc, uploadRepo, movieRepo, transcodes, store := newUploadService(t)
+
+	upload := &models.Upload{ID: bson.NewObjectID(), ImdbID: "tt1", Filename: "arrival.mkv", Length: 5, CreatedBy: "admin"}
+	storedUpload(uploadRepo, upload)
+	key := "media/tt1/" + upload.ID.Hex() + ".mkv"
+	transcodes.On("QueueTranscode", mock.Anything, "tt1", key, "admin").Return(&models.TranscodeJob{ImdbID: "tt1", SourceKey: key}, nil)
+
+	written, err := svc.WriteChunk(ctx, upload.ID.Hex(), 0, strings.NewReader("video"), 5)
+	assert.NoError(t, err)
+	if assert.NotNil(t, written) {
+		waitForJoin(t, store, written.Parts)
+	}
+	// The title only becomes playable once the transcode is ready.
+	movieRepo.AssertNotCalled(t, "SetMovieStream", mock.Anything, mock.Anything, mock.Anything)
+	transcodes.AssertExpectations(t)
+}
+
+func TestUploadService_RetriesJoin(t *testing.T) {
+	ctx := context.Background()
+	svc, uploadRepo, _, transcodes, store := newUploadService(t)
+
+	upload := &models.Upload{ID: bson.NewObjectID(), ImdbID: "tt1", Length: 5}
+	uploadRepo.On("CompleteUpload", mock.Anything, upload.ID, mock.Anything).Return(errors.New("connection refused")).Once()
+	storedUpload(uploadRepo, upload)
+	transcodes.On("QueueTranscode", mock.Anything, "tt1", mock.Anything, mock.Anything).Return(&models.TranscodeJob{}, nil)
+
+	written, err := svc.WriteChunk(ctx, upload.ID.Hex(), 0, strings.NewReader("video"), 5)
+	assert.NoError(t, err)
+	// The write claimed the join, and the failed join releases it.
+	assert.Eventually(t, func() bool {
+		current, err := svc.GetUpload(ctx, upload.ID.Hex())
+		return err == nil && current.JoiningUntil == nil
+	}, time.Second, 5*time.Millisecond)
+
+	// Every byte is in, so an empty write at the end runs the join again.
+	_, err = svc.WriteChunk(ctx, upload.ID.Hex(), 5, strings.NewReader(""), 0)
+	assert.NoError(t, err)
+	waitForJoin(t, store, written.Parts)
+	completed, err := svc.GetUpload(ctx, upload.ID.Hex())
+	if assert.NoError(t, err) {
+		assert.True(t, completed.Completed())
+	}
+	uploadRepo.AssertNumberOfCalls(t, "CompleteUpload", 2)
+}
+
+func TestUploadService_CreateUpload(t *testing.T) {
+	ctx := context.Background()
+	svc, uploadRepo, movieRepo, _, _ := newUploadService(t)
+
+	movieRepo.On("GetMovie", mock.Anything, "tt1").Return(&models.Movie{ImdbID: "tt1"}, nil)
+	movieRepo.On("GetMovie", mock.Anything, "tt0").Return(nil, mongo.ErrNoDocuments)
+	upload := models.Upload{ImdbID: "tt1", Length: 1024}
+	uploadRepo.On("CreateUpload", mock.Anything, upload).Return(&models.Upload{ID: bson.NewObjectID(), ImdbID: "tt1", Length: 1024}, nil)
+
+	created, err := svc.CreateUpload(ctx, upload)
+	assert.NoError(t, err)
+	assert.NotNil(t, created)
+
+	_, err = svc.CreateUpload(ctx, models.Upload{Length: 1024})
+	assert.True(t, errors.Is(err, service.ErrInvalidUpload))
+	_, err = svc.CreateUpload(ctx, models.Upload{ImdbID: "tt1", Length: 1<<20 + 1})
+	assert.True(t, errors.Is(err, service.ErrUploadTooLarge))
+	_, err = svc.CreateUpload(ctx, models.Upload{ImdbID: "tt0", Length: 1024})
+	assert.True(t, errors.Is(err, mongo.ErrNoDocuments))
+}
+
+func TestUploadService_DeleteUpload(t *testing.T) {
+	ctx := context.Background()
+	svc, uploadRepo, _, _, store := newUploadService(t)
+
+	upload := &models.Upload{ID: bson.NewObjectID(), ImdbID: "tt1", Length: 100}
+	storedUpload(uploadRepo, upload)
+	uploadRepo.On("DeleteUpload", mock.Anything, upload.ID).Return(nil)
+
+	written, err := svc.WriteChunk(ctx, upload.ID.Hex(), 0, strings.NewReader("partial"), 7)
+	assert.NoError(t, err)
+
+	assert.NoError(t, svc.DeleteUpload(ctx, upload.ID.Hex()))
+	_, err = store.Stat(ctx, written.Parts[0].Key)
+	assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+	_, err = svc.GetUpload(ctx, "not-an-id")
+	assert.True(t, errors.Is(err, service.ErrUploadNotFound))
+}
@@ -20,14 +20,16 @@ type UserService interface {
 }
 
 type userService struct {
-	repo   repository.UserRepository
-	config *config.Config
+	repo      repository.UserRepository
+	genreRepo repository.GenreRepository
+	config    *config.Config
 }
 
-func NewUserService(repo repository.UserRepository, cfg *config.Config) UserService {
+func NewUserService(repo repository.UserRepository, genreRepo repository.GenreRepository, cfg *config.Config) UserService {
 	return &userService{
-		repo:   repo,
-		config: cfg,
+		repo:      repo,
+		genreRepo: genreRepo,
+		config:    cfg,
 	}
 }
 
@@ -40,6 +42,14 @@ func (s *userService) RegisterUser(ctx context.Context, user models.User) (*mode
 		return nil, errors.New("user already exists")
 	}
 
+	// Favourite genres are matched by name across the recommender and the home page, so they
+	// must be genres from the managed list, named as there.
+	genres, err := canonicalGenres(ctx, s.genreRepo, user.FavoriteGenres)
+	if err != nil {
+		return nil, err
+	}
+	user.FavoriteGenres = genres
+
 	hashedPassword, err := utils.HashPassword(user.Password)
 	if err != nil {
 		return nil, err
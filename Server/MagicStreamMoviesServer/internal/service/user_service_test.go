@@ -17,7 +17,7 @@ import (
 func TestRegisterUser_Success(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
 	cfg := &config.Config{}
-	svc := service.NewUserService(mockRepo, cfg)
+	svc := service.NewUserService(mockRepo, nil, cfg)
 
 	user := models.User{
 		Email:    "test@example.com",
@@ -47,10 +47,31 @@ func TestRegisterUser_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestRegisterUser_CanonicalGenres(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	genreRepo := new(mocks.MockGenreRepository)
+	svc := service.NewUserService(mockRepo, genreRepo, &config.Config{})
+
+	mockRepo.On("CountUsersByEmail", mock.Anything, "test@example.com").Return(int64(0), nil)
+	genreRepo.On("GetGenresByIDs", mock.Anything, []int{1}).Return([]models.Genre{{GenreID: 1, GenreName: "Comedy"}}, nil)
+	genreRepo.On("GetGenresByIDs", mock.Anything, []int{1, 99}).Return([]models.Genre{{GenreID: 1, GenreName: "Comedy"}}, nil)
+	mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return(&mongo.InsertOneResult{}, nil)
+
+	user := models.User{Email: "test@example.com", Password: "password123", FavoriteGenres: []models.Genre{{GenreID: 1, GenreName: "comedy!"}}}
+	created, err := svc.RegisterUser(context.Background(), user)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Genre{{GenreID: 1, GenreName: "Comedy"}}, created.FavoriteGenres)
+
+	user.FavoriteGenres = []models.Genre{{GenreID: 1}, {GenreID: 99, GenreName: "Made Up"}}
+	_, err = svc.RegisterUser(context.Background(), user)
+	assert.ErrorIs(t, err, service.ErrUnknownGenres)
+	mockRepo.AssertNumberOfCalls(t, "CreateUser", 1)
+}
+
 func TestRegisterUser_UserExists(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
 	cfg := &config.Config{}
-	svc := service.NewUserService(mockRepo, cfg)
+	svc := service.NewUserService(mockRepo, nil, cfg)
 
 	user := models.User{
 		Email:    "existing@example.com",
@@ -78,7 +99,7 @@ func TestRefreshToken_Success(t *testing.T) {
 		SecretKey:        "secret",
 		SecretRefreshKey: "refresh_secret",
 	}
-	svc := service.NewUserService(mockRepo, cfg)
+	svc := service.NewUserService(mockRepo, nil, cfg)
 
 	// Generate a valid refresh token first
 	_, refreshToken, err := utils.GenerateAllTokens("test@example.com", "John", "Doe", "USER", "user123", "secret", "refresh_secret")
@@ -108,7 +129,7 @@ func TestRefreshToken_InvalidToken(t *testing.T) {
 	cfg := &config.Config{
 		SecretRefreshKey: "refresh_secret",
 	}
-	svc := service.NewUserService(mockRepo, cfg)
+	svc := service.NewUserService(mockRepo, nil, cfg)
 
 	_, _, err := svc.RefreshToken(context.Background(), "invalid-token")
 
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckKey(t *testing.T) {
+	for _, key := range []string{"images/a.jpg", "a", "images/posters/tt1/abc/large.jpg"} {
+		assert.NoError(t, CheckKey(key), key)
+	}
+	for _, key := range []string{"", "/etc/passwd", "..", "../a", "a/../../b", "a//b", "a/./b", "a\\b", "a/"} {
+		assert.True(t, errors.Is(CheckKey(key), ErrInvalidKey), key)
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Put(ctx, "images/tt1/abc/large.jpg", strings.NewReader("jpeg"), "image/jpeg"))
+	assert.NoError(t, store.Put(ctx, "images/tt1/abc/large.webp", strings.NewReader("webp"), "image/webp"))
+	assert.NoError(t, store.Put(ctx, "images/tt1/abcd/large.jpg", strings.NewReader("other"), "image/jpeg"))
+
+	blob, err := store.Open(ctx, "images/tt1/abc/large.jpg")
+	assert.NoError(t, err)
+	if blob != nil {
+		data, _ := io.ReadAll(blob)
+		blob.Close()
+		assert.Equal(t, "jpeg", string(data))
+		assert.Equal(t, int64(4), blob.Size)
+		assert.Equal(t, "image/jpeg", blob.ContentType)
+	}
+
+	info, err := store.Stat(ctx, "images/tt1/abc/large.webp")
+	assert.NoError(t, err)
+	if info != nil {
+		assert.Equal(t, "image/webp", info.ContentType)
+	}
+
+	_, err = store.Open(ctx, "../outside")
+	assert.True(t, errors.Is(err, ErrInvalidKey))
+	_, err = store.Open(ctx, "images/missing.jpg")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	// The prefix only matches whole path segments.
+	assert.NoError(t, store.DeletePrefix(ctx, "images/tt1/abc"))
+	_, err = store.Stat(ctx, "images/tt1/abc/large.jpg")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	_, err = store.Stat(ctx, "images/tt1/abcd/large.jpg")
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Delete(ctx, "images/tt1/abcd/large.jpg"))
+	_, err = store.Stat(ctx, "images/tt1/abcd/large.jpg")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestContentTypeOf(t *testing.T) {
+	assert.Equal(t, "application/vnd.apple.mpegurl", ContentTypeOf("media/tt1/hls/master.m3u8"))
+	assert.Equal(t, "video/mp2t", ContentTypeOf("media/tt1/hls/720p/seg001.TS"))
+	assert.Equal(t, "image/jpeg", ContentTypeOf("images/a/large.jpg"))
+	assert.Equal(t, "application/octet-stream", ContentTypeOf("media/tt1/master"))
+}
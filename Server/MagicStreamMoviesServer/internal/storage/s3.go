@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type s3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Store keeps blobs in a bucket of an S3-compatible service, such as AWS S3, MinIO or
+// Cloudflare R2. The bucket must exist.
+func NewS3Store(endpoint, accessKey, secretKey, bucket, region string, useSSL bool) (BlobStore, error) {
+	if endpoint == "" || bucket == "" {
+		return nil, errors.New("could not read S3_ENDPOINT and S3_BUCKET")
+	}
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s3Store{client: client, bucket: bucket}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	// A size of -1 makes the client upload in parts, so r does not have to be buffered.
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, -1, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *s3Store) Open(ctx context.Context, key string) (*Blob, error) {
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+	return &Blob{ReadSeekCloser: object, BlobInfo: *info}, nil
+}
+
+func (s *s3Store) Stat(ctx context.Context, key string) (*BlobInfo, error) {
+	if err := CheckKey(key); err != nil {
+		return nil, err
+	}
+	stat, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+	contentType := stat.ContentType
+	if contentType == "" {
+		contentType = ContentTypeOf(key)
+	}
+	return &BlobInfo{Key: key, Size: stat.Size, ContentType: contentType, ModTime: stat.LastModified}, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *s3Store) DeletePrefix(ctx context.Context, prefix string) error {
+	if err := CheckKey(prefix); err != nil {
+		return err
+	}
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix + "/", Recursive: true})
+	for result := range s.client.RemoveObjects(ctx, s.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+func s3Error(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
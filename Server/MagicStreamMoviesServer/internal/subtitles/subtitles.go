@@ -0,0 +1,225 @@
+// Package subtitles reads caption files in SRT or WebVTT and writes them as WebVTT, the format
+// browsers play through <track> elements.
+package subtitles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalid is returned for files that are neither valid SRT nor valid WebVTT.
+var ErrInvalid = errors.New("invalid subtitles")
+
+var (
+	vttHeader = regexp.MustCompile(`^WEBVTT([ \t].*)?$`)
+	// WebVTT timestamps may leave the hours out; the cue settings follow the end time.
+	vttTiming = regexp.MustCompile(`^(?:(\d{2,}):)?(\d{2}):(\d{2})\.(\d{3})[ \t]+-->[ \t]+(?:(\d{2,}):)?(\d{2}):(\d{2})\.(\d{3})(?:[ \t]+(.*))?$`)
+	// SRT timestamps use a comma, though some writers use a dot, and may be followed by
+	// display coordinates, which are dropped.
+	srtTiming = regexp.MustCompile(`^(\d{1,3}):(\d{2}):(\d{2})[,.](\d{3})[ \t]*-->[ \t]*(\d{1,3}):(\d{2}):(\d{2})[,.](\d{3})(?:[ \t].*)?$`)
+	// srtMarkup are the <font> tags and {\an8} style overrides of SRT, which WebVTT lacks.
+	srtMarkup = regexp.MustCompile(`</?font[^>]*>|\{\\[^}]*\}`)
+)
+
+// Cue is a caption shown from Start to End.
+type Cue struct {
+	ID    string
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// ToWebVTT converts an SRT file to WebVTT, or checks and returns a WebVTT file, told apart by
+// the WEBVTT header. Either way every cue's timing is checked and the result has LF line
+// endings and no byte order mark.
+func ToWebVTT(data []byte) ([]byte, error) {
+	text, err := normalize(data)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(text, "\n")
+	if vttHeader.MatchString(lines[0]) {
+		if _, err := parseWebVTT(lines); err != nil {
+			return nil, err
+		}
+		return []byte(strings.TrimRight(text, "\n") + "\n"), nil
+	}
+
+	cues, err := parseSRT(lines)
+	if err != nil {
+		return nil, err
+	}
+	return WriteWebVTT(cues), nil
+}
+
+// WriteWebVTT writes cues as a WebVTT file.
+func WriteWebVTT(cues []Cue) []byte {
+	var b bytes.Buffer
+	b.WriteString("WEBVTT\n")
+	for _, cue := range cues {
+		b.WriteString("\n")
+		if cue.ID != "" {
+			b.WriteString(cue.ID + "\n")
+		}
+		fmt.Fprintf(&b, "%s --> %s\n%s\n", formatTimestamp(cue.Start), formatTimestamp(cue.End), cue.Text)
+	}
+	return b.Bytes()
+}
+
+func normalize(data []byte) (string, error) {
+	if !utf8.Valid(data) {
+		return "", fmt.Errorf("%w: subtitles must be UTF-8", ErrInvalid)
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("%w: the file is empty", ErrInvalid)
+	}
+	return text, nil
+}
+
+// block is a run of non-blank lines, with the number of its first line.
+type block struct {
+	line  int
+	lines []string
+}
+
+func blocks(lines []string) []block {
+	var result []block
+	var current *block
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			result = append(result, block{line: i + 1})
+			current = &result[len(result)-1]
+		}
+		current.lines = append(current.lines, line)
+	}
+	return result
+}
+
+func parseSRT(lines []string) ([]Cue, error) {
+	var cues []Cue
+	for _, b := range blocks(lines) {
+		// The sequence number comes first, though some writers leave it out.
+		id, timing := "", 0
+		if !strings.Contains(b.lines[0], "-->") {
+			id, timing = strings.TrimSpace(b.lines[0]), 1
+		}
+		if timing >= len(b.lines) {
+			return nil, fmt.Errorf("%w: line %d: cue has no timing", ErrInvalid, b.line)
+		}
+		match := srtTiming.FindStringSubmatch(strings.TrimSpace(b.lines[timing]))
+		if match == nil {
+			return nil, fmt.Errorf("%w: line %d: invalid timing %q", ErrInvalid, b.line+timing, b.lines[timing])
+		}
+		cue, err := newCue(id, match[1:5], match[5:9], b.line+timing)
+		if err != nil {
+			return nil, err
+		}
+
+		text := make([]string, 0, len(b.lines)-timing-1)
+		for _, line := range b.lines[timing+1:] {
+			line = strings.ReplaceAll(srtMarkup.ReplaceAllString(line, ""), "-->", "--&gt;")
+			text = append(text, line)
+		}
+		cue.Text = strings.Join(text, "\n")
+		if strings.TrimSpace(cue.Text) == "" {
+			continue
+		}
+		cues = append(cues, cue)
+	}
+	if len(cues) == 0 {
+		return nil, fmt.Errorf("%w: no cues", ErrInvalid)
+	}
+	return cues, nil
+}
+
+func parseWebVTT(lines []string) ([]Cue, error) {
+	var cues []Cue
+	// The first block is the header.
+	for _, b := range blocks(lines)[1:] {
+		first := b.lines[0]
+		if isVTTBlock(first, "NOTE") || isVTTBlock(first, "STYLE") || isVTTBlock(first, "REGION") {
+			continue
+		}
+		id, timing := "", 0
+		if !strings.Contains(first, "-->") {
+			id, timing = first, 1
+		}
+		if timing >= len(b.lines) {
+			return nil, fmt.Errorf("%w: line %d: cue has no timing", ErrInvalid, b.line)
+		}
+		match := vttTiming.FindStringSubmatch(b.lines[timing])
+		if match == nil {
+			return nil, fmt.Errorf("%w: line %d: invalid timing %q", ErrInvalid, b.line+timing, b.lines[timing])
+		}
+		cue, err := newCue(id, match[1:5], match[5:9], b.line+timing)
+		if err != nil {
+			return nil, err
+		}
+		cue.Text = strings.Join(b.lines[timing+1:], "\n")
+		cues = append(cues, cue)
+	}
+	if len(cues) == 0 {
+		return nil, fmt.Errorf("%w: no cues", ErrInvalid)
+	}
+	return cues, nil
+}
+
+func isVTTBlock(line string, keyword string) bool {
+	rest, ok := strings.CutPrefix(line, keyword)
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+func newCue(id string, start []string, end []string, line int) (Cue, error) {
+	cue := Cue{ID: id}
+	var err error
+	if cue.Start, err = parseTimestamp(start); err != nil {
+		return Cue{}, fmt.Errorf("%w: line %d: %v", ErrInvalid, line, err)
+	}
+	if cue.End, err = parseTimestamp(end); err != nil {
+		return Cue{}, fmt.Errorf("%w: line %d: %v", ErrInvalid, line, err)
+	}
+	if cue.End <= cue.Start {
+		return Cue{}, fmt.Errorf("%w: line %d: cue must end after it starts", ErrInvalid, line)
+	}
+	return cue, nil
+}
+
+// parseTimestamp reads hours, minutes, seconds and milliseconds; the hours may be empty.
+func parseTimestamp(parts []string) (time.Duration, error) {
+	var values [4]int
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp")
+		}
+		values[i] = value
+	}
+	if values[1] > 59 || values[2] > 59 {
+		return 0, fmt.Errorf("minutes and seconds must be below 60")
+	}
+	return time.Duration(values[0])*time.Hour +
+		time.Duration(values[1])*time.Minute +
+		time.Duration(values[2])*time.Second +
+		time.Duration(values[3])*time.Millisecond, nil
+}
+
+func formatTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
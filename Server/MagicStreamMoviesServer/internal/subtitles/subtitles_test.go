@@ -0,0 +1,57 @@
+package subtitles
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToWebVTT_ConvertsSRT(t *testing.T) {
+	srt := "\ufeff1\r\n00:00:01,000 --> 00:00:04,250\r\nHello <i>there</i>\r\n\r\n" +
+		"2\r\n00:01:02,5 --> 00:01:03,000\r\n"
+	_, err := ToWebVTT([]byte(srt))
+	assert.True(t, errors.Is(err, ErrInvalid), "two digit milliseconds")
+
+	srt = "\ufeff1\r\n00:00:01,000 --> 00:00:04,250\r\nHello <i>there</i>\r\n\r\n" +
+		"2\r\n01:01:02,500 --> 01:01:03,000 X1:100 X2:200 Y1:10 Y2:20\r\n{\\an8}<font color=\"#ffff00\">Up here</font>\r\nA --> B\r\n\r\n\r\n" +
+		"3\r\n00:02:00,000 --> 00:02:01,000\r\n \r\n"
+	vtt, err := ToWebVTT([]byte(srt))
+	assert.NoError(t, err)
+	assert.Equal(t, "WEBVTT\n\n"+
+		"1\n00:00:01.000 --> 00:00:04.250\nHello <i>there</i>\n\n"+
+		"2\n01:01:02.500 --> 01:01:03.000\nUp here\nA --&gt; B\n", string(vtt))
+}
+
+func TestToWebVTT_ChecksWebVTT(t *testing.T) {
+	vtt := "WEBVTT - English\r\nKind: captions\r\n\r\n" +
+		"NOTE made by hand\r\n\r\n" +
+		"STYLE\r\n::cue { color: yellow }\r\n\r\n" +
+		"intro\r\n00:01.000 --> 00:04.000 line:0 align:start\r\n<v Anna>Hi\r\n\r\n" +
+		"01:00:00.000 --> 01:00:02.000\r\nBye\r\n"
+	out, err := ToWebVTT([]byte(vtt))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.ReplaceAll(vtt, "\r\n", "\n"), string(out))
+}
+
+func TestToWebVTT_RejectsBadFiles(t *testing.T) {
+	for name, data := range map[string]string{
+		"empty":            " \n\n",
+		"no cues":          "WEBVTT\n\nNOTE nothing here\n",
+		"comma in vtt":     "WEBVTT\n\n00:00:01,000 --> 00:00:02,000\nHi\n",
+		"missing timing":   "1\nHello\n",
+		"bad minutes":      "1\n00:61:00,000 --> 00:62:00,000\nHi\n",
+		"ends before":      "1\n00:00:05,000 --> 00:00:04,000\nHi\n",
+		"zero length":      "1\n00:00:04,000 --> 00:00:04,000\nHi\n",
+		"vtt id only":      "WEBVTT\n\nintro\n",
+		"not subtitles":    "<html><body>nope</body></html>",
+		"latin-1 encoding": "1\n00:00:01,000 --> 00:00:02,000\nCaf\xe9\n",
+	} {
+		_, err := ToWebVTT([]byte(data))
+		assert.True(t, errors.Is(err, ErrInvalid), name)
+	}
+
+	_, err := ToWebVTT([]byte("1\n00:00:01,000 --> 00:00:02,000\nok\n\n2\n00:00:03.000 -> 00:00:04.000\nbad\n"))
+	assert.EqualError(t, err, `invalid subtitles: line 6: invalid timing "00:00:03.000 -> 00:00:04.000"`)
+}
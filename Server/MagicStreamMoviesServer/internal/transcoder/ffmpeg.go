@@ -0,0 +1,254 @@
+package transcoder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	segmentSeconds = 6
+	// Thumbnails are spread evenly over the video, at most one a second.
+	thumbnailCount = 10
+	thumbnailWidth = 320
+)
+
+// FFmpeg transcodes by running local ffmpeg and ffprobe binaries, one ffmpeg run per rendition.
+type FFmpeg struct {
+	ffmpeg  string
+	ffprobe string
+	ladder  []Rendition
+}
+
+func NewFFmpeg(ffmpegPath string, ffprobePath string, ladder []Rendition) (*FFmpeg, error) {
+	ffmpeg, err := exec.LookPath(ffmpegPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not find ffmpeg: %w", err)
+	}
+	ffprobe, err := exec.LookPath(ffprobePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not find ffprobe: %w", err)
+	}
+	return &FFmpeg{ffmpeg: ffmpeg, ffprobe: ffprobe, ladder: ladder}, nil
+}
+
+// source describes the video being transcoded.
+type source struct {
+	Width    int
+	Height   int
+	Duration float64 // seconds
+}
+
+func (f *FFmpeg) Transcode(ctx context.Context, input string, dir string, progress func(float64)) (*Output, error) {
+	src, err := f.probe(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	renditions := f.renditionsFor(src)
+	// Thumbnails are one more step, so progress only reaches 1 once everything is written.
+	steps := float64(len(renditions) + 1)
+	output := &Output{Master: MasterPlaylist}
+	for i, r := range renditions {
+		variant := Variant{
+			Name:      r.Name,
+			Width:     scaledWidth(src, r.Height),
+			Height:    r.Height,
+			Bandwidth: int(float64(r.VideoBitrate)*1.1) + r.AudioBitrate,
+			Playlist:  r.Name + "/index.m3u8",
+		}
+		if err := os.MkdirAll(filepath.Join(dir, r.Name), 0o755); err != nil {
+			return nil, err
+		}
+		step := float64(i)
+		err := f.run(ctx, src.Duration, func(done float64) { progress((step + done) / steps) }, renditionArgs(input, dir, r, variant.Width)...)
+		if err != nil {
+			return nil, fmt.Errorf("transcode %s: %w", r.Name, err)
+		}
+		output.Variants = append(output.Variants, variant)
+	}
+	if err := WriteMaster(dir, output.Variants); err != nil {
+		return nil, err
+	}
+
+	output.Thumbnails, err = f.thumbnails(ctx, input, dir, src, func(done float64) { progress((steps - 1 + done) / steps) })
+	if err != nil {
+		return nil, err
+	}
+	progress(1)
+	return output, nil
+}
+
+func (f *FFmpeg) probe(ctx context.Context, input string) (*source, error) {
+	out, err := exec.CommandContext(ctx, f.ffprobe, "-v", "error", "-select_streams", "v:0",
+		"-show_entries", "stream=width,height:format=duration", "-of", "json", input).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("ffprobe: %w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("ffprobe: %w", err)
+	}
+
+	var result struct {
+		Streams []struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"streams"`
+		Format struct {
+			Duration string `json:"duration"`
+		} `json:"format"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("ffprobe: %w", err)
+	}
+	if len(result.Streams) == 0 || result.Streams[0].Width == 0 || result.Streams[0].Height == 0 {
+		return nil, fmt.Errorf("no video stream in the source")
+	}
+	duration, _ := strconv.ParseFloat(result.Format.Duration, 64)
+	return &source{Width: result.Streams[0].Width, Height: result.Streams[0].Height, Duration: duration}, nil
+}
+
+// renditionsFor leaves out rungs taller than the source, as scaling up only wastes bits. A
+// source shorter than every rung still gets the smallest one.
+func (f *FFmpeg) renditionsFor(src *source) []Rendition {
+	var renditions []Rendition
+	for _, r := range f.ladder {
+		if r.Height <= src.Height {
+			renditions = append(renditions, r)
+		}
+	}
+	if len(renditions) == 0 {
+		renditions = f.ladder[:1]
+	}
+	return renditions
+}
+
+// scaledWidth keeps the source's aspect ratio at height, rounded to the even width H.264 needs.
+func scaledWidth(src *source, height int) int {
+	return int(math.Round(float64(src.Width)*float64(height)/float64(src.Height)/2)) * 2
+}
+
+func renditionArgs(input string, dir string, r Rendition, width int) []string {
+	return []string{
+		"-i", input,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-vf", fmt.Sprintf("scale=%d:%d,setsar=1", width, r.Height),
+		"-c:v", "libx264", "-preset", "veryfast", "-profile:v", "main", "-pix_fmt", "yuv420p",
+		"-b:v", strconv.Itoa(r.VideoBitrate),
+		"-maxrate", strconv.Itoa(r.VideoBitrate * 107 / 100),
+		"-bufsize", strconv.Itoa(r.VideoBitrate * 3 / 2),
+		// Keyframes on segment boundaries let players switch renditions between segments.
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentSeconds), "-sc_threshold", "0",
+		"-c:a", "aac", "-b:a", strconv.Itoa(r.AudioBitrate), "-ac", "2",
+		"-f", "hls", "-hls_time", strconv.Itoa(segmentSeconds), "-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(dir, r.Name, "segment_%04d.ts"),
+		filepath.Join(dir, r.Name, "index.m3u8"),
+	}
+}
+
+func (f *FFmpeg) thumbnails(ctx context.Context, input string, dir string, src *source, progress func(float64)) ([]string, error) {
+	thumbDir := filepath.Join(dir, "thumbs")
+	if err := os.MkdirAll(thumbDir, 0o755); err != nil {
+		return nil, err
+	}
+	interval := math.Max(src.Duration/thumbnailCount, 1)
+	err := f.run(ctx, src.Duration, progress,
+		"-i", input,
+		"-map", "0:v:0",
+		"-vf", fmt.Sprintf("fps=%.6f,scale=%d:-2", 1/interval, thumbnailWidth),
+		"-frames:v", strconv.Itoa(thumbnailCount), "-q:v", "5",
+		filepath.Join(thumbDir, "thumb_%03d.jpg"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("thumbnails: %w", err)
+	}
+
+	entries, err := os.ReadDir(thumbDir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, "thumbs/"+entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// run runs ffmpeg with args, reporting the share of the source processed from its progress
+// output.
+func (f *FFmpeg) run(ctx context.Context, duration float64, progress func(float64), args ...string) error {
+	args = append([]string{"-hide_banner", "-nostdin", "-y", "-nostats", "-progress", "pipe:1"}, args...)
+	cmd := exec.CommandContext(ctx, f.ffmpeg, args...)
+	stderr := &tailBuffer{max: 2048}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if done, ok := parseProgress(scanner.Text(), duration); ok {
+			progress(done)
+		}
+	}
+	// Keep ffmpeg from blocking on a full pipe if the scanner gave up on a line.
+	io.Copy(io.Discard, stdout)
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("ffmpeg: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// parseProgress reads the position from a line of ffmpeg's -progress output, as a share of
+// duration.
+func parseProgress(line string, duration float64) (float64, bool) {
+	key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+	// out_time_ms is in microseconds too, despite its name.
+	if !ok || (key != "out_time_us" && key != "out_time_ms") || duration <= 0 {
+		return 0, false
+	}
+	us, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return math.Min(math.Max(float64(us)/1e6/duration, 0), 1), true
+}
+
+// tailBuffer keeps the last max bytes written to it, enough of ffmpeg's log to explain a
+// failure.
+type tailBuffer struct {
+	buf []byte
+	max int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.max {
+		t.buf = t.buf[len(t.buf)-t.max:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	return string(t.buf)
+}
@@ -0,0 +1,102 @@
+// Package transcoder turns uploaded video into an adaptive HLS ladder, a set of renditions at
+// different sizes and bitrates that players switch between, plus thumbnails.
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirolad/MagicStreamMovies/Server/MagicStreamMovies/Server/internal/config"
+)
+
+// MasterPlaylist is the name of the master playlist written at the top of the output.
+const MasterPlaylist = "master.m3u8"
+
+// Rendition is one rung of the ladder.
+type Rendition struct {
+	Name   string
+	Height int
+	// Bitrates are in bits per second.
+	VideoBitrate int
+	AudioBitrate int
+}
+
+// Renditions are the rungs a ladder can be built from, by name.
+var Renditions = map[string]Rendition{
+	"240p":  {Name: "240p", Height: 240, VideoBitrate: 400_000, AudioBitrate: 64_000},
+	"360p":  {Name: "360p", Height: 360, VideoBitrate: 800_000, AudioBitrate: 96_000},
+	"480p":  {Name: "480p", Height: 480, VideoBitrate: 1_400_000, AudioBitrate: 128_000},
+	"720p":  {Name: "720p", Height: 720, VideoBitrate: 2_800_000, AudioBitrate: 128_000},
+	"1080p": {Name: "1080p", Height: 1080, VideoBitrate: 5_000_000, AudioBitrate: 192_000},
+}
+
+// ParseLadder looks up renditions by name and orders them from smallest to largest.
+func ParseLadder(names []string) ([]Rendition, error) {
+	var ladder []Rendition
+	for _, name := range names {
+		rendition, ok := Renditions[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown rendition %q", name)
+		}
+		if !slices.ContainsFunc(ladder, func(r Rendition) bool { return r.Name == rendition.Name }) {
+			ladder = append(ladder, rendition)
+		}
+	}
+	if len(ladder) == 0 {
+		return nil, fmt.Errorf("no renditions configured")
+	}
+	slices.SortFunc(ladder, func(a, b Rendition) int { return a.Height - b.Height })
+	return ladder, nil
+}
+
+// Variant is a rendition as written, with its size worked out from the source's aspect ratio.
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+	// Bandwidth is the peak bitrate advertised in the master playlist, in bits per second.
+	Bandwidth int
+	// Playlist is the variant playlist's path relative to the output directory.
+	Playlist string
+}
+
+// Output lists what a transcode wrote, by paths relative to the output directory.
+type Output struct {
+	Master     string
+	Variants   []Variant
+	Thumbnails []string
+}
+
+// Transcoder converts a video file to HLS.
+type Transcoder interface {
+	// Transcode writes a variant playlist and segments per rendition under dir, a master
+	// playlist listing them and thumbnails, calling progress with the share done from 0 to 1.
+	Transcode(ctx context.Context, input string, dir string, progress func(float64)) (*Output, error)
+}
+
+// New builds an ffmpeg transcoder for the ladder in cfg.TranscodeRenditions.
+func New(cfg *config.Config) (Transcoder, error) {
+	ladder, err := ParseLadder(cfg.TranscodeRenditions)
+	if err != nil {
+		return nil, err
+	}
+	ffmpeg, err := NewFFmpeg(cfg.FFmpegPath, cfg.FFprobePath, ladder)
+	if err != nil {
+		return nil, err
+	}
+	return ffmpeg, nil
+}
+
+// WriteMaster writes the master playlist listing variants to dir.
+func WriteMaster(dir string, variants []Variant) error {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+	for _, v := range variants {
+		fmt.Fprintf(&b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d,NAME=%q\n%s\n", v.Bandwidth, v.Width, v.Height, v.Name, filepath.ToSlash(v.Playlist))
+	}
+	return os.WriteFile(filepath.Join(dir, MasterPlaylist), []byte(b.String()), 0o644)
+}
@@ -0,0 +1,120 @@
+package transcoder
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLadder(t *testing.T) {
+	ladder, err := ParseLadder([]string{"1080p", "360P", "720p", "360p"})
+	assert.NoError(t, err)
+	var names []string
+	for _, r := range ladder {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"360p", "720p", "1080p"}, names)
+
+	_, err = ParseLadder([]string{"4k"})
+	assert.Error(t, err)
+	_, err = ParseLadder(nil)
+	assert.Error(t, err)
+}
+
+func TestWriteMaster(t *testing.T) {
+	dir := t.TempDir()
+	err := WriteMaster(dir, []Variant{
+		{Name: "360p", Width: 640, Height: 360, Bandwidth: 976000, Playlist: "360p/index.m3u8"},
+		{Name: "720p", Width: 1280, Height: 720, Bandwidth: 3208000, Playlist: "720p/index.m3u8"},
+	})
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, MasterPlaylist))
+	assert.NoError(t, err)
+	assert.Equal(t, "#EXTM3U\n#EXT-X-VERSION:3\n"+
+		"#EXT-X-STREAM-INF:BANDWIDTH=976000,RESOLUTION=640x360,NAME=\"360p\"\n360p/index.m3u8\n"+
+		"#EXT-X-STREAM-INF:BANDWIDTH=3208000,RESOLUTION=1280x720,NAME=\"720p\"\n720p/index.m3u8\n", string(data))
+}
+
+func TestParseProgress(t *testing.T) {
+	done, ok := parseProgress("out_time_us=5000000", 10)
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, done)
+
+	done, ok = parseProgress("out_time_ms=20000000\n", 10)
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, done)
+
+	for _, line := range []string{"out_time_us=N/A", "frame=12", "progress=continue", ""} {
+		_, ok := parseProgress(line, 10)
+		assert.False(t, ok, line)
+	}
+	_, ok = parseProgress("out_time_us=5000000", 0)
+	assert.False(t, ok)
+}
+
+func TestRenditionsFor(t *testing.T) {
+	ladder, _ := ParseLadder([]string{"360p", "720p", "1080p"})
+	f := &FFmpeg{ladder: ladder}
+
+	renditions := f.renditionsFor(&source{Width: 1280, Height: 720})
+	assert.Len(t, renditions, 2)
+	assert.Equal(t, "720p", renditions[1].Name)
+
+	renditions = f.renditionsFor(&source{Width: 320, Height: 240})
+	assert.Len(t, renditions, 1)
+	assert.Equal(t, "360p", renditions[0].Name)
+
+	assert.Equal(t, 640, scaledWidth(&source{Width: 1920, Height: 1080}, 360))
+	assert.Equal(t, 480, scaledWidth(&source{Width: 320, Height: 240}, 360))
+}
+
+func TestFFmpegTranscode(t *testing.T) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+	ladder, _ := ParseLadder([]string{"240p", "360p", "720p"})
+	f, err := NewFFmpeg("ffmpeg", "ffprobe", ladder)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// A two second 320x240 clip with a tone, small enough to transcode in a moment.
+	ctx := context.Background()
+	input := filepath.Join(t.TempDir(), "sample.mp4")
+	out, err := exec.CommandContext(ctx, "ffmpeg", "-hide_banner", "-loglevel", "error",
+		"-f", "lavfi", "-i", "testsrc=size=320x240:rate=24:duration=2",
+		"-f", "lavfi", "-i", "sine=frequency=440:duration=2",
+		"-c:v", "libx264", "-pix_fmt", "yuv420p", "-c:a", "aac", "-shortest", input).CombinedOutput()
+	if err != nil {
+		t.Skipf("could not generate a sample clip: %v: %s", err, out)
+	}
+
+	dir := t.TempDir()
+	var reported []float64
+	output, err := f.Transcode(ctx, input, dir, func(done float64) { reported = append(reported, done) })
+	assert.NoError(t, err)
+	if output == nil {
+		return
+	}
+
+	// 360p and 720p are taller than the source, so only 240p is made.
+	assert.Len(t, output.Variants, 1)
+	assert.Equal(t, Variant{Name: "240p", Width: 320, Height: 240, Bandwidth: 504000, Playlist: "240p/index.m3u8"}, output.Variants[0])
+	assert.NotEmpty(t, output.Thumbnails)
+	for _, name := range append([]string{output.Master, output.Variants[0].Playlist}, output.Thumbnails...) {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+	playlist, _ := os.ReadFile(filepath.Join(dir, "240p", "index.m3u8"))
+	assert.True(t, strings.Contains(string(playlist), "segment_0000.ts"))
+	assert.True(t, strings.Contains(string(playlist), "#EXT-X-ENDLIST"))
+
+	if assert.NotEmpty(t, reported) {
+		assert.Equal(t, 1.0, reported[len(reported)-1])
+	}
+}
@@ -0,0 +1,148 @@
+// Package urlsign signs playback URLs so media players can fetch streams without sending an
+// Authorization header. A signature binds a user, a title and an expiry time, and names the key
+// that made it, so keys can be rotated without breaking URLs already handed out.
+package urlsign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query parameters carrying a signature.
+const (
+	ParamUserID    = "uid"
+	ParamExpires   = "exp"
+	ParamKeyID     = "kid"
+	ParamSignature = "sig"
+)
+
+// minSecretLength keeps secrets long enough that they cannot be guessed.
+const minSecretLength = 32
+
+var (
+	ErrMissingSignature = errors.New("signature is missing")
+	ErrInvalidSignature = errors.New("signature is invalid")
+	ErrExpired          = errors.New("signed URL has expired")
+	ErrUnknownKey       = errors.New("signing key is unknown")
+)
+
+// Key is a named signing secret.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// Signer signs with its first key and verifies with any of them. To rotate, put the new key
+// first and drop the old one once the URLs it signed have expired.
+type Signer struct {
+	keys []Key
+}
+
+// New builds a signer from "id:secret" entries, as read from STREAM_SIGNING_KEYS.
+func New(entries []string) (*Signer, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("could not read STREAM_SIGNING_KEYS")
+	}
+	keys := make([]Key, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		id, secret, ok := strings.Cut(entry, ":")
+		if !ok || id == "" || strings.ContainsAny(id, "&=?#") {
+			return nil, fmt.Errorf("signing key %q is not in id:secret form", id)
+		}
+		if len(secret) < minSecretLength {
+			return nil, fmt.Errorf("signing key %q is shorter than %d characters", id, minSecretLength)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("signing key %q is listed twice", id)
+		}
+		seen[id] = true
+		keys = append(keys, Key{ID: id, Secret: []byte(secret)})
+	}
+	return NewSigner(keys...), nil
+}
+
+// NewSigner builds a signer from keys, the first of which signs.
+func NewSigner(keys ...Key) *Signer {
+	return &Signer{keys: keys}
+}
+
+// Sign returns the query parameters that let userID play imdbID until expires.
+func (s *Signer) Sign(userID string, imdbID string, expires time.Time) url.Values {
+	key := s.keys[0]
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return url.Values{
+		ParamUserID:    {userID},
+		ParamExpires:   {exp},
+		ParamKeyID:     {key.ID},
+		ParamSignature: {sign(key.Secret, userID, imdbID, exp)},
+	}
+}
+
+// Verify checks the signature in query against imdbID and returns the user it was issued to.
+func (s *Signer) Verify(query url.Values, imdbID string, now time.Time) (string, error) {
+	userID, exp, keyID, signature := query.Get(ParamUserID), query.Get(ParamExpires), query.Get(ParamKeyID), query.Get(ParamSignature)
+	if signature == "" {
+		return "", ErrMissingSignature
+	}
+	if userID == "" || exp == "" {
+		return "", ErrInvalidSignature
+	}
+	secret, ok := s.secret(keyID)
+	if !ok {
+		return "", ErrUnknownKey
+	}
+	if !hmac.Equal([]byte(signature), []byte(sign(secret, userID, imdbID, exp))) {
+		return "", ErrInvalidSignature
+	}
+	expires, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	if now.Unix() >= expires {
+		return "", ErrExpired
+	}
+	return userID, nil
+}
+
+// Signed reports whether query carries a signature, so callers can tell signed requests from
+// ones that authenticate otherwise.
+func Signed(query url.Values) bool {
+	return query.Has(ParamSignature)
+}
+
+// Params returns just the signature parameters of query, to pass a signature on to the URLs a
+// signed playlist refers to.
+func Params(query url.Values) url.Values {
+	params := url.Values{}
+	for _, name := range []string{ParamUserID, ParamExpires, ParamKeyID, ParamSignature} {
+		if value := query.Get(name); value != "" {
+			params.Set(name, value)
+		}
+	}
+	return params
+}
+
+func (s *Signer) secret(keyID string) ([]byte, bool) {
+	for _, key := range s.keys {
+		if key.ID == keyID {
+			return key.Secret, true
+		}
+	}
+	return nil, false
+}
+
+// sign MACs the fields separated by newlines, which none of them can contain unescaped, so
+// different fields never produce the same message.
+func sign(secret []byte, userID string, imdbID string, exp string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{"stream", url.QueryEscape(userID), url.QueryEscape(imdbID), exp}, "\n")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,81 @@
+package urlsign
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	oldSecret = strings.Repeat("o", 32)
+	newSecret = strings.Repeat("n", 32)
+)
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	signer, err := New([]string{"k1:" + oldSecret})
+	assert.NoError(t, err)
+
+	query := signer.Sign("user123", "tt2543164", now.Add(time.Hour))
+	assert.Equal(t, "k1", query.Get(ParamKeyID))
+	assert.True(t, Signed(query))
+
+	userID, err := signer.Verify(query, "tt2543164", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "user123", userID)
+
+	_, err = signer.Verify(query, "tt0000001", now)
+	assert.True(t, errors.Is(err, ErrInvalidSignature), "other title")
+
+	_, err = signer.Verify(query, "tt2543164", now.Add(time.Hour))
+	assert.True(t, errors.Is(err, ErrExpired))
+
+	tampered := signer.Sign("user123", "tt2543164", now.Add(time.Hour))
+	tampered.Set(ParamUserID, "user456")
+	_, err = signer.Verify(tampered, "tt2543164", now)
+	assert.True(t, errors.Is(err, ErrInvalidSignature), "other user")
+
+	tampered = signer.Sign("user123", "tt2543164", now.Add(time.Hour))
+	tampered.Set(ParamExpires, "9999999999")
+	_, err = signer.Verify(tampered, "tt2543164", now)
+	assert.True(t, errors.Is(err, ErrInvalidSignature), "longer expiry")
+
+	tampered.Del(ParamSignature)
+	_, err = signer.Verify(tampered, "tt2543164", now)
+	assert.True(t, errors.Is(err, ErrMissingSignature))
+	assert.False(t, Signed(tampered))
+}
+
+func TestKeyRotation(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	before, err := New([]string{"k1:" + oldSecret})
+	assert.NoError(t, err)
+	rotated, err := New([]string{"k2:" + newSecret, "k1:" + oldSecret})
+	assert.NoError(t, err)
+	after, err := New([]string{"k2:" + newSecret})
+	assert.NoError(t, err)
+
+	issued := before.Sign("user123", "tt2543164", now.Add(time.Hour))
+	_, err = rotated.Verify(issued, "tt2543164", now)
+	assert.NoError(t, err, "old URLs keep working during rotation")
+	assert.Equal(t, "k2", rotated.Sign("user123", "tt2543164", now.Add(time.Hour)).Get(ParamKeyID))
+
+	_, err = after.Verify(issued, "tt2543164", now)
+	assert.True(t, errors.Is(err, ErrUnknownKey), "retired keys are rejected")
+}
+
+func TestNew_RejectsBadKeys(t *testing.T) {
+	for _, entries := range [][]string{
+		nil,
+		{oldSecret},
+		{"k1:short"},
+		{":" + oldSecret},
+		{"k1:" + oldSecret, "k1:" + newSecret},
+	} {
+		_, err := New(entries)
+		assert.Error(t, err, entries)
+	}
+}
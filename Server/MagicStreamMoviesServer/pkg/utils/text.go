@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+var stopWords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "but": {}, "by": {},
+	"for": {}, "from": {}, "has": {}, "have": {}, "he": {}, "her": {}, "his": {}, "in": {},
+	"is": {}, "it": {}, "its": {}, "of": {}, "on": {}, "or": {}, "she": {}, "that": {}, "the": {},
+	"their": {}, "they": {}, "this": {}, "to": {}, "was": {}, "were": {}, "will": {}, "with": {},
+}
+
+// Tokenize lowercases text, splits it on anything that is not a letter or digit and drops
+// stop words and single characters.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if len([]rune(f)) < 2 {
+			continue
+		}
+		if _, ok := stopWords[f]; ok {
+			continue
+		}
+		tokens = append(tokens, f)
+	}
+	return tokens
+}